@@ -1,6 +1,7 @@
 package things3
 
 import (
+	"net/url"
 	"strings"
 	"testing"
 	"time"
@@ -61,6 +62,29 @@ func TestAddTodoBuilder_NotesTooLong(t *testing.T) {
 	assert.ErrorIs(t, err, ErrNotesTooLong)
 }
 
+func TestAddTodoBuilder_AddLink(t *testing.T) {
+	scheme := newScheme()
+	thingsURL, err := scheme.AddTodo().
+		Title("Read").
+		Notes("Background").
+		AddLink("Go", "https://go.dev").
+		AddLink("", "https://example.com").
+		Build()
+	require.NoError(t, err)
+
+	_, params := parseThingsURL(t, thingsURL)
+	assert.Equal(t, "Background\n[Go](https://go.dev)\nhttps://example.com", params.Get("notes"))
+
+	todo := Todo{Notes: params.Get("notes")}
+	assert.Equal(t, []string{"https://go.dev", "https://example.com"}, todo.NoteLinks())
+}
+
+func TestAddTodoBuilder_AddLinkNotesTooLong(t *testing.T) {
+	scheme := newScheme()
+	_, err := scheme.AddTodo().Title("Test").Notes(strings.Repeat("a", 10000)).AddLink("x", "https://x.dev").Build()
+	assert.ErrorIs(t, err, ErrNotesTooLong)
+}
+
 func TestAddTodoBuilder_When(t *testing.T) {
 	scheme := newScheme()
 
@@ -215,6 +239,15 @@ func TestAddTodoBuilder_Reveal(t *testing.T) {
 	require.Equal(t, "true", params.Get("reveal"))
 }
 
+func TestAddTodoBuilder_RevealAfterCreate(t *testing.T) {
+	scheme := newScheme()
+	want, err := scheme.AddTodo().Title("Test").Reveal(true).Build()
+	require.NoError(t, err)
+	got, err := scheme.AddTodo().Title("Test").RevealAfterCreate().Build()
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
 func TestAddTodoBuilder_Titles(t *testing.T) {
 	scheme := newScheme()
 	thingsURL, err := scheme.AddTodo().Titles("Task 1", "Task 2", "Task 3").Build()
@@ -421,6 +454,30 @@ func TestAddProjectBuilder_When(t *testing.T) {
 	require.Equal(t, "2025-03-01", params.Get("when"))
 }
 
+func TestDeadline_RejectsTimeOfDay(t *testing.T) {
+	scheme := newScheme()
+	auth := scheme.WithToken("test-token")
+	withTime := time.Date(2025, 12, 31, 17, 30, 0, 0, time.Local)
+
+	_, err := scheme.AddTodo().Title("Test").Deadline(withTime).Build()
+	require.ErrorIs(t, err, ErrDeadlineHasTime)
+
+	_, err = auth.UpdateProject("uuid").Deadline(withTime).Build()
+	require.ErrorIs(t, err, ErrDeadlineHasTime)
+
+	_, err = scheme.Batch().
+		AddTodo(func(todo BatchTodoConfigurator) { todo.Title("Test").Deadline(withTime) }).
+		Build()
+	require.ErrorIs(t, err, ErrDeadlineHasTime)
+
+	// Midnight in any location is a plain date.
+	thingsURL, err := scheme.AddTodo().Title("Test").
+		Deadline(time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)).Build()
+	require.NoError(t, err)
+	_, params := parseThingsURL(t, thingsURL)
+	assert.Equal(t, "2025-12-31", params.Get("deadline"))
+}
+
 func TestAddProjectBuilder_Deadline(t *testing.T) {
 	scheme := newScheme()
 	thingsURL, err := scheme.AddProject().
@@ -561,6 +618,57 @@ func TestShowBuilder_ID(t *testing.T) {
 	require.Equal(t, "uuid-123", params.Get("id"))
 }
 
+func TestClientAppendTodo(t *testing.T) {
+	client := newTestClient(t)
+	ctx := t.Context()
+
+	adder, err := client.AppendTodo(ctx, "Project in Area 1", "Next step")
+	require.NoError(t, err)
+	thingsURL, err := adder.Build()
+	require.NoError(t, err)
+
+	cmd, params := parseThingsURL(t, thingsURL)
+	assert.Equal(t, "add", cmd)
+	assert.Equal(t, "Next step", params.Get("title"))
+	assert.Equal(t, testUUIDProjectInArea1, params.Get("list-id"))
+	assert.Empty(t, params.Get("list"), "the name is resolved, never sent")
+
+	// A substring of a title is not a match, and neither is a closed project.
+	_, err = client.AppendTodo(ctx, "Project in", "Next step")
+	require.ErrorIs(t, err, ErrProjectNotFound)
+	_, err = client.AppendTodo(ctx, "Cancelled Project in Area", "Next step")
+	require.ErrorIs(t, err, ErrProjectNotFound)
+}
+
+func TestClientAppendTodoAmbiguous(t *testing.T) {
+	dbPath := copyWritableFixture(t)
+	execFixtureSQL(t, dbPath, "UPDATE TMTask SET title = 'Project in Area 1' WHERE uuid = 'TCozQqXVbB2TJkXXXQj2H9'")
+
+	client, err := NewClient(WithDatabasePath(dbPath))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+
+	_, err = client.AppendTodo(t.Context(), "Project in Area 1", "Next step")
+	require.ErrorIs(t, err, ErrAmbiguousProject)
+}
+
+func TestShowBuilder_IDTargetsAreas(t *testing.T) {
+	client := newTestClient(t)
+	area, err := client.Areas().WithUUID(testUUIDArea1).First(t.Context())
+	require.NoError(t, err)
+
+	// Areas share the id parameter with to-dos and projects; Things picks the
+	// view from the UUID itself.
+	for _, uuid := range []string{area.UUID, testUUIDTodoInArea1, testUUIDProjectInArea1} {
+		thingsURL, err := client.ShowBuilder().ID(uuid).Build()
+		require.NoError(t, err)
+
+		cmd, params := parseThingsURL(t, thingsURL)
+		assert.Equal(t, "show", cmd)
+		assert.Equal(t, url.Values{"id": {uuid}}, params)
+	}
+}
+
 func TestShowBuilder_List(t *testing.T) {
 	tests := []struct {
 		list     ListID
@@ -770,6 +878,32 @@ func TestUpdateTodoBuilder_When(t *testing.T) {
 	require.Equal(t, "2025-01-15", params.Get("when"))
 }
 
+// When takes a time.Time on every builder; the time of day is dropped and the
+// zero time leaves when unset.
+func TestBuilders_WhenTime(t *testing.T) {
+	scheme := newScheme()
+	auth := scheme.WithToken("test-token")
+	builds := map[string]func(time.Time) (string, error){
+		"add todo":       func(tm time.Time) (string, error) { return scheme.AddTodo().Title("T").When(tm).Build() },
+		"add project":    func(tm time.Time) (string, error) { return scheme.AddProject().Title("P").When(tm).Build() },
+		"update todo":    func(tm time.Time) (string, error) { return auth.UpdateTodo("uuid").Title("T").When(tm).Build() },
+		"update project": func(tm time.Time) (string, error) { return auth.UpdateProject("uuid").Title("P").When(tm).Build() },
+	}
+	for name, build := range builds {
+		t.Run(name, func(t *testing.T) {
+			thingsURL, err := build(time.Date(2025, time.May, 4, 18, 30, 0, 0, time.Local))
+			require.NoError(t, err)
+			_, params := parseThingsURL(t, thingsURL)
+			assert.Equal(t, "2025-05-04", params.Get("when"))
+
+			thingsURL, err = build(time.Time{})
+			require.NoError(t, err)
+			_, params = parseThingsURL(t, thingsURL)
+			assert.NotContains(t, params, "when")
+		})
+	}
+}
+
 func TestUpdateTodoBuilder_WhenAnytime(t *testing.T) {
 	scheme := newScheme()
 	auth := scheme.WithToken("test-token")
@@ -781,6 +915,26 @@ func TestUpdateTodoBuilder_WhenAnytime(t *testing.T) {
 	require.Equal(t, "anytime", params.Get("when"))
 }
 
+// Moving to Someday must not leave the earlier date behind: when is a single
+// parameter and Things drops the start date itself.
+func TestUpdateTodoBuilder_WhenSomeday(t *testing.T) {
+	scheme := newScheme()
+	auth := scheme.WithToken("test-token")
+	thingsURL, err := auth.UpdateTodo("uuid").
+		When(time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)).
+		WhenSomeday().
+		Build()
+	require.NoError(t, err)
+
+	cmd, params := parseThingsURL(t, thingsURL)
+	assert.Equal(t, "update", cmd)
+	assert.Equal(t, url.Values{
+		"auth-token": {"test-token"},
+		"id":         {"uuid"},
+		"when":       {"someday"},
+	}, params)
+}
+
 func TestUpdateTodoBuilder_Deadline(t *testing.T) {
 	scheme := newScheme()
 	auth := scheme.WithToken("test-token")
@@ -939,6 +1093,21 @@ func TestUpdateTodoBuilder_ValidationError(t *testing.T) {
 // UpdateProjectBuilder Tests
 // =============================================================================
 
+func TestUpdateProjectBuilder_WhenSomeday(t *testing.T) {
+	scheme := newScheme()
+	auth := scheme.WithToken("test-token")
+	thingsURL, err := auth.UpdateProject("uuid").
+		WhenInDays(3).
+		WhenSomeday().
+		Build()
+	require.NoError(t, err)
+
+	cmd, params := parseThingsURL(t, thingsURL)
+	assert.Equal(t, "update-project", cmd)
+	assert.Equal(t, "someday", params.Get("when"))
+	assert.Len(t, params, 3, "only auth-token, id and when")
+}
+
 func TestUpdateProjectBuilder_Title(t *testing.T) {
 	scheme := newScheme()
 	auth := scheme.WithToken("test-token")
@@ -1180,6 +1349,44 @@ func TestBatchBuilder_Reveal(t *testing.T) {
 	require.True(t, params.Has("data"))
 }
 
+// Things reveals the first entry of the data array, so insertion order is
+// the only way to pick which item gets revealed.
+func TestBatchBuilder_RevealTargetsFirstAddedItem(t *testing.T) {
+	scheme := newScheme()
+	thingsURL, err := scheme.Batch().
+		AddProject(func(p BatchProjectConfigurator) { p.Title("Open me") }).
+		AddTodo(func(todo BatchTodoConfigurator) { todo.Title("Second") }).
+		AddTodo(func(todo BatchTodoConfigurator) { todo.Title("Third") }).
+		Reveal(true).
+		Build()
+	require.NoError(t, err)
+
+	items := parseJSONItems(t, thingsURL)
+	require.Len(t, items, 3)
+	assert.Equal(t, "Open me", items[0].Attributes["title"])
+	assert.Equal(t, "Second", items[1].Attributes["title"])
+	assert.Equal(t, "Third", items[2].Attributes["title"])
+}
+
+func TestBatchBuilder_DefaultArea(t *testing.T) {
+	scheme := newScheme()
+	thingsURL, err := scheme.Batch().
+		DefaultArea("Work").
+		AddProject(func(p BatchProjectConfigurator) { p.Title("Inherits") }).
+		AddProject(func(p BatchProjectConfigurator) { p.Title("Named").Area("Home") }).
+		AddProject(func(p BatchProjectConfigurator) { p.Title("By ID").AreaID("area-1") }).
+		AddTodo(func(todo BatchTodoConfigurator) { todo.Title("Loose") }).
+		Build()
+	require.NoError(t, err)
+
+	require.Equal(t, []JSONItem{
+		{Type: JSONItemTypeProject, Attributes: map[string]any{"title": "Inherits", "area": "Work"}},
+		{Type: JSONItemTypeProject, Attributes: map[string]any{"title": "Named", "area": "Home"}},
+		{Type: JSONItemTypeProject, Attributes: map[string]any{"title": "By ID", "area-id": "area-1"}},
+		{Type: JSONItemTypeTodo, Attributes: map[string]any{"title": "Loose"}},
+	}, parseJSONItems(t, thingsURL))
+}
+
 func TestBatchBuilder_NoItems(t *testing.T) {
 	scheme := newScheme()
 	_, err := scheme.Batch().Build()
@@ -1664,6 +1871,37 @@ func Test_batchProjectBuilder_Todos(t *testing.T) {
 	}}, parseJSONItems(t, thingsURL))
 }
 
+func Test_batchProjectBuilder_HeadingsInOrder(t *testing.T) {
+	scheme := newScheme()
+	thingsURL, err := scheme.Batch().
+		AddProject(func(project BatchProjectConfigurator) {
+			project.Title("Launch").
+				AddHeading("Heading A").
+				Todos(
+					func(todo BatchTodoConfigurator) { todo.Title("todo1") },
+					func(todo BatchTodoConfigurator) { todo.Title("todo2") },
+				).
+				AddHeading("Heading B").
+				AddTodo(func(todo BatchTodoConfigurator) { todo.Title("todo3") })
+		}).
+		Build()
+	require.NoError(t, err)
+
+	require.Equal(t, []JSONItem{{
+		Type: JSONItemTypeProject,
+		Attributes: map[string]any{
+			"title": "Launch",
+			"items": []any{
+				map[string]any{"type": "heading", "attributes": map[string]any{"title": "Heading A"}},
+				map[string]any{"type": "to-do", "attributes": map[string]any{"title": "todo1"}},
+				map[string]any{"type": "to-do", "attributes": map[string]any{"title": "todo2"}},
+				map[string]any{"type": "heading", "attributes": map[string]any{"title": "Heading B"}},
+				map[string]any{"type": "to-do", "attributes": map[string]any{"title": "todo3"}},
+			},
+		},
+	}}, parseJSONItems(t, thingsURL))
+}
+
 // TestbatchProjectBuilder_Notes tests adding project notes
 func Test_batchProjectBuilder_Notes(t *testing.T) {
 	scheme := newScheme()
@@ -1941,6 +2179,23 @@ func TestAuthBatchBuilder_Reveal(t *testing.T) {
 	require.Equal(t, "true", params.Get("reveal"))
 }
 
+// TestAuthBatchBuilder_DefaultArea tests that updates keep their area
+func TestAuthBatchBuilder_DefaultArea(t *testing.T) {
+	scheme := newScheme()
+	auth := scheme.WithToken("test-token")
+	thingsURL, err := auth.Batch().
+		DefaultArea("Work").
+		AddProject(func(p BatchProjectConfigurator) { p.Title("New") }).
+		UpdateProject("proj-1", func(p BatchProjectConfigurator) { p.Title("Renamed") }).
+		Build()
+	require.NoError(t, err)
+
+	items := parseJSONItems(t, thingsURL)
+	require.Len(t, items, 2)
+	assert.Equal(t, "Work", items[0].Attributes["area"])
+	assert.NotContains(t, items[1].Attributes, "area")
+}
+
 // TestAuthBatchBuilder_CreateOnly tests create-only operations don't need auth token
 func TestAuthBatchBuilder_CreateOnly(t *testing.T) {
 	scheme := newScheme()
@@ -2198,6 +2453,23 @@ func TestAddTodoBuilder_Reminder_WithWhenTime(t *testing.T) {
 	assert.Equal(t, "2025-03-15@09:00", params.Get("when"))
 }
 
+func TestAddTodoBuilder_Reminder_WithToday(t *testing.T) {
+	scheme := newScheme()
+	today := Today()
+	for name, b := range map[string]URLBuilder{
+		"todo":    scheme.AddTodo().Title("Standup").When(today).Reminder(14, 30),
+		"project": scheme.AddProject().Title("Sprint").When(today).Reminder(14, 30),
+	} {
+		t.Run(name, func(t *testing.T) {
+			thingsURL, err := b.Build()
+			require.NoError(t, err)
+
+			_, params := parseThingsURL(t, thingsURL)
+			assert.Equal(t, today.Format(time.DateOnly)+"@14:30", params.Get("when"))
+		})
+	}
+}
+
 func TestAddTodoBuilder_Reminder_DefaultsToToday(t *testing.T) {
 	scheme := newScheme()
 	thingsURL, err := scheme.AddTodo().
@@ -2,9 +2,12 @@ package things3
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"net/url"
 	"sync"
+	"time"
 
 	"github.com/moond4rk/things3/internal/database"
 	"github.com/moond4rk/things3/internal/scheme"
@@ -44,6 +47,9 @@ type Client struct {
 	// Token management with mutex (not sync.Once to allow retry on transient failures)
 	tokenMu    sync.Mutex
 	tokenCache string
+
+	watchInterval time.Duration
+	clock         func() time.Time
 }
 
 // NewClient creates a new unified Things 3 client.
@@ -78,18 +84,18 @@ func NewClient(opts ...ClientOption) (*Client, error) {
 	if options.background {
 		schemeOpts = append(schemeOpts, scheme.WithBackground())
 	}
-
-	// Build DB options
-	var dbOpts []database.Option
-	if options.databasePath != "" {
-		dbOpts = append(dbOpts, database.WithPath(options.databasePath))
+	if options.openMethod != OpenMethodAuto {
+		schemeOpts = append(schemeOpts, scheme.WithOpenMethod(options.openMethod))
+	}
+	if options.timeout > 0 {
+		schemeOpts = append(schemeOpts, scheme.WithExecuteTimeout(options.timeout))
 	}
-	if options.printSQL {
-		dbOpts = append(dbOpts, database.WithPrintSQL(options.printSQL))
+	if options.clock != nil {
+		schemeOpts = append(schemeOpts, scheme.WithClock(options.clock))
 	}
 
 	// Create DB connection
-	d, err := newDB(dbOpts...)
+	d, err := newDB(options.databaseOptions()...)
 	if err != nil {
 		return nil, err
 	}
@@ -98,8 +104,10 @@ func NewClient(opts ...ClientOption) (*Client, error) {
 	s := scheme.New(schemeOpts...)
 
 	client := &Client{
-		database: d,
-		scheme:   s,
+		database:      d,
+		scheme:        s,
+		watchInterval: options.watchInterval,
+		clock:         options.clock,
 	}
 
 	// Preload token if requested
@@ -113,6 +121,27 @@ func NewClient(opts ...ClientOption) (*Client, error) {
 	return client, nil
 }
 
+// CheckDatabaseAccess verifies that the Things database can be located and
+// read with the given options, without opening a connection. Call it at
+// startup to tell users what to fix before any query fails:
+//
+//   - ErrThingsNotInstalled: Things 3 has never been installed for this user.
+//   - ErrFullDiskAccessRequired: the database exists but macOS denies access.
+//   - ErrDatabaseNotFound: Things is installed but no database was found.
+//
+// Example:
+//
+//	if err := things3.CheckDatabaseAccess(); errors.Is(err, things3.ErrFullDiskAccessRequired) {
+//	    fmt.Println("Grant Full Disk Access to this terminal and try again.")
+//	}
+func CheckDatabaseAccess(opts ...ClientOption) error {
+	options := &clientOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return database.CheckAccess(options.databaseOptions()...)
+}
+
 // Close closes the database connection.
 func (c *Client) Close() error {
 	if c.database != nil {
@@ -121,6 +150,17 @@ func (c *Client) Close() error {
 	return nil
 }
 
+// SQLDB returns the client's underlying database handle, an escape hatch for
+// custom read queries that the builders do not cover. It is not part of the
+// supported API: the Things schema is undocumented and may change with app
+// updates, and SQL run through the handle bypasses the library's date
+// decoding and filters. The connection is opened with mode=ro, so writes fail,
+// and Things must never be written to outside the URL scheme regardless. The
+// handle is owned by the Client; do not close it, use Client.Close.
+func (c *Client) SQLDB() *sql.DB {
+	return c.database.inner.SQLDB()
+}
+
 // ============================================================================
 // Token Management
 // ============================================================================
@@ -166,12 +206,16 @@ func (c *Client) Token(ctx context.Context) (string, error) {
 // Query Operations - Query Builders
 // ============================================================================
 
-// Todos creates a new TodoQueryBuilder for querying todos.
+// Todos creates a new TodoQueryBuilder for querying todos. A new builder
+// applies no status filter: it matches open, completed, and canceled todos
+// alike until Status narrows it. Only trashed todos and repeating templates
+// are left out by default.
 func (c *Client) Todos() TodoQueryBuilder {
 	return c.database.Todos()
 }
 
-// Projects creates a new ProjectQueryBuilder for querying projects.
+// Projects creates a new ProjectQueryBuilder for querying projects. Like
+// Todos, it matches every status unless Status narrows it.
 func (c *Client) Projects() ProjectQueryBuilder {
 	return c.database.Projects()
 }
@@ -191,6 +235,45 @@ func (c *Client) Tags() TagQueryBuilder {
 	return c.database.Tags()
 }
 
+// ChecklistItem returns the checklist item with the given UUID, or
+// ErrChecklistItemNotFound when there is none.
+func (c *Client) ChecklistItem(ctx context.Context, uuid string) (*ChecklistItem, error) {
+	row, err := c.database.inner.QueryChecklistItem(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	if row == nil {
+		return nil, ErrChecklistItemNotFound
+	}
+	item := convertChecklistItemRows([]database.ChecklistItemRow{*row})[0]
+	return &item, nil
+}
+
+// ChecklistItemsFor returns the checklist items of the given todos keyed by
+// todo UUID, loading them in a single query instead of one per todo. Todos
+// without a checklist, and unknown UUIDs, have no entry in the map.
+func (c *Client) ChecklistItemsFor(ctx context.Context, todoUUIDs ...string) (map[string][]ChecklistItem, error) {
+	rows, err := c.database.inner.QueryChecklistItemsOfTasks(ctx, todoUUIDs)
+	if err != nil {
+		return nil, err
+	}
+	items := make(map[string][]ChecklistItem, len(rows))
+	for uuid, r := range rows {
+		items[uuid] = convertChecklistItemRows(r)
+	}
+	return items, nil
+}
+
+// ChecklistComplete returns the open todos whose checklist items are all
+// completed or canceled: todos that look ready to close. Todos without a
+// checklist are not included. The result is never nil.
+func (c *Client) ChecklistComplete(ctx context.Context) ([]Todo, error) {
+	return c.database.Todos().
+		checklistComplete().
+		Status().Incomplete().
+		All(ctx)
+}
+
 // ============================================================================
 // Add Operations
 // ============================================================================
@@ -208,6 +291,175 @@ func (c *Client) AddTodo() TodoAdder {
 	return scheme.NewTodoAdder(c.scheme)
 }
 
+// AppendTodo returns a TodoAdder preset to create a todo titled title in the
+// open project named project. The name is resolved to the project's UUID
+// first, so the todo cannot land in an area or another list that shares the
+// name. The title must match exactly; AppendTodo returns ErrProjectNotFound
+// when no open project has it and ErrAmbiguousProject when several do.
+//
+// Example:
+//
+//	adder, err := client.AppendTodo(ctx, "Home Renovation", "Order tiles")
+//	if err != nil {
+//	    return err
+//	}
+//	adder.Deadline(deadline).Execute(ctx)
+func (c *Client) AppendTodo(ctx context.Context, project, title string) (TodoAdder, error) {
+	uuid, err := c.findOpenProject(ctx, project, "")
+	if err != nil {
+		return nil, err
+	}
+	return c.AddTodo().Title(title).ListID(uuid), nil
+}
+
+// CreateAndFetch executes adder with ExecuteAndWait and returns the todo it
+// created, read back from the database with its checklist. Things reports
+// the new todo's UUID through the x-success callback, so same-titled todos
+// cannot be mistaken for it. The todo is then polled for by UUID like
+// EnsureProject, and ErrTodoNotCreated reports one that never shows up. The
+// adder must create a single todo, so Titles is rejected; the callback wait
+// follows ExecuteAndWait, including its one-minute cap when ctx has no
+// deadline.
+//
+// Example:
+//
+//	todo, err := client.CreateAndFetch(ctx, client.AddTodo().Title("Call Alex").When(things3.Today()))
+func (c *Client) CreateAndFetch(ctx context.Context, adder TodoAdder) (*Todo, error) {
+	thingsURL, err := adder.Build()
+	if err != nil {
+		return nil, err
+	}
+	u, err := url.Parse(thingsURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Query().Has(scheme.KeyTitles) {
+		return nil, errors.New("things3: CreateAndFetch needs a single todo")
+	}
+
+	uuid, err := adder.ExecuteAndWait(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return c.waitForTodo(ctx, uuid)
+}
+
+// waitForTodo polls for the todo with the given UUID.
+func (c *Client) waitForTodo(ctx context.Context, uuid string) (*Todo, error) {
+	q := c.Todos().WithUUID(uuid).Status().Any().IncludeChecklist()
+	return pollDatabase(ctx, fmt.Errorf("%w: %s", ErrTodoNotCreated, uuid), func(ctx context.Context) (*Todo, error) {
+		todo, ok, err := q.FirstOK(ctx)
+		if err == nil && !ok {
+			err = errNotYet
+		}
+		return todo, err
+	})
+}
+
+// findOpenProject returns the UUID of the one open project titled exactly
+// title, limited to the area areaID unless it is empty. It returns
+// ErrProjectNotFound or ErrAmbiguousProject when zero or several match.
+func (c *Client) findOpenProject(ctx context.Context, title, areaID string) (string, error) {
+	q := c.Projects().WithTitle(title).Status().Incomplete()
+	if areaID != "" {
+		q = q.InArea(areaID)
+	}
+	candidates, err := q.All(ctx)
+	if err != nil {
+		return "", err
+	}
+	var matches []string
+	for i := range candidates {
+		if candidates[i].Title == title {
+			matches = append(matches, candidates[i].UUID)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("%w: %q", ErrProjectNotFound, title)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("%w: %d open projects named %q", ErrAmbiguousProject, len(matches), title)
+	}
+}
+
+// Polling bounds for writes read back from the database (EnsureProject,
+// CreateAndFetch): how often the database is re-read after the URL is sent,
+// and how long to wait when ctx allows longer.
+const (
+	writePollInterval = 100 * time.Millisecond
+	writePollTimeout  = 10 * time.Second
+)
+
+// errNotYet is returned by a pollDatabase lookup that found nothing yet.
+var errNotYet = errors.New("things3: not in the database yet")
+
+// pollDatabase calls find until it succeeds or fails with an error other than
+// errNotYet. When the wait runs out, possibly in the middle of a lookup, it
+// returns errTimeout wrapped with the cause.
+func pollDatabase[T any](ctx context.Context, errTimeout error, find func(context.Context) (T, error)) (T, error) {
+	ctx, cancel := context.WithTimeout(ctx, writePollTimeout)
+	defer cancel()
+	ticker := time.NewTicker(writePollInterval)
+	defer ticker.Stop()
+
+	for {
+		v, err := find(ctx)
+		switch {
+		case err == nil:
+			return v, nil
+		case ctx.Err() != nil:
+			return v, fmt.Errorf("%w: %w", errTimeout, context.Cause(ctx))
+		case !errors.Is(err, errNotYet):
+			return v, err
+		}
+		select {
+		case <-ctx.Done():
+		case <-ticker.C:
+		}
+	}
+}
+
+// EnsureProject returns the UUID of the open project titled exactly title,
+// creating it first when it does not exist, so provisioning scripts can run
+// it repeatedly without duplicating projects. A non-empty areaID limits the
+// lookup to that area and files a new project there. A new project is
+// created with ExecuteAndWait, which reports its UUID through the x-success
+// callback; EnsureProject then polls the database until that project
+// appears, so the next call finds it, returning ErrProjectNotCreated if it
+// does not within ten seconds or before ctx ends. Several matching projects
+// yield ErrAmbiguousProject.
+func (c *Client) EnsureProject(ctx context.Context, title, areaID string) (string, error) {
+	uuid, err := c.findOpenProject(ctx, title, areaID)
+	if !errors.Is(err, ErrProjectNotFound) {
+		return uuid, err
+	}
+
+	adder := c.AddProject().Title(title)
+	if areaID != "" {
+		adder = adder.AreaID(areaID)
+	}
+	uuid, err = adder.ExecuteAndWait(ctx)
+	if err != nil {
+		return "", err
+	}
+	return uuid, c.waitForProject(ctx, uuid)
+}
+
+// waitForProject polls for the project with the given UUID.
+func (c *Client) waitForProject(ctx context.Context, uuid string) error {
+	q := c.Projects().WithUUID(uuid).Status().Any()
+	_, err := pollDatabase(ctx, fmt.Errorf("%w: %s", ErrProjectNotCreated, uuid), func(ctx context.Context) (*Project, error) {
+		project, ok, err := q.FirstOK(ctx)
+		if err == nil && !ok {
+			err = errNotYet
+		}
+		return project, err
+	})
+	return err
+}
+
 // AddProject returns a ProjectAdder for creating a new project.
 //
 // Example:
@@ -221,6 +473,8 @@ func (c *Client) AddProject() ProjectAdder {
 }
 
 // Batch returns a BatchCreator for batch create operations.
+// Items are created in the order they are added. Reveal(true) always opens
+// the first item, since the URL scheme cannot target a later one.
 //
 // Example:
 //
@@ -285,7 +539,8 @@ func (c *Client) UpdateProject(id string) ProjectUpdater {
 // Show Operations
 // ============================================================================
 
-// Show opens Things and displays the item with the given UUID.
+// Show opens Things and displays the item with the given UUID, which may
+// identify a to-do, project, area, or tag.
 // By default, brings Things to foreground since the user wants to view the item.
 // Use WithBackgroundNavigation() option to run in background without stealing focus.
 func (c *Client) Show(ctx context.Context, uuid string) error {
@@ -1,17 +1,80 @@
 package things3
 
+import (
+	"context"
+	"time"
+
+	"github.com/moond4rk/things3/internal/database"
+)
+
 // clientOptions holds the configuration options for the Client.
 type clientOptions struct {
 	// Database options
-	databasePath string
-	printSQL     bool
+	databasePath  string
+	printSQL      bool
+	logger        func(ctx context.Context, sql string, dur time.Duration, err error)
+	searchColumns []SearchColumn
+	skipCorrupt   bool
+	immutable     bool
+	maxOpenConns  int
+	busyTimeout   time.Duration
+	queryTimeout  time.Duration
 
 	// Scheme options
-	foreground bool // bring Things to foreground for create/update
-	background bool // keep Things in background for navigation
+	foreground bool          // bring Things to foreground for create/update
+	background bool          // keep Things in background for navigation
+	openMethod OpenMethod    // command used for every URL, overriding the two above
+	timeout    time.Duration // limit on a single URL open
 
 	// Token options
 	preloadToken bool // fetch token immediately during NewClient
+
+	watchInterval time.Duration // poll interval for Watch
+
+	clock func() time.Time // current time for date filters and scheduling helpers
+}
+
+// databaseOptions translates the client options into database options.
+func (o *clientOptions) databaseOptions() []database.Option {
+	var dbOpts []database.Option
+	if o.databasePath != "" {
+		dbOpts = append(dbOpts, database.WithPath(o.databasePath))
+	}
+	if o.printSQL {
+		dbOpts = append(dbOpts, database.WithPrintSQL(o.printSQL))
+	}
+	if len(o.searchColumns) > 0 {
+		names := make([]string, len(o.searchColumns))
+		for i, col := range o.searchColumns {
+			names[i] = string(col)
+		}
+		dbOpts = append(dbOpts, database.WithSearchColumns(names...))
+	}
+	if o.skipCorrupt {
+		dbOpts = append(dbOpts, database.WithSkipCorruptRows(true))
+	}
+	if o.immutable {
+		dbOpts = append(dbOpts, database.WithImmutable(true))
+	}
+	if o.maxOpenConns > 0 {
+		dbOpts = append(dbOpts, database.WithMaxOpenConns(o.maxOpenConns))
+	}
+	if o.busyTimeout > 0 {
+		dbOpts = append(dbOpts, database.WithBusyTimeout(o.busyTimeout))
+	}
+	if o.queryTimeout > 0 {
+		dbOpts = append(dbOpts, database.WithQueryTimeout(o.queryTimeout))
+	}
+	if o.clock != nil {
+		dbOpts = append(dbOpts, database.WithClock(o.clock))
+	}
+	if logger := o.logger; logger != nil {
+		dbOpts = append(dbOpts, database.WithLogger(
+			func(ctx context.Context, query string, _ []any, dur time.Duration, err error) {
+				logger(ctx, query, dur, err)
+			}))
+	}
+	return dbOpts
 }
 
 // ClientOption is a functional option for configuring the Client.
@@ -41,10 +104,142 @@ func WithPrintSQL(enabled bool) ClientOption {
 	}
 }
 
+// WithLogger routes every database query to fn, called after the query runs
+// with its SQL, how long it took to return its first row, and its error, so
+// reads can be logged through slog or zap with timing instead of printed.
+// Filter values are inlined in the SQL. WithPrintSQL keeps working alongside
+// it. ctx is the context of the read that issued the query. Under
+// WithSkipCorruptRows fn also receives each skipped row as its query with a
+// zero dur and a *CorruptRowsError.
+//
+// Example:
+//
+//	client, err := things3.NewClient(things3.WithLogger(
+//	    func(ctx context.Context, sql string, dur time.Duration, err error) {
+//	        slog.DebugContext(ctx, "things3 query", "sql", sql, "dur", dur, "err", err)
+//	    }))
+func WithLogger(fn func(ctx context.Context, sql string, dur time.Duration, err error)) ClientOption {
+	return func(opts *clientOptions) {
+		opts.logger = fn
+	}
+}
+
+// WithSkipCorruptRows makes todo, project and heading queries skip rows that
+// cannot be read instead of failing outright, for best-effort reads of a
+// damaged database or backup. All then returns the readable rows together
+// with a *CorruptRowsError, so check for it before discarding the results:
+//
+//	todos, err := client.Todos().All(ctx)
+//	if err != nil && !errors.Is(err, things3.ErrCorruptRows) {
+//	    return err
+//	}
+//
+// First passes over unreadable rows to the first readable match, and
+// composed reads such as Sidebar or HeadingGroups return what they could read
+// with the same error. Each skipped row is also reported to WithLogger, and
+// printed by WithPrintSQL, as a *CorruptRowsError for its query.
+func WithSkipCorruptRows(enabled bool) ClientOption {
+	return func(opts *clientOptions) {
+		opts.skipCorrupt = enabled
+	}
+}
+
+// WithImmutable opens the database with SQLite's immutable flag, so reads
+// take no locks at all and never wait on Things. The flag also makes SQLite
+// skip the write-ahead log, where Things keeps its most recent changes until
+// a checkpoint, so reads may miss them. Use it for a backup or a copy of the
+// database, not the live file. The connection is read-only either way.
+//
+// Example:
+//
+//	client, err := things3.NewClient(
+//	    things3.WithDatabasePath("/backups/main.sqlite"),
+//	    things3.WithImmutable(true),
+//	)
+func WithImmutable(enabled bool) ClientOption {
+	return func(opts *clientOptions) {
+		opts.immutable = enabled
+	}
+}
+
+// WithMaxOpenConns caps the connections the Client keeps open to the
+// database. The default of zero sets no limit.
+//
+// Example:
+//
+//	client, err := things3.NewClient(things3.WithMaxOpenConns(1))
+func WithMaxOpenConns(n int) ClientOption {
+	return func(opts *clientOptions) {
+		opts.maxOpenConns = n
+	}
+}
+
+// WithBusyTimeout sets how long a query waits while Things holds a write
+// lock before failing with "database is locked". The default of zero keeps
+// the SQLite driver's five seconds.
+//
+// Example:
+//
+//	client, err := things3.NewClient(things3.WithBusyTimeout(10 * time.Second))
+func WithBusyTimeout(d time.Duration) ClientOption {
+	return func(opts *clientOptions) {
+		opts.busyTimeout = d
+	}
+}
+
+// WithQueryTimeout sets a safety-net deadline for reads made with a context
+// that has none, so a pathological query cannot hang the caller. A read that
+// runs past d fails with an error matching context.DeadlineExceeded. A
+// context with its own deadline keeps it, and cancellation works as before.
+// The bound covers one database call; Each applies it to each chunk it
+// reads, not to the time spent in its callback. The default of zero sets no
+// limit.
+//
+// Example:
+//
+//	client, err := things3.NewClient(things3.WithQueryTimeout(5 * time.Second))
+func WithQueryTimeout(d time.Duration) ClientOption {
+	return func(opts *clientOptions) {
+		opts.queryTimeout = d
+	}
+}
+
+// WithClock sets the clock the client treats as "now". Relative date filters
+// such as StartDate().Future(), the Today and Upcoming views,
+// UpcomingDeadlines, and relative scheduling like WhenInDays then follow it
+// instead of the system time, which makes results reproducible in tests and
+// across time zones. The default is time.Now.
+//
+// Example:
+//
+//	frozen := time.Date(2024, 6, 15, 9, 0, 0, 0, time.Local)
+//	client, err := things3.NewClient(things3.WithClock(func() time.Time { return frozen }))
+func WithClock(clock func() time.Time) ClientOption {
+	return func(opts *clientOptions) {
+		opts.clock = clock
+	}
+}
+
+// WithSearchColumns sets the fields matched by Search on todo and project
+// queries, replacing the default of title, notes, and area title.
+// NewClient returns ErrInvalidSearchColumn for a column outside the
+// SearchColumn constants.
+//
+// Example:
+//
+//	client, err := things3.NewClient(things3.WithSearchColumns(
+//	    things3.SearchColumnTitle, things3.SearchColumnNotes, things3.SearchColumnProject,
+//	))
+func WithSearchColumns(columns ...SearchColumn) ClientOption {
+	return func(opts *clientOptions) {
+		opts.searchColumns = columns
+	}
+}
+
 // WithForegroundExecution configures the Client to bring Things to foreground
 // when executing create/update operations (AddTodo, AddProject, UpdateTodo, etc.).
 //
-// By default, create/update operations run in background without stealing focus.
+// By default, create/update operations run through `open -g` without stealing focus.
 // Use this option when you want Things to become the active window after operations.
 //
 // Example:
@@ -74,6 +269,36 @@ func WithBackgroundNavigation() ClientOption {
 	}
 }
 
+// WithOpenMethod fixes the command used to hand every URL to Things,
+// overriding WithForegroundExecution and WithBackgroundNavigation.
+// OpenMethodBackground runs `open -g`, OpenMethodForeground runs `open`, and
+// OpenMethodScript asks Things to open the URL via osascript. Use it when the
+// default `open -g` does not behave on a given macOS setup, for example
+// when it still brings Things forward.
+//
+// Example:
+//
+//	client, err := things3.NewClient(things3.WithOpenMethod(things3.OpenMethodScript))
+func WithOpenMethod(method OpenMethod) ClientOption {
+	return func(opts *clientOptions) {
+		opts.openMethod = method
+	}
+}
+
+// WithExecuteTimeout bounds how long handing a URL to Things may take, for
+// example while the app is still launching. An Execute that exceeds d fails
+// with an error wrapping ErrExecuteTimeout instead of blocking until the
+// caller's context ends. The default of zero sets no limit.
+//
+// Example:
+//
+//	client, err := things3.NewClient(things3.WithExecuteTimeout(10 * time.Second))
+func WithExecuteTimeout(d time.Duration) ClientOption {
+	return func(opts *clientOptions) {
+		opts.timeout = d
+	}
+}
+
 // WithPreloadToken fetches the authentication token immediately during NewClient()
 // instead of lazily on first update operation.
 //
@@ -91,3 +316,16 @@ func WithPreloadToken() ClientOption {
 		opts.preloadToken = true
 	}
 }
+
+// WithWatchInterval sets how often Watch polls the database for changes.
+// The default is two seconds; each poll stats two files and runs one small
+// query.
+//
+// Example:
+//
+//	client, err := things3.NewClient(things3.WithWatchInterval(500 * time.Millisecond))
+func WithWatchInterval(d time.Duration) ClientOption {
+	return func(opts *clientOptions) {
+		opts.watchInterval = d
+	}
+}
@@ -1,8 +1,10 @@
 package things3
 
 import (
+	"context"
 	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -41,6 +43,224 @@ func TestNewClient(t *testing.T) {
 	})
 }
 
+func TestCheckDatabaseAccess(t *testing.T) {
+	initTestPaths()
+
+	require.NoError(t, CheckDatabaseAccess(WithDatabasePath(testDatabasePath)))
+	err := CheckDatabaseAccess(WithDatabasePath("/nonexistent/path/main.sqlite"))
+	require.ErrorIs(t, err, ErrDatabaseNotFound)
+	assert.NotErrorIs(t, err, ErrFullDiskAccessRequired)
+}
+
+func TestWithSearchColumns(t *testing.T) {
+	initTestPaths()
+	ctx := t.Context()
+
+	byDefault, err := newTestClient(t).Todos().Search("Project without Area").All(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, byDefault, "project titles are not searched by default")
+
+	client, err := NewClient(
+		WithDatabasePath(testDatabasePath),
+		WithSearchColumns(SearchColumnTitle, SearchColumnProject),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+
+	todos, err := client.Todos().Search("Project without Area").All(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, extractTodoUUIDs(todos), testUUIDTodoInProject)
+
+	_, err = NewClient(WithDatabasePath(testDatabasePath), WithSearchColumns("uuid"))
+	require.ErrorIs(t, err, ErrInvalidSearchColumn)
+	require.ErrorIs(t, err, ErrInvalidColumn)
+}
+
+func TestConnectionOptions(t *testing.T) {
+	initTestPaths()
+	ctx := t.Context()
+
+	tests := map[string]ClientOption{
+		"immutable":      WithImmutable(true),
+		"max open conns": WithMaxOpenConns(1),
+		"busy timeout":   WithBusyTimeout(100 * time.Millisecond),
+	}
+	for name, opt := range tests {
+		t.Run(name, func(t *testing.T) {
+			client, err := NewClient(WithDatabasePath(testDatabasePath), opt)
+			require.NoError(t, err)
+			t.Cleanup(func() { client.Close() })
+
+			todo, err := client.Todos().WithUUID(testUUIDTodoInToday).First(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, "To-Do in Today", todo.Title)
+
+			_, err = client.SQLDB().ExecContext(ctx, "DELETE FROM TMTask")
+			require.Error(t, err, "the connection stays read-only")
+		})
+	}
+
+	client, err := NewClient(WithDatabasePath(testDatabasePath), WithMaxOpenConns(1))
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+	assert.Equal(t, 1, client.SQLDB().Stats().MaxOpenConnections)
+}
+
+func TestWithLogger(t *testing.T) {
+	initTestPaths()
+	type ctxKey struct{}
+
+	type entry struct {
+		sql string
+		dur time.Duration
+		err error
+		ctx context.Context
+	}
+	var logged []entry
+	client, err := NewClient(WithDatabasePath(testDatabasePath), WithLogger(
+		func(ctx context.Context, sql string, dur time.Duration, err error) {
+			logged = append(logged, entry{sql: sql, dur: dur, err: err, ctx: ctx})
+		}))
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+
+	ctx := context.WithValue(t.Context(), ctxKey{}, "request-1")
+	_, err = client.Todos().InArea(testUUIDArea1).All(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, logged)
+	assert.Contains(t, logged[0].sql, testUUIDArea1)
+	assert.Positive(t, logged[0].dur)
+	require.NoError(t, logged[0].err)
+	assert.Equal(t, "request-1", logged[0].ctx.Value(ctxKey{}), "the caller's context reaches the logger")
+
+	logged = nil
+	_, err = client.Areas().Count(ctx)
+	require.NoError(t, err)
+	require.Len(t, logged, 1, "single-row queries are logged too")
+	assert.Contains(t, logged[0].sql, "COUNT")
+	assert.Positive(t, logged[0].dur)
+
+	logged = nil
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = client.Todos().All(canceled)
+	require.Error(t, err)
+	require.Len(t, logged, 1)
+	assert.ErrorIs(t, logged[0].err, context.Canceled)
+}
+
+func TestClientChecklistItemsFor(t *testing.T) {
+	client := newTestClient(t)
+	ctx := t.Context()
+
+	items, err := client.ChecklistItemsFor(ctx, testUUIDTodoInboxChecklist, testUUIDTodoInToday)
+	require.NoError(t, err)
+	require.Len(t, items, 1, "todos without a checklist have no entry")
+
+	todo, err := client.Todos().WithUUID(testUUIDTodoInboxChecklist).First(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, todo.Checklist, items[testUUIDTodoInboxChecklist])
+
+	empty, err := client.ChecklistItemsFor(ctx)
+	require.NoError(t, err)
+	assert.NotNil(t, empty)
+	assert.Empty(t, empty)
+}
+
+func TestClientChecklistComplete(t *testing.T) {
+	dbPath := copyWritableFixture(t)
+	client, err := NewClient(WithDatabasePath(dbPath))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	ctx := t.Context()
+
+	todos, err := client.ChecklistComplete(ctx)
+	require.NoError(t, err)
+	assert.NotNil(t, todos)
+	assert.Empty(t, todos, "the fixture checklist still has open items")
+
+	// Close the open items, one as canceled: canceled items count as done.
+	execFixtureSQL(t, dbPath, "UPDATE TMChecklistItem SET status = 3 WHERE task = ?", testUUIDTodoInboxChecklist)
+	require.Equal(t, int64(1), execFixtureSQL(t, dbPath,
+		"UPDATE TMChecklistItem SET status = 2 WHERE uuid = (SELECT uuid FROM TMChecklistItem WHERE task = ? LIMIT 1)",
+		testUUIDTodoInboxChecklist))
+
+	todos, err = client.ChecklistComplete(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{testUUIDTodoInboxChecklist}, extractTodoUUIDs(todos))
+
+	execFixtureSQL(t, dbPath, "UPDATE TMTask SET status = 3 WHERE uuid = ?", testUUIDTodoInboxChecklist)
+	todos, err = client.ChecklistComplete(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, todos, "a closed todo is no longer a candidate")
+}
+
+func TestClientChecklistItem(t *testing.T) {
+	client := newTestClient(t)
+	ctx := t.Context()
+
+	todo, err := client.Todos().WithUUID(testUUIDTodoInboxChecklist).First(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, todo.Checklist)
+
+	for _, want := range todo.Checklist {
+		got, err := client.ChecklistItem(ctx, want.UUID)
+		require.NoError(t, err)
+		assert.Equal(t, want, *got)
+	}
+
+	_, err = client.ChecklistItem(ctx, "nonexistent-uuid")
+	require.ErrorIs(t, err, ErrChecklistItemNotFound)
+}
+
+func TestClientGetMany(t *testing.T) {
+	client := newTestClient(t)
+	const testUUIDHeading = "6QpDLSHZMRAUSAeZ9mNvgt"
+
+	got, err := client.GetMany(t.Context(), []string{
+		testUUIDTodoInArea1Tags, testUUIDProjectInArea1, testUUIDHeading,
+		testUUIDArea1, testUUIDTagOffice, "nonexistent-uuid",
+	})
+	require.NoError(t, err)
+	require.Len(t, got, 5)
+	assert.NotContains(t, got, "nonexistent-uuid")
+
+	todo, ok := got[testUUIDTodoInArea1Tags].(*Todo)
+	require.True(t, ok, "todo resolved to %T", got[testUUIDTodoInArea1Tags])
+	assert.ElementsMatch(t, []string{"Home", "Errand"}, todo.Tags)
+	project, ok := got[testUUIDProjectInArea1].(*Project)
+	require.True(t, ok, "project resolved to %T", got[testUUIDProjectInArea1])
+	assert.Equal(t, testUUIDProjectInArea1, project.UUID)
+	heading, ok := got[testUUIDHeading].(*Heading)
+	require.True(t, ok, "heading resolved to %T", got[testUUIDHeading])
+	assert.Equal(t, "Heading", heading.Title)
+	area, ok := got[testUUIDArea1].(*Area)
+	require.True(t, ok, "area resolved to %T", got[testUUIDArea1])
+	assert.Equal(t, testUUIDArea1, area.UUID)
+	tag, ok := got[testUUIDTagOffice].(*Tag)
+	require.True(t, ok, "tag resolved to %T", got[testUUIDTagOffice])
+	assert.Equal(t, "Office", tag.Title)
+
+	empty, err := client.GetMany(t.Context(), nil)
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+}
+
+func TestClientSQLDB(t *testing.T) {
+	client := newTestClient(t)
+	ctx := t.Context()
+
+	var title string
+	err := client.SQLDB().QueryRowContext(ctx,
+		"SELECT title FROM TMTask WHERE uuid = ?", testUUIDTodoInToday).Scan(&title)
+	require.NoError(t, err)
+	assert.Equal(t, "To-Do in Today", title)
+
+	_, err = client.SQLDB().ExecContext(ctx,
+		"UPDATE TMTask SET title = 'changed' WHERE uuid = ?", testUUIDTodoInToday)
+	require.Error(t, err, "the handle is read-only")
+}
+
 func TestClientQueryBuilders(t *testing.T) {
 	client := newTestClient(t)
 	ctx := t.Context()
@@ -195,3 +415,71 @@ func TestClientURLSchemeBuilders(t *testing.T) {
 		require.Equal(t, "updated", params.Get("title"))
 	})
 }
+
+func TestClientEnsureProjectExisting(t *testing.T) {
+	client := newTestClient(t)
+	ctx := t.Context()
+
+	uuid, err := client.EnsureProject(ctx, "Project in Area 1", "")
+	require.NoError(t, err)
+	assert.Equal(t, testUUIDProjectInArea1, uuid)
+
+	uuid, err = client.EnsureProject(ctx, "Project in Area 1", testUUIDArea1)
+	require.NoError(t, err)
+	assert.Equal(t, testUUIDProjectInArea1, uuid)
+
+	// Scoped to another area the project is absent; check the lookup alone,
+	// since EnsureProject would go on to open a create URL.
+	_, err = client.findOpenProject(ctx, "Project in Area 1", testUUIDArea2)
+	require.ErrorIs(t, err, ErrProjectNotFound)
+}
+
+func TestClientWaitForProject(t *testing.T) {
+	dbPath := copyWritableFixture(t)
+	client, err := NewClient(WithDatabasePath(dbPath))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+
+	// A trashed project stands in for one Things has not written yet.
+	const pending = "Tc7DABDNNMZvV4ZGB8tLDh"
+	short, cancel := context.WithTimeout(t.Context(), 3*writePollInterval)
+	defer cancel()
+	err = client.waitForProject(short, pending)
+	require.ErrorIs(t, err, ErrProjectNotCreated)
+
+	execFixtureSQL(t, dbPath, "UPDATE TMTask SET trashed = 0 WHERE uuid = ?", pending)
+	require.NoError(t, client.waitForProject(t.Context(), pending))
+}
+
+func TestClientCreateAndFetchRejects(t *testing.T) {
+	client := newTestClient(t)
+	ctx := t.Context()
+
+	_, err := client.CreateAndFetch(ctx, client.AddTodo().Titles("One", "Two"))
+	require.Error(t, err)
+	_, err = client.CreateAndFetch(ctx, client.AddTodo().Title("Due").Deadline(time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)))
+	require.ErrorIs(t, err, ErrDeadlineHasTime, "build errors surface before anything is opened")
+}
+
+func TestClientWaitForTodo(t *testing.T) {
+	dbPath := copyWritableFixture(t)
+	client, err := NewClient(WithDatabasePath(dbPath))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+
+	// A trashed todo stands in for one Things has not written yet.
+	const pending = "A2oPvtt4dXoypeoLc8uYzY"
+	short, cancel := context.WithTimeout(t.Context(), 3*writePollInterval)
+	defer cancel()
+	_, err = client.waitForTodo(short, pending)
+	require.ErrorIs(t, err, ErrTodoNotCreated)
+
+	execFixtureSQL(t, dbPath, "UPDATE TMTask SET trashed = 0 WHERE uuid = ?", pending)
+	todo, err := client.waitForTodo(t.Context(), pending)
+	require.NoError(t, err)
+	assert.Equal(t, pending, todo.UUID)
+
+	todo, err = client.waitForTodo(t.Context(), testUUIDTodoInboxChecklist)
+	require.NoError(t, err)
+	assert.NotEmpty(t, todo.Checklist, "the todo is read back with its checklist")
+}
@@ -0,0 +1,44 @@
+package things3
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// CreateFromClipboard creates a todo from the text on the macOS clipboard,
+// read with pbpaste. The first non-blank line becomes the title and any
+// further lines become the notes. It returns ErrClipboardEmpty when the
+// clipboard holds no text.
+//
+// The Things URL scheme has no parameter that reads the clipboard itself, and
+// Quick Entry's autofill is an app feature rather than a URL option; to review
+// the todo in Quick Entry before it is saved, build it with AddTodo and
+// ShowQuickEntry(true) instead.
+func (c *Client) CreateFromClipboard(ctx context.Context) error {
+	out, err := exec.CommandContext(ctx, "pbpaste").Output()
+	if err != nil {
+		return fmt.Errorf("things3: reading clipboard: %w", err)
+	}
+	adder, err := c.todoFromText(string(out))
+	if err != nil {
+		return err
+	}
+	return adder.Execute(ctx)
+}
+
+// todoFromText returns a TodoAdder titled with the first non-blank line of
+// text, carrying the remaining lines as notes.
+func (c *Client) todoFromText(text string) (TodoAdder, error) {
+	text = strings.TrimSpace(strings.ReplaceAll(text, "\r\n", "\n"))
+	if text == "" {
+		return nil, ErrClipboardEmpty
+	}
+	title, notes, _ := strings.Cut(text, "\n")
+	adder := c.AddTodo().Title(strings.TrimSpace(title))
+	if notes = strings.TrimSpace(notes); notes != "" {
+		adder = adder.Notes(notes)
+	}
+	return adder, nil
+}
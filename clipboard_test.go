@@ -0,0 +1,39 @@
+package things3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientTodoFromText(t *testing.T) {
+	client := newTestClient(t)
+
+	tests := []struct {
+		name      string
+		text      string
+		wantTitle string
+		wantNotes string
+	}{
+		{"single line", "Buy milk", "Buy milk", ""},
+		{"surrounding blank lines", "\n\n  Buy milk  \n\n", "Buy milk", ""},
+		{"title and notes", "Call Alex\r\nAbout the lease\r\n- bring keys\r\n", "Call Alex", "About the lease\n- bring keys"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adder, err := client.todoFromText(tt.text)
+			require.NoError(t, err)
+			thingsURL, err := adder.Build()
+			require.NoError(t, err)
+
+			cmd, params := parseThingsURL(t, thingsURL)
+			assert.Equal(t, "add", cmd)
+			assert.Equal(t, tt.wantTitle, params.Get("title"))
+			assert.Equal(t, tt.wantNotes, params.Get("notes"))
+		})
+	}
+
+	_, err := client.todoFromText(" \n\t ")
+	require.ErrorIs(t, err, ErrClipboardEmpty)
+}
@@ -101,6 +101,31 @@ func TestAddDryRun(t *testing.T) {
 		_, _, err := executeCommand(t, "add", "Wake", "--reminder", "25:00", "--dry-run")
 		assertExitCode(t, err, 1)
 	})
+
+	t.Run("list and reveal", func(t *testing.T) {
+		u := dryRunURL(t, "add", "Call mom", "--list", "Family", "--reveal")
+		q := u.Query()
+		if q.Get("list") != "Family" {
+			t.Errorf("list = %q", q.Get("list"))
+		}
+		if q.Has("list-id") {
+			t.Errorf("--list must not resolve to list-id, got %q", q.Get("list-id"))
+		}
+		if q.Get("reveal") != "true" {
+			t.Errorf("reveal = %q", q.Get("reveal"))
+		}
+	})
+
+	t.Run("reveal omitted by default", func(t *testing.T) {
+		if u := dryRunURL(t, "add", "Quiet"); u.Query().Has("reveal") {
+			t.Errorf("reveal should be absent, got %q", u.Query().Get("reveal"))
+		}
+	})
+
+	t.Run("list and project are mutually exclusive", func(t *testing.T) {
+		_, _, err := executeCommand(t, "add", "X", "--list", "Family", "--project", "Work", "--dry-run")
+		assertExitCode(t, err, 1)
+	})
 }
 
 func TestAddDestinationFlags(t *testing.T) {
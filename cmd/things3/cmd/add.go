@@ -21,7 +21,8 @@ func newAddCmd() *cobra.Command {
 		GroupID: groupActions,
 		Example: `  things3 add "Buy milk" --when today
   things3 add "Email Bob" --project Work --tags urgent
-  things3 add "Plan trip" --checklist Flights --checklist Hotel`,
+  things3 add "Plan trip" --checklist Flights --checklist Hotel
+  things3 add "Call mom" --list Family --reveal`,
 		Args: cobra.ExactArgs(1),
 		RunE: withClient(runAddTodo),
 	}
@@ -32,9 +33,11 @@ func newAddCmd() *cobra.Command {
 	cmd.Flags().String(flagProject, "", "place in a project (name, prefix, or UUID)")
 	cmd.Flags().String(flagArea, "", "place in an area (name, prefix, or UUID)")
 	cmd.Flags().String(flagHeading, "", "place under a heading (requires --project)")
+	cmd.Flags().String(flagList, "", "place in a project or area by exact title, resolved by Things")
 	cmd.Flags().String(flagTags, "", "tags (comma-separated)")
 	cmd.Flags().StringArray(flagChecklist, nil, "checklist item (repeatable)")
-	cmd.MarkFlagsMutuallyExclusive(flagProject, flagArea)
+	cmd.Flags().Bool(flagReveal, false, "open Things to the new todo")
+	cmd.MarkFlagsMutuallyExclusive(flagProject, flagArea, flagList)
 	cmd.MarkFlagsMutuallyExclusive(flagArea, flagHeading)
 	addWriteFlags(cmd)
 	cmd.AddCommand(newAddProjectCmd())
@@ -80,6 +83,9 @@ func runAddTodo(cmd *cobra.Command, args []string, client *things3.Client) error
 	if items, _ := f.GetStringArray(flagChecklist); len(items) > 0 {
 		builder = builder.ChecklistItems(items...)
 	}
+	if reveal, _ := f.GetBool(flagReveal); reveal {
+		builder = builder.Reveal(true)
+	}
 
 	builder, err := placeTodo(ctx, client, builder, f)
 	if err != nil {
@@ -96,11 +102,13 @@ func runAddTodo(cmd *cobra.Command, args []string, client *things3.Client) error
 }
 
 // placeTodo resolves the --project/--area/--heading destination flags onto the
-// adder. --heading requires --project.
+// adder. --heading requires --project. --list is passed through by title for
+// Things to resolve, so it works for lists the database has not seen yet.
 func placeTodo(ctx context.Context, client *things3.Client, builder things3.TodoAdder, f *pflag.FlagSet) (things3.TodoAdder, error) {
 	heading, _ := f.GetString(flagHeading)
 	project, _ := f.GetString(flagProject)
 	area, _ := f.GetString(flagArea)
+	list, _ := f.GetString(flagList)
 
 	switch {
 	case heading != "":
@@ -128,6 +136,8 @@ func placeTodo(ctx context.Context, client *things3.Client, builder things3.Todo
 			return builder, fromResolveError(err)
 		}
 		return builder.ListID(a.UUID), nil
+	case list != "":
+		return builder.List(list), nil
 	default:
 		return builder, nil
 	}
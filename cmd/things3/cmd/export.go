@@ -0,0 +1,281 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/moond4rk/things3"
+	"github.com/moond4rk/things3/cmd/things3/internal/resolve"
+)
+
+// Export flag names.
+const (
+	flagList   = "list"
+	flagFormat = "format"
+	flagStatus = "status"
+)
+
+// exportFormat names a serialization selected by export --format.
+type exportFormat string
+
+const (
+	exportJSON     exportFormat = "json"
+	exportCSV      exportFormat = "csv"
+	exportMarkdown exportFormat = "markdown"
+)
+
+func (f *exportFormat) String() string { return string(*f) }
+
+func (f *exportFormat) Set(v string) error {
+	switch exportFormat(v) {
+	case exportJSON, exportCSV, exportMarkdown:
+		*f = exportFormat(v)
+		return nil
+	default:
+		return errors.New("must be one of: json, csv, markdown")
+	}
+}
+
+func (f *exportFormat) Type() string { return "format" }
+
+// exportLists are the views export --list accepts.
+var exportLists = []string{nameInbox, nameToday, nameUpcoming, nameAnytime, nameSomeday, nameLogbook}
+
+// exportList adapts a view name to pflag.Value so an unknown --list fails at
+// parse time.
+type exportList string
+
+func (l *exportList) String() string { return string(*l) }
+
+func (l *exportList) Set(v string) error {
+	if !slices.Contains(exportLists, v) {
+		return fmt.Errorf("must be one of: %s", strings.Join(exportLists, ", "))
+	}
+	*l = exportList(v)
+	return nil
+}
+
+func (l *exportList) Type() string { return "list" }
+
+// Status filter values for export --status. The empty value keeps the view's
+// own statuses: open todos, or every closed todo for the logbook.
+const (
+	statusOpen      = "open"
+	statusCompleted = "completed"
+	statusCanceled  = "canceled"
+	statusAny       = "any"
+)
+
+// exportStatus adapts a status name to pflag.Value.
+type exportStatus string
+
+func (s *exportStatus) String() string { return string(*s) }
+
+func (s *exportStatus) Set(v string) error {
+	switch v {
+	case statusOpen, statusCompleted, statusCanceled, statusAny:
+		*s = exportStatus(v)
+		return nil
+	default:
+		return errors.New("must be one of: open, completed, canceled, any")
+	}
+}
+
+func (s *exportStatus) Type() string { return "status" }
+
+func newExportCmd() *cobra.Command {
+	list := exportList(nameToday)
+	format := exportJSON
+	var status exportStatus
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Write every todo in a view as JSON, CSV, or a Markdown checklist",
+		Long: `export writes a whole view for scripting: no pagination, no footer. JSON is
+the library's todo schema, CSV has a header row, and Markdown is a checklist.
+--tag filters like it does for the list commands.`,
+		GroupID: groupLookup,
+		Example: "  things3 export --list today --format csv\n" +
+			"  things3 export --list logbook --status completed --format markdown\n" +
+			"  things3 export --list anytime --area Work --tag errand",
+		Args: cobra.NoArgs,
+		RunE: withClient(runExport),
+	}
+	cmd.Flags().Var(&list, flagList, "view to export: "+strings.Join(exportLists, ", "))
+	cmd.Flags().Var(&format, flagFormat, "output format: json, csv, markdown")
+	cmd.Flags().Var(&status, flagStatus, "keep only todos with this status: open, completed, canceled, any")
+	cmd.Flags().String(flagArea, "", "keep only todos in this area (name, prefix, or UUID)")
+	return cmd
+}
+
+func runExport(cmd *cobra.Command, _ []string, client *things3.Client) error {
+	list := cmd.Flags().Lookup(flagList).Value.String()
+	format := exportFormat(cmd.Flags().Lookup(flagFormat).Value.String())
+	status := cmd.Flags().Lookup(flagStatus).Value.String()
+
+	var areaUUID string
+	if area, _ := cmd.Flags().GetString(flagArea); area != "" {
+		a, err := resolve.Area(cmd.Context(), client, area)
+		if err != nil {
+			return fromResolveError(err)
+		}
+		areaUUID = a.UUID
+	}
+
+	todos, err := exportTodos(cmd, client, list, status, areaUUID)
+	if err != nil {
+		return err
+	}
+	if tag, _ := cmd.Flags().GetString(flagTag); tag != "" {
+		todos = filterByTag(todos, todoAccessors.tags, tag)
+	}
+
+	w := cmd.OutOrStdout()
+	switch format {
+	case exportCSV:
+		return writeTodosCSV(w, todos)
+	case exportMarkdown:
+		return writeTodosMarkdown(w, todos)
+	default:
+		return writeJSON(w, todos)
+	}
+}
+
+// exportTodos runs the query behind a view with the --status and --area
+// filters applied. Today and Upcoming come from composed client views that
+// hold open todos only, so they take no --status and filter the area in
+// memory on the same column InArea matches.
+func exportTodos(cmd *cobra.Command, client *things3.Client, list, status, areaUUID string) ([]things3.Todo, error) {
+	ctx := cmd.Context()
+	var view func() ([]things3.Todo, error)
+	switch list {
+	case nameToday:
+		view = func() ([]things3.Todo, error) { return client.Today(ctx) }
+	case nameUpcoming:
+		view = func() ([]things3.Todo, error) { return client.Upcoming(ctx) }
+	}
+	if view != nil {
+		if status != "" && status != statusOpen {
+			return nil, fmt.Errorf("invalid --%s %s: --%s %s holds open todos only", flagStatus, status, flagList, list)
+		}
+		todos, err := view()
+		if err != nil || areaUUID == "" {
+			return todos, err
+		}
+		return slices.DeleteFunc(todos, func(t things3.Todo) bool { return t.AreaUUID != areaUUID }), nil
+	}
+
+	q := client.Todos()
+	switch list {
+	case nameInbox:
+		q = q.Start().Inbox()
+	case nameAnytime:
+		q = q.Start().Anytime()
+	case nameSomeday:
+		q = q.StartDate().Exists(false).Start().Someday()
+	case nameLogbook:
+		q = q.StopDate().Exists(true)
+	}
+	if status == "" {
+		status = statusOpen
+		if list == nameLogbook {
+			status = statusAny
+		}
+	}
+	switch status {
+	case statusCompleted:
+		q = q.Status().Completed()
+	case statusCanceled:
+		q = q.Status().Canceled()
+	case statusAny:
+		q = q.Status().Any()
+	default:
+		q = q.Status().Incomplete()
+	}
+	if areaUUID != "" {
+		q = q.InArea(areaUUID)
+	}
+	todos, err := q.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if list == nameLogbook {
+		sortByStopTimeDesc(todos)
+	}
+	return todos, nil
+}
+
+// csvHeader names the columns writeTodosCSV emits.
+var csvHeader = []string{
+	"uuid", "title", "status", "start", "start_date", "deadline",
+	"project", "heading", "area", "tags", "completed_at", "notes",
+}
+
+// writeTodosCSV writes a header row and one row per todo. Dates are
+// YYYY-MM-DD, completion times RFC 3339, and tags are comma-separated inside
+// their field; encoding/csv quotes any field that needs it.
+func writeTodosCSV(w io.Writer, todos []things3.Todo) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for i := range todos {
+		t := &todos[i]
+		var completed string
+		if t.CompletedAt != nil {
+			completed = t.CompletedAt.Format(time.RFC3339)
+		}
+		row := []string{
+			t.UUID, t.Title, t.Status.String(), t.Start.String(),
+			csvDate(t.StartDate), csvDate(t.Deadline),
+			t.ProjectTitle, t.HeadingTitle, t.AreaTitle,
+			strings.Join(t.Tags, ","), completed, t.Notes,
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvDate formats an optional date for a CSV field.
+func csvDate(d *time.Time) string {
+	if d == nil {
+		return ""
+	}
+	return d.Format(time.DateOnly)
+}
+
+// writeTodosMarkdown writes todos as a Markdown checklist: "- [x]" for a
+// closed todo with a canceled title struck through, tags as #hashtags with
+// spaces turned into hyphens, and the deadline last.
+func writeTodosMarkdown(w io.Writer, todos []things3.Todo) error {
+	for i := range todos {
+		t := &todos[i]
+		box, title := "[ ]", t.Title
+		switch t.Status {
+		case things3.StatusCompleted:
+			box = "[x]"
+		case things3.StatusCanceled:
+			box, title = "[x]", "~~"+title+"~~"
+		}
+		line := "- " + box + " " + title
+		for _, tag := range t.Tags {
+			line += " #" + strings.ReplaceAll(tag, " ", "-")
+		}
+		if t.Deadline != nil {
+			line += " (due " + t.Deadline.Format(time.DateOnly) + ")"
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/moond4rk/things3"
+	"github.com/moond4rk/things3/thingstest"
+)
+
+func TestExportJSON(t *testing.T) {
+	setupFixtureDB(t)
+	stdout, stderr, err := executeCommand(t, "export", "--list", "inbox")
+	if err != nil {
+		t.Fatalf("export: %v (stderr %s)", err, stderr)
+	}
+	var todos []things3.Todo
+	if err := json.Unmarshal([]byte(stdout), &todos); err != nil {
+		t.Fatalf("output is not a JSON todo array: %v\n%s", err, stdout)
+	}
+	if len(todos) != thingstest.Inbox {
+		t.Errorf("exported %d inbox todos, want %d", len(todos), thingstest.Inbox)
+	}
+	if !strings.Contains(stdout, `"status": "incomplete"`) {
+		t.Errorf("status must encode as its name:\n%s", stdout)
+	}
+}
+
+func TestExportCSV(t *testing.T) {
+	setupFixtureDB(t)
+	stdout, stderr, err := executeCommand(t, "export", "--list", "anytime", "--format", "csv")
+	if err != nil {
+		t.Fatalf("export: %v (stderr %s)", err, stderr)
+	}
+	records, err := csv.NewReader(strings.NewReader(stdout)).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not CSV: %v\n%s", err, stdout)
+	}
+	if len(records) != thingstest.TodosAnytime+1 {
+		t.Fatalf("got %d records, want a header plus %d rows", len(records), thingstest.TodosAnytime)
+	}
+	if got := strings.Join(records[0], ","); got != strings.Join(csvHeader, ",") {
+		t.Errorf("header = %q", got)
+	}
+	for _, rec := range records[1:] {
+		if len(rec) != len(csvHeader) {
+			t.Errorf("row %v has %d fields, want %d", rec, len(rec), len(csvHeader))
+		}
+		if rec[2] != "incomplete" || rec[3] != "anytime" {
+			t.Errorf("row %v: status/start = %s/%s", rec, rec[2], rec[3])
+		}
+	}
+	if !strings.Contains(stdout, "\"With\nNotes\"") {
+		t.Errorf("multi-line notes must be quoted:\n%s", stdout)
+	}
+}
+
+func TestExportMarkdown(t *testing.T) {
+	setupFixtureDB(t)
+	stdout, stderr, err := executeCommand(t, "export", "--list", "logbook", "--status", "completed", "--format", "markdown")
+	if err != nil {
+		t.Fatalf("export: %v (stderr %s)", err, stderr)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) == 0 || lines[0] == "" {
+		t.Fatal("no completed todos exported")
+	}
+	for _, line := range lines {
+		if !strings.HasPrefix(line, "- [x] ") || strings.Contains(line, "~~") {
+			t.Errorf("completed todo line = %q", line)
+		}
+	}
+}
+
+func TestExportFilters(t *testing.T) {
+	setupFixtureDB(t)
+
+	stdout, stderr, err := executeCommand(t, "export", "--list", "anytime", "--area", "Area 1")
+	if err != nil {
+		t.Fatalf("export --area: %v (stderr %s)", err, stderr)
+	}
+	var inArea []things3.Todo
+	if err := json.Unmarshal([]byte(stdout), &inArea); err != nil {
+		t.Fatal(err)
+	}
+	if len(inArea) == 0 {
+		t.Fatal("--area Area 1 exported nothing")
+	}
+	for _, todo := range inArea {
+		if todo.AreaTitle != "Area 1" {
+			t.Errorf("--area Area 1 exported %q from area %q", todo.Title, todo.AreaTitle)
+		}
+	}
+
+	stdout, stderr, err = executeCommand(t, "export", "--list", "anytime", "--tag", "important", "--format", "csv")
+	if err != nil {
+		t.Fatalf("export --tag: %v (stderr %s)", err, stderr)
+	}
+	records, err := csv.NewReader(strings.NewReader(stdout)).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || records[1][1] != "To-Do in Project" {
+		t.Errorf("--tag important exported %v", records)
+	}
+
+	_, _, err = executeCommand(t, "export", "--list", "today", "--status", "completed")
+	if err == nil {
+		t.Error("--status completed must be rejected for today")
+	}
+	_, _, err = executeCommand(t, "export", "--format", "xml")
+	if err == nil {
+		t.Error("an unknown --format must be rejected")
+	}
+}
@@ -76,6 +76,7 @@ func registerCommands(root *cobra.Command) {
 		newTagsCmd(),
 		newShowCmd(),
 		newSearchCmd(),
+		newExportCmd(),
 		newAddCmd(),
 		newDoneCmd(),
 		newCancelCmd(),
@@ -34,6 +34,7 @@ const (
 	flagHeading       = "heading"
 	flagChecklist     = "checklist"
 	flagTodos         = "todos"
+	flagReveal        = "reveal"
 )
 
 // addWriteFlags adds the flags shared by every action command.
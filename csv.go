@@ -0,0 +1,142 @@
+package things3
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"time"
+
+	"github.com/moond4rk/things3/internal/database"
+)
+
+// CSVColumn names a todo field written by MarshalCSV. The value doubles as
+// the header cell and matches the field's JSON name.
+type CSVColumn string
+
+// CSV columns accepted by MarshalCSV.
+const (
+	CSVColumnUUID        CSVColumn = "uuid"
+	CSVColumnTitle       CSVColumn = "title"
+	CSVColumnStatus      CSVColumn = "status"
+	CSVColumnNotes       CSVColumn = "notes"
+	CSVColumnStart       CSVColumn = "start"
+	CSVColumnArea        CSVColumn = "area_title"
+	CSVColumnProject     CSVColumn = "project_title"
+	CSVColumnHeading     CSVColumn = "heading_title"
+	CSVColumnTags        CSVColumn = "tags"
+	CSVColumnStartDate   CSVColumn = "start_date"
+	CSVColumnDeadline    CSVColumn = "deadline"
+	CSVColumnCreatedAt   CSVColumn = "created_at"
+	CSVColumnModifiedAt  CSVColumn = "modified_at"
+	CSVColumnCompletedAt CSVColumn = "completed_at"
+	CSVColumnCanceledAt  CSVColumn = "canceled_at"
+)
+
+// csvColumns is the allow-list MarshalCSV checks columns against.
+var csvColumns = []CSVColumn{
+	CSVColumnUUID, CSVColumnTitle, CSVColumnStatus, CSVColumnNotes, CSVColumnStart,
+	CSVColumnArea, CSVColumnProject, CSVColumnHeading, CSVColumnTags,
+	CSVColumnStartDate, CSVColumnDeadline, CSVColumnCreatedAt, CSVColumnModifiedAt,
+	CSVColumnCompletedAt, CSVColumnCanceledAt,
+}
+
+// defaultCSVColumns is the column set MarshalCSV writes when given none.
+var defaultCSVColumns = []CSVColumn{
+	CSVColumnUUID,
+	CSVColumnTitle,
+	CSVColumnStatus,
+	CSVColumnProject,
+	CSVColumnArea,
+	CSVColumnTags,
+	CSVColumnStartDate,
+	CSVColumnDeadline,
+	CSVColumnCreatedAt,
+	CSVColumnCompletedAt,
+}
+
+// MarshalCSV renders todos as CSV for spreadsheet analysis: a header row of
+// column names, then one row per todo with only the requested columns, in
+// order. A nil cols selects uuid, title, status, project and area titles,
+// tags, start date, deadline, created and completed time. Timestamps are
+// RFC 3339, start dates and deadlines YYYY-MM-DD, and tags share one cell
+// joined by semicolons; an unset value is an empty cell. It returns
+// ErrInvalidCSVColumn for a column it does not know.
+func MarshalCSV(todos []Todo, cols []CSVColumn) ([]byte, error) {
+	if cols == nil {
+		cols = defaultCSVColumns
+	}
+	header := make([]string, len(cols))
+	for i, col := range cols {
+		if err := database.CheckColumn(ErrInvalidCSVColumn, col, csvColumns); err != nil {
+			return nil, err
+		}
+		header[i] = string(col)
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(header); err != nil {
+		return nil, err
+	}
+	record := make([]string, len(cols))
+	for i := range todos {
+		for j, col := range cols {
+			record[j] = csvCell(&todos[i], col)
+		}
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// csvCell returns the cell for one column of a todo, or "" for a column
+// outside csvColumns.
+func csvCell(todo *Todo, col CSVColumn) string {
+	switch col {
+	case CSVColumnUUID:
+		return todo.UUID
+	case CSVColumnTitle:
+		return todo.Title
+	case CSVColumnStatus:
+		return todo.Status.String()
+	case CSVColumnNotes:
+		return todo.Notes
+	case CSVColumnStart:
+		return todo.Start.String()
+	case CSVColumnArea:
+		return todo.AreaTitle
+	case CSVColumnProject:
+		return todo.ProjectTitle
+	case CSVColumnHeading:
+		return todo.HeadingTitle
+	case CSVColumnTags:
+		return strings.Join(todo.Tags, ";")
+	case CSVColumnStartDate:
+		return csvTime(todo.StartDate, time.DateOnly)
+	case CSVColumnDeadline:
+		return csvTime(todo.Deadline, time.DateOnly)
+	case CSVColumnCreatedAt:
+		return csvTime(&todo.CreatedAt, time.RFC3339)
+	case CSVColumnModifiedAt:
+		return csvTime(&todo.ModifiedAt, time.RFC3339)
+	case CSVColumnCompletedAt:
+		return csvTime(todo.CompletedAt, time.RFC3339)
+	case CSVColumnCanceledAt:
+		return csvTime(todo.CanceledAt, time.RFC3339)
+	default:
+		return ""
+	}
+}
+
+// csvTime formats t with layout, or returns "" for a nil or zero time.
+func csvTime(t *time.Time, layout string) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.Format(layout)
+}
@@ -0,0 +1,89 @@
+package things3
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readCSV parses MarshalCSV output back into records.
+func readCSV(t *testing.T, data []byte) [][]string {
+	t.Helper()
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	require.NoError(t, err)
+	return records
+}
+
+func TestMarshalCSV(t *testing.T) {
+	created := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+	done := time.Date(2024, 3, 2, 18, 0, 0, 0, time.UTC)
+	deadline := time.Date(2024, 3, 15, 0, 0, 0, 0, time.Local)
+	todos := []Todo{
+		{
+			UUID:         "A",
+			Title:        "Buy milk",
+			Status:       StatusCompleted,
+			ProjectTitle: "Errands",
+			Tags:         []string{"home", "shop"},
+			Deadline:     &deadline,
+			CreatedAt:    created,
+			CompletedAt:  &done,
+		},
+		{UUID: "B", Title: "Plain"},
+	}
+
+	t.Run("default columns", func(t *testing.T) {
+		data, err := MarshalCSV(todos, nil)
+		require.NoError(t, err)
+		records := readCSV(t, data)
+		require.Len(t, records, 3)
+		assert.Equal(t, []string{
+			"uuid", "title", "status", "project_title", "area_title", "tags",
+			"start_date", "deadline", "created_at", "completed_at",
+		}, records[0])
+		assert.Equal(t, []string{
+			"A", "Buy milk", "completed", "Errands", "", "home;shop",
+			"", "2024-03-15", "2024-03-01T09:30:00Z", "2024-03-02T18:00:00Z",
+		}, records[1])
+		assert.Equal(t, "", records[2][8], "zero created time is an empty cell")
+	})
+
+	t.Run("requested columns in order", func(t *testing.T) {
+		data, err := MarshalCSV(todos, []CSVColumn{CSVColumnTitle, CSVColumnUUID})
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{{"title", "uuid"}, {"Buy milk", "A"}, {"Plain", "B"}}, readCSV(t, data))
+	})
+
+	t.Run("quoting round-trips", func(t *testing.T) {
+		titles := []string{`comma, here`, `say "hi"`, "two\nlines", `all, "of"` + "\nit"}
+		in := make([]Todo, len(titles))
+		for i, title := range titles {
+			in[i] = Todo{Title: title, Notes: title}
+		}
+		data, err := MarshalCSV(in, []CSVColumn{CSVColumnTitle, CSVColumnNotes})
+		require.NoError(t, err)
+		records := readCSV(t, data)
+		require.Len(t, records, len(titles)+1)
+		for i, title := range titles {
+			assert.Equal(t, []string{title, title}, records[i+1])
+		}
+	})
+
+	t.Run("no todos writes the header", func(t *testing.T) {
+		data, err := MarshalCSV(nil, []CSVColumn{CSVColumnTitle})
+		require.NoError(t, err)
+		assert.Equal(t, "title\n", string(data))
+	})
+
+	t.Run("unknown column", func(t *testing.T) {
+		_, err := MarshalCSV(todos, []CSVColumn{CSVColumnTitle, "priority"})
+		require.ErrorIs(t, err, ErrInvalidCSVColumn)
+		require.ErrorIs(t, err, ErrInvalidColumn)
+		assert.NotErrorIs(t, err, ErrInvalidTaskColumn)
+		assert.ErrorContains(t, err, "priority")
+	})
+}
@@ -0,0 +1,126 @@
+package things3
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+
+	"github.com/moond4rk/things3/internal/database"
+)
+
+// PageResult is one page of a todo or project query read with Page.
+type PageResult[T any] struct {
+	Items []T `json:"items"`
+	// NextCursor resumes the query after the last item when passed to
+	// AfterCursor. It is empty on the last page.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// cursorToken is the decoded form of a page cursor: the last row's position
+// in the index ordering the page was read with.
+type cursorToken struct {
+	Column string `json:"c"`
+	Index  int    `json:"i"`
+	UUID   string `json:"u"`
+}
+
+// encodeCursor returns the opaque cursor resuming a query ordered by the
+// column index after row.
+func encodeCursor(column string, row *database.TaskRow) string {
+	token := cursorToken{Column: column, Index: row.Index, UUID: row.UUID}
+	if column == database.IndexToday {
+		token.Index = row.TodayIndex
+	}
+	data, _ := json.Marshal(token) // cannot fail for strings and ints
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor parses a cursor made by encodeCursor, returning
+// ErrInvalidCursor for anything else.
+func decodeCursor(cursor string) (*database.TaskKey, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var token cursorToken
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if token.Column != database.IndexDefault && token.Column != database.IndexToday || token.UUID == "" {
+		return nil, ErrInvalidCursor
+	}
+	return &database.TaskKey{Column: token.Column, Index: token.Index, UUID: token.UUID}, nil
+}
+
+// afterCursor decodes cursor into the filter, recording a malformed cursor
+// so the query reports it when executed.
+func (q *taskQuery) afterCursor(cursor string) {
+	q.filter.After, q.cursorErr = nil, nil
+	if cursor == "" {
+		return
+	}
+	q.filter.After, q.cursorErr = decodeCursor(cursor)
+}
+
+// checkCursor reports a cursor the query cannot honor: a malformed one, or
+// one used with an ordering other than the index it was made for.
+func (q *taskQuery) checkCursor() error {
+	if q.cursorErr != nil {
+		return q.cursorErr
+	}
+	f := &q.filter
+	if f.After != nil && (len(f.OrderBy) > 0 || f.TodayView || f.After.Column != f.Index) {
+		return ErrCursorOrder
+	}
+	return nil
+}
+
+// page reads up to size rows after the query's cursor, plus one more to learn
+// whether another page follows, and returns the cursor for that page. Rows
+// skipped under WithSkipCorruptRows count as read but not as page items, so
+// page reads on past them until it has size+1 rows or the result ends.
+func (q *taskQuery) page(ctx context.Context, size int) (rows []database.TaskRow, next string, skipped, err error) {
+	if size <= 0 {
+		return nil, "", nil, ErrInvalidPageSize
+	}
+	if err := q.check(); err != nil {
+		return nil, "", nil, err
+	}
+	f := &q.filter
+	if len(f.OrderBy) > 0 || f.TodayView {
+		return nil, "", nil, ErrCursorOrder
+	}
+	c := *q
+	c.filter.Keyset = true
+	offset := 0
+	if f.Offset != nil && f.After == nil {
+		// The cursor already passed the offset rows on the first page.
+		offset = *f.Offset
+	}
+	var corrupt skippedRows
+	for len(rows) <= size {
+		limit := size + 1 - len(rows)
+		c.filter.Limit, c.filter.Offset = new(limit), new(offset)
+		chunk, passed, err := c.queryTasks(ctx)
+		if err != nil {
+			return nil, "", nil, err
+		}
+		_ = corrupt.add(passed)
+		read := len(chunk)
+		var skippedErr *CorruptRowsError
+		if errors.As(passed, &skippedErr) {
+			read += skippedErr.Skipped
+		}
+		rows = append(rows, chunk...)
+		if read < limit {
+			break
+		}
+		offset += read
+	}
+	if len(rows) > size {
+		rows = rows[:size]
+		next = encodeCursor(f.Index, &rows[size-1])
+	}
+	return rows, next, corrupt.result(), nil
+}
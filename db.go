@@ -2,6 +2,7 @@ package things3
 
 import (
 	"context"
+	"time"
 
 	"github.com/moond4rk/things3/internal/database"
 )
@@ -53,6 +54,10 @@ func convertTaskRowToTodo(r *database.TaskRow) Todo {
 		Trashed:    r.Trashed,
 		Evening:    r.Evening,
 		Repeating:  r.Repeating,
+
+		RecurrenceRule: convertRecurrenceRule(r.RecurrenceRule),
+
+		deadlineSuppressed: r.DeadlineSuppressed,
 	}
 
 	// Convert status string to Status enum
@@ -87,6 +92,34 @@ func convertTaskRowToTodo(r *database.TaskRow) Todo {
 	return todo
 }
 
+// convertRecurrenceRule converts a decoded database rule to a public
+// RecurrenceRule, returning nil for nil.
+func convertRecurrenceRule(r *database.RecurrenceRule) *RecurrenceRule {
+	if r == nil {
+		return nil
+	}
+	rule := &RecurrenceRule{
+		Interval:    r.Interval,
+		DaysOfMonth: r.DaysOfMonth,
+		Until:       r.Until,
+		Count:       r.Count,
+	}
+	switch r.Unit {
+	case database.RecurrenceUnitDay:
+		rule.Frequency = FrequencyDaily
+	case database.RecurrenceUnitWeek:
+		rule.Frequency = FrequencyWeekly
+	case database.RecurrenceUnitMonth:
+		rule.Frequency = FrequencyMonthly
+	case database.RecurrenceUnitYear:
+		rule.Frequency = FrequencyYearly
+	}
+	for _, wd := range r.Weekdays {
+		rule.Weekdays = append(rule.Weekdays, time.Weekday(wd))
+	}
+	return rule
+}
+
 // convertTaskRowToProject converts an internal TaskRow to a public Project.
 func convertTaskRowToProject(r *database.TaskRow) Project {
 	project := Project{
@@ -100,6 +133,8 @@ func convertTaskRowToProject(r *database.TaskRow) Project {
 		ModifiedAt: r.Modified,
 		Trashed:    r.Trashed,
 		Repeating:  r.Repeating,
+
+		RecurrenceRule: convertRecurrenceRule(r.RecurrenceRule),
 	}
 
 	project.Status = parseStatusFromString(r.Status)
@@ -137,8 +172,9 @@ func convertTaskRowToHeading(r *database.TaskRow) Heading {
 // convertAreaRow converts an internal AreaRow to a public Area.
 func convertAreaRow(r database.AreaRow) Area {
 	area := Area{
-		UUID:  r.UUID,
-		Title: r.Title,
+		UUID:    r.UUID,
+		Title:   r.Title,
+		Visible: r.Visible,
 	}
 	if r.HasTags {
 		area.Tags = []string{}
@@ -149,9 +185,10 @@ func convertAreaRow(r database.AreaRow) Area {
 // convertTagRow converts an internal TagRow to a public Tag.
 func convertTagRow(r database.TagRow) Tag {
 	return Tag{
-		UUID:     r.UUID,
-		Title:    r.Title,
-		Shortcut: r.Shortcut,
+		UUID:       r.UUID,
+		Title:      r.Title,
+		Shortcut:   r.Shortcut,
+		ParentUUID: r.ParentUUID,
 	}
 }
 
@@ -0,0 +1,135 @@
+package things3
+
+import (
+	"slices"
+	"time"
+)
+
+// TodoDiff is the difference between two snapshots of todos, matched by UUID.
+type TodoDiff struct {
+	Added   []Todo       `json:"added"`
+	Removed []Todo       `json:"removed"`
+	Changed []TodoChange `json:"changed"`
+}
+
+// TodoChange describes a todo present in both snapshots whose fields differ.
+// Fields lists the JSON names of the differing fields in Todo field order.
+type TodoChange struct {
+	UUID   string   `json:"uuid"`
+	Old    Todo     `json:"old"`
+	New    Todo     `json:"new"`
+	Fields []string `json:"fields"`
+}
+
+// IsEmpty reports whether the snapshots were identical.
+func (d *TodoDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// DiffTodos compares two snapshots of todos, such as the results of the same
+// query taken at two polls. Added keeps the order of newTodos, Removed the
+// order of oldTodos, and Changed the order of newTodos. Time fields compare by
+// instant, so a value reloaded in another location is not a change. All
+// slices in the result are non-nil.
+func DiffTodos(oldTodos, newTodos []Todo) TodoDiff {
+	diff := TodoDiff{Added: []Todo{}, Removed: []Todo{}, Changed: []TodoChange{}}
+
+	before := make(map[string]*Todo, len(oldTodos))
+	for i := range oldTodos {
+		before[oldTodos[i].UUID] = &oldTodos[i]
+	}
+	after := make(map[string]bool, len(newTodos))
+	for i := range newTodos {
+		n := &newTodos[i]
+		after[n.UUID] = true
+		o, ok := before[n.UUID]
+		if !ok {
+			diff.Added = append(diff.Added, *n)
+			continue
+		}
+		if fields := changedTodoFields(o, n); len(fields) > 0 {
+			diff.Changed = append(diff.Changed, TodoChange{UUID: n.UUID, Old: *o, New: *n, Fields: fields})
+		}
+	}
+	for i := range oldTodos {
+		if !after[oldTodos[i].UUID] {
+			diff.Removed = append(diff.Removed, oldTodos[i])
+		}
+	}
+	return diff
+}
+
+// changedTodoFields returns the JSON names of the fields that differ between
+// a and b.
+func changedTodoFields(a, b *Todo) []string {
+	checks := []struct {
+		name  string
+		equal bool
+	}{
+		{"title", a.Title == b.Title},
+		{"status", a.Status == b.Status},
+		{"notes", a.Notes == b.Notes},
+		{"start", a.Start == b.Start},
+		{"area_uuid", a.AreaUUID == b.AreaUUID},
+		{"area_title", a.AreaTitle == b.AreaTitle},
+		{"project_uuid", a.ProjectUUID == b.ProjectUUID},
+		{"project_title", a.ProjectTitle == b.ProjectTitle},
+		{"heading_uuid", a.HeadingUUID == b.HeadingUUID},
+		{"heading_title", a.HeadingTitle == b.HeadingTitle},
+		{"tags", slices.Equal(a.Tags, b.Tags)},
+		{"checklist", slices.EqualFunc(a.Checklist, b.Checklist, checklistItemEqual)},
+		{"start_date", timePtrEqual(a.StartDate, b.StartDate)},
+		{"deadline", timePtrEqual(a.Deadline, b.Deadline)},
+		{"reminder", timePtrEqual(a.Reminder, b.Reminder)},
+		{"created_at", a.CreatedAt.Equal(b.CreatedAt)},
+		{"modified_at", a.ModifiedAt.Equal(b.ModifiedAt)},
+		{"completed_at", timePtrEqual(a.CompletedAt, b.CompletedAt)},
+		{"canceled_at", timePtrEqual(a.CanceledAt, b.CanceledAt)},
+		{"trashed", a.Trashed == b.Trashed},
+		{"evening", a.Evening == b.Evening},
+		{"repeating", a.Repeating == b.Repeating},
+		{"recurrence_rule", recurrenceRuleEqual(a.RecurrenceRule, b.RecurrenceRule)},
+	}
+
+	var fields []string
+	for _, c := range checks {
+		if !c.equal {
+			fields = append(fields, c.name)
+		}
+	}
+	return fields
+}
+
+// checklistItemEqual reports whether two checklist items are the same.
+func checklistItemEqual(a, b ChecklistItem) bool {
+	return a.UUID == b.UUID &&
+		a.Title == b.Title &&
+		a.Status == b.Status &&
+		a.CreatedAt.Equal(b.CreatedAt) &&
+		a.ModifiedAt.Equal(b.ModifiedAt) &&
+		timePtrEqual(a.CompletedAt, b.CompletedAt) &&
+		timePtrEqual(a.CanceledAt, b.CanceledAt)
+}
+
+// recurrenceRuleEqual reports whether two optional recurrence rules are both
+// unset or describe the same schedule.
+func recurrenceRuleEqual(a, b *RecurrenceRule) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Frequency == b.Frequency &&
+		a.Interval == b.Interval &&
+		slices.Equal(a.Weekdays, b.Weekdays) &&
+		slices.Equal(a.DaysOfMonth, b.DaysOfMonth) &&
+		timePtrEqual(a.Until, b.Until) &&
+		a.Count == b.Count
+}
+
+// timePtrEqual reports whether two optional times are both unset or the same
+// instant.
+func timePtrEqual(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
@@ -0,0 +1,87 @@
+package things3
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffTodos(t *testing.T) {
+	deadline := time.Date(2026, time.May, 1, 0, 0, 0, 0, time.UTC)
+	sameInstant := deadline.In(time.FixedZone("UTC+2", 2*60*60))
+	later := deadline.AddDate(0, 0, 1)
+
+	oldTodos := []Todo{
+		{UUID: "kept", Title: "Kept", Deadline: &deadline},
+		{UUID: "edited", Title: "Before", Tags: []string{"a"}},
+		{UUID: "gone", Title: "Gone"},
+		{UUID: "moved", Title: "Moved", Deadline: &deadline},
+	}
+	newTodos := []Todo{
+		{UUID: "fresh", Title: "Fresh"},
+		{UUID: "moved", Title: "Moved", Deadline: &later},
+		{UUID: "edited", Title: "After", Tags: []string{"a", "b"}, Status: StatusCompleted},
+		{UUID: "kept", Title: "Kept", Deadline: &sameInstant},
+	}
+
+	diff := DiffTodos(oldTodos, newTodos)
+	assert.Equal(t, []string{"fresh"}, extractTodoUUIDs(diff.Added))
+	assert.Equal(t, []string{"gone"}, extractTodoUUIDs(diff.Removed))
+	require.Len(t, diff.Changed, 2, "a deadline in another zone is the same instant")
+
+	assert.Equal(t, "moved", diff.Changed[0].UUID)
+	assert.Equal(t, []string{"deadline"}, diff.Changed[0].Fields)
+	assert.Equal(t, "edited", diff.Changed[1].UUID)
+	assert.Equal(t, []string{"title", "status", "tags"}, diff.Changed[1].Fields)
+	assert.Equal(t, "Before", diff.Changed[1].Old.Title)
+	assert.Equal(t, "After", diff.Changed[1].New.Title)
+	assert.False(t, diff.IsEmpty())
+}
+
+func TestDiffTodosIdentical(t *testing.T) {
+	client := newTestClient(t)
+	ctx := t.Context()
+
+	first, err := client.Todos().Status().Any().IncludeChecklist().All(ctx)
+	require.NoError(t, err)
+	second, err := client.Todos().Status().Any().IncludeChecklist().All(ctx)
+	require.NoError(t, err)
+
+	diff := DiffTodos(first, second)
+	assert.True(t, diff.IsEmpty())
+	assert.NotNil(t, diff.Added)
+	assert.NotNil(t, diff.Removed)
+	assert.NotNil(t, diff.Changed)
+}
+
+func TestDiffTodosChecklist(t *testing.T) {
+	done := time.Now()
+	oldTodos := []Todo{{UUID: "t", Checklist: []ChecklistItem{{UUID: "c", Title: "Step"}}}}
+	newTodos := []Todo{{UUID: "t", Checklist: []ChecklistItem{{
+		UUID: "c", Title: "Step", Status: StatusCompleted, CompletedAt: &done,
+	}}}}
+
+	diff := DiffTodos(oldTodos, newTodos)
+	require.Len(t, diff.Changed, 1)
+	assert.Equal(t, []string{"checklist"}, diff.Changed[0].Fields)
+}
+
+func TestDiffTodosRecurrenceRule(t *testing.T) {
+	weekly := &RecurrenceRule{Frequency: FrequencyWeekly, Interval: 1, Weekdays: []time.Weekday{time.Monday}}
+	oldTodos := []Todo{{UUID: "t", Repeating: true, RecurrenceRule: weekly}}
+	newTodos := []Todo{{UUID: "t", Repeating: true, RecurrenceRule: &RecurrenceRule{
+		Frequency: FrequencyWeekly, Interval: 1, Weekdays: []time.Weekday{time.Monday, time.Thursday},
+	}}}
+
+	diff := DiffTodos(oldTodos, newTodos)
+	require.Len(t, diff.Changed, 1)
+	assert.Equal(t, []string{"recurrence_rule"}, diff.Changed[0].Fields)
+
+	same := []Todo{{UUID: "t", Repeating: true, RecurrenceRule: &RecurrenceRule{
+		Frequency: FrequencyWeekly, Interval: 1, Weekdays: []time.Weekday{time.Monday},
+	}}}
+	unchanged := DiffTodos(oldTodos, same)
+	assert.True(t, unchanged.IsEmpty(), "equal rules behind different pointers")
+}
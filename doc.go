@@ -24,6 +24,11 @@
 //	    StartDate().Future().
 //	    All(ctx)
 //
+// A query builder filters only what its chain asks for: without a Status call
+// it returns open, completed, and canceled items alike. The composed views
+// (Today, Upcoming, UpcomingDeadlines, and similar) select open todos because
+// the app's lists they mirror do.
+//
 // # URL Scheme
 //
 // Create and update items via Things URL Scheme:
@@ -38,6 +43,14 @@
 //	// Update existing items (auth token managed automatically)
 //	client.UpdateTodo("uuid").Completed(true).Execute(ctx)
 //
+// # Reading After a Write
+//
+// Execute returns once the URL has been handed to Things, not once the change
+// is stored. The database records no per-item sync or pending state that a
+// query could wait on (Things Cloud bookkeeping lives in opaque metadata
+// blobs), so confirm a write by polling for its effect, for example
+// re-reading the item until ModifiedAt advances or the expected title appears.
+//
 // # Configuration
 //
 // Configure the client with functional options:
@@ -11,24 +11,99 @@ import (
 var (
 	// ErrDatabaseNotFound is returned when the Things database cannot be located.
 	ErrDatabaseNotFound = database.ErrDatabaseNotFound
+	// ErrThingsNotInstalled is returned when auto-discovery finds no Things
+	// installation at all. It also matches ErrDatabaseNotFound.
+	ErrThingsNotInstalled = database.ErrThingsNotInstalled
+	// ErrFullDiskAccessRequired is returned when macOS denies reading the
+	// database; the message tells the user how to grant Full Disk Access.
+	ErrFullDiskAccessRequired = database.ErrFullDiskAccessRequired
 	// ErrDatabaseVersionTooOld is returned when the database version is not supported.
 	ErrDatabaseVersionTooOld = database.ErrDatabaseVersionTooOld
 	// ErrAuthTokenNotFound is returned when the URL scheme auth token cannot be read.
 	ErrAuthTokenNotFound = database.ErrAuthTokenNotFound
+	// ErrInvalidColumn is matched by all four invalid column errors below
+	// and by ErrInvalidCSVColumn.
+	ErrInvalidColumn = database.ErrInvalidColumn
+	// ErrInvalidSearchColumn is returned when WithSearchColumns names an
+	// unsupported column, and by a query whose SearchIn does.
+	ErrInvalidSearchColumn = database.ErrInvalidSearchColumn
+	// ErrInvalidGroupColumn is returned by CountBy for a column outside the
+	// GroupBy constants.
+	ErrInvalidGroupColumn = database.ErrInvalidGroupColumn
+	// ErrInvalidTaskColumn is returned when a query ordered with OrderBy by a
+	// column outside the TaskColumn constants runs.
+	ErrInvalidTaskColumn = database.ErrInvalidTaskColumn
+	// ErrRawArgCount is returned by a query built with WhereRaw whose ?
+	// placeholders and args differ in number.
+	ErrRawArgCount = database.ErrRawArgCount
+	// ErrInvalidDate is returned by ValidateISODate for a malformed or
+	// impossible date.
+	ErrInvalidDate = database.ErrInvalidDate
+	// ErrCorruptRows is matched by the *CorruptRowsError a query returns
+	// under WithSkipCorruptRows.
+	ErrCorruptRows = database.ErrCorruptRows
 )
 
+// CorruptRowsError is returned, together with the readable results, by a
+// todo, project or heading query that skipped unreadable rows under
+// WithSkipCorruptRows. Skipped counts them and First holds the first error.
+type CorruptRowsError = database.CorruptRowsError
+
 // Query Errors
 var (
 	// ErrTodoNotFound is returned when a todo with the specified UUID does not exist.
 	ErrTodoNotFound = errors.New("things3: todo not found")
 	// ErrProjectNotFound is returned when a project with the specified UUID does not exist.
 	ErrProjectNotFound = errors.New("things3: project not found")
+	// ErrAmbiguousProject is returned when a project title matches more than one open project.
+	ErrAmbiguousProject = errors.New("things3: project title is ambiguous")
+	// ErrProjectNotCreated is returned by EnsureProject when a project it
+	// created does not show up in the database in time.
+	ErrProjectNotCreated = errors.New("things3: created project did not appear in the database")
+	// ErrTodoNotCreated is returned by CreateAndFetch when the todo it
+	// created does not show up in the database in time.
+	ErrTodoNotCreated = errors.New("things3: created todo did not appear in the database")
 	// ErrHeadingNotFound is returned when a heading with the specified UUID does not exist.
 	ErrHeadingNotFound = errors.New("things3: heading not found")
 	// ErrAreaNotFound is returned when an area with the specified UUID does not exist.
 	ErrAreaNotFound = errors.New("things3: area not found")
 	// ErrTagNotFound is returned when a tag with the specified title does not exist.
 	ErrTagNotFound = errors.New("things3: tag not found")
+	// ErrChecklistItemNotFound is returned when a checklist item with the specified UUID does not exist.
+	ErrChecklistItemNotFound = errors.New("things3: checklist item not found")
+	// ErrInvalidCursor is returned by a query given an AfterCursor token that
+	// Page did not produce.
+	ErrInvalidCursor = errors.New("things3: invalid page cursor")
+	// ErrCursorOrder is returned when a cursor is combined with an ordering
+	// other than the index ordering it was made for; keyset paging supports
+	// OrderByIndex and OrderByTodayIndex only.
+	ErrCursorOrder = errors.New("things3: page cursor does not match the query ordering")
+	// ErrInvalidPageSize is returned by Page for a size below one.
+	ErrInvalidPageSize = errors.New("things3: page size must be positive")
+)
+
+// Clipboard Errors
+var (
+	// ErrClipboardEmpty is returned by CreateFromClipboard when the clipboard
+	// holds no text.
+	ErrClipboardEmpty = errors.New("things3: clipboard has no text")
+)
+
+// Markdown Errors
+var (
+	// ErrMarkdownNoTitle is returned by ProjectFromMarkdown when the document
+	// has no "# " line to name the project.
+	ErrMarkdownNoTitle = errors.New("things3: markdown has no # title line")
+)
+
+// Export Errors
+var (
+	// ErrMissingUUID is returned by MarshalICal for a todo without a UUID,
+	// which the calendar entry needs as its UID.
+	ErrMissingUUID = errors.New("things3: todo has no UUID")
+	// ErrInvalidCSVColumn is returned by MarshalCSV for a column it does not
+	// know. It matches ErrInvalidColumn.
+	ErrInvalidCSVColumn = database.ErrInvalidCSVColumn
 )
 
 // URL Scheme Validation Errors - aliased from internal/scheme.
@@ -44,6 +119,12 @@ var (
 	// ErrReminderNeedsDate is returned when a reminder is set without a concrete
 	// start date (someday and anytime cannot carry one).
 	ErrReminderNeedsDate = scheme.ErrReminderNeedsDate
+	// ErrInvalidWhen is returned by ParseWhen when a "when" value is neither
+	// a scheduling keyword nor a yyyy-mm-dd date.
+	ErrInvalidWhen = scheme.ErrInvalidWhen
+	// ErrDeadlineHasTime is returned when a deadline is given a time of day;
+	// Things stores deadlines as dates only.
+	ErrDeadlineHasTime = scheme.ErrDeadlineHasTime
 	// ErrTagContainsComma is returned when a tag name contains a comma.
 	ErrTagContainsComma = scheme.ErrTagContainsComma
 	// ErrTitleContainsNewline is returned when a title contains a newline.
@@ -61,4 +142,22 @@ var (
 	ErrIDRequired = scheme.ErrIDRequired
 	// ErrNoJSONItems is returned when building a JSON URL with no items.
 	ErrNoJSONItems = scheme.ErrNoJSONItems
+	// ErrExecuteTimeout is returned when opening a URL takes longer than the
+	// limit set by WithExecuteTimeout.
+	ErrExecuteTimeout = scheme.ErrExecuteTimeout
+	// ErrCallbackFailed is returned by ExecuteAndWait when Things reports
+	// that the add failed.
+	ErrCallbackFailed = scheme.ErrCallbackFailed
+	// ErrCallbackTimeout is returned by ExecuteAndWait when Things does not
+	// call back within a minute and the context carries no deadline.
+	ErrCallbackTimeout = scheme.ErrCallbackTimeout
+)
+
+// URL Parse Errors - aliased from internal/scheme.
+var (
+	// ErrInvalidURL is returned by ParseURL for a malformed or non-Things URL.
+	ErrInvalidURL = scheme.ErrInvalidURL
+	// ErrUnknownCommand is returned by ParseURL for a command the URL scheme
+	// does not define.
+	ErrUnknownCommand = scheme.ErrUnknownCommand
 )
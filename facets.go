@@ -0,0 +1,56 @@
+package things3
+
+// Ref identifies a related entity by UUID and display title.
+type Ref struct {
+	UUID  string `json:"uuid"`
+	Title string `json:"title"`
+}
+
+// DistinctAreas returns the areas referenced by todos, deduplicated by UUID
+// in order of first appearance. Todos without an area are skipped.
+func DistinctAreas(todos []Todo) []Ref {
+	return distinctRefs(todos, func(t *Todo) Ref {
+		return Ref{UUID: t.AreaUUID, Title: t.AreaTitle}
+	})
+}
+
+// DistinctProjects returns the projects referenced by todos, deduplicated by
+// UUID in order of first appearance. Todos without a project are skipped.
+func DistinctProjects(todos []Todo) []Ref {
+	return distinctRefs(todos, func(t *Todo) Ref {
+		return Ref{UUID: t.ProjectUUID, Title: t.ProjectTitle}
+	})
+}
+
+// DistinctTags returns the tag titles used by todos, deduplicated in order
+// of first appearance.
+func DistinctTags(todos []Todo) []string {
+	tags := make([]string, 0)
+	seen := make(map[string]bool)
+	for i := range todos {
+		for _, tag := range todos[i].Tags {
+			if seen[tag] {
+				continue
+			}
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// distinctRefs collects the non-empty refs produced by ref, keeping the first
+// occurrence of each UUID.
+func distinctRefs(todos []Todo, ref func(*Todo) Ref) []Ref {
+	refs := make([]Ref, 0)
+	seen := make(map[string]bool)
+	for i := range todos {
+		r := ref(&todos[i])
+		if r.UUID == "" || seen[r.UUID] {
+			continue
+		}
+		seen[r.UUID] = true
+		refs = append(refs, r)
+	}
+	return refs
+}
@@ -0,0 +1,51 @@
+package things3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDistinctHelpers(t *testing.T) {
+	todos := []Todo{
+		{UUID: "1", AreaUUID: "a1", AreaTitle: "Work", Tags: []string{"home", "errand"}},
+		{UUID: "2", ProjectUUID: "p1", ProjectTitle: "Launch", Tags: []string{"errand"}},
+		{UUID: "3", AreaUUID: "a2", AreaTitle: "Home", ProjectUUID: "p1", ProjectTitle: "Launch"},
+		{UUID: "4", AreaUUID: "a1", AreaTitle: "Work", Tags: []string{"office"}},
+		{UUID: "5"},
+	}
+
+	assert.Equal(t, []Ref{{UUID: "a1", Title: "Work"}, {UUID: "a2", Title: "Home"}}, DistinctAreas(todos))
+	assert.Equal(t, []Ref{{UUID: "p1", Title: "Launch"}}, DistinctProjects(todos))
+	assert.Equal(t, []string{"home", "errand", "office"}, DistinctTags(todos))
+}
+
+func TestDistinctHelpersEmpty(t *testing.T) {
+	assert.Empty(t, DistinctAreas(nil))
+	assert.NotNil(t, DistinctAreas(nil))
+	assert.NotNil(t, DistinctProjects([]Todo{{UUID: "1"}}))
+	assert.NotNil(t, DistinctTags(nil))
+}
+
+func TestDistinctAreasFromFixture(t *testing.T) {
+	db := newTestDB(t)
+
+	todos, err := db.Todos().Status().Incomplete().All(t.Context())
+	require.NoError(t, err)
+
+	areas := DistinctAreas(todos)
+	uuids := make([]string, len(areas))
+	for i, a := range areas {
+		uuids[i] = a.UUID
+		assert.NotEmpty(t, a.Title)
+	}
+	assert.Contains(t, uuids, testUUIDArea1)
+	assert.Subset(t, testAreaUUIDs, uuids)
+
+	unique := make(map[string]bool, len(uuids))
+	for _, uuid := range uuids {
+		unique[uuid] = true
+	}
+	assert.Len(t, unique, len(uuids), "areas must be unique")
+}
@@ -0,0 +1,94 @@
+package things3
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf8"
+)
+
+// iCalendar layout constants.
+const (
+	icalDate     = "20060102"
+	icalDateTime = "20060102T150405Z"
+	// icalLineOctets is the RFC 5545 limit on a content line, excluding CRLF.
+	icalLineOctets = 75
+)
+
+// icalEscaper escapes TEXT property values per RFC 5545.
+var icalEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// MarshalICal renders todos as an iCalendar (RFC 5545) VCALENDAR with one
+// VTODO each, for importing deadlines into a calendar app. The UUID becomes
+// the UID, the title SUMMARY, notes DESCRIPTION, tags CATEGORIES, the start
+// date DTSTART and the deadline DUE, both as all-day dates. Status maps to
+// NEEDS-ACTION, COMPLETED or CANCELLED, and a completion time to COMPLETED.
+// DTSTAMP is the modification time. Lines longer than 75 octets are folded.
+// It returns ErrMissingUUID for a todo without a UUID.
+func MarshalICal(todos []Todo) ([]byte, error) {
+	var buf bytes.Buffer
+	writeICalLine(&buf, "BEGIN:VCALENDAR")
+	writeICalLine(&buf, "VERSION:2.0")
+	writeICalLine(&buf, "PRODID:-//moond4rk//things3//EN")
+	for i := range todos {
+		todo := &todos[i]
+		if todo.UUID == "" {
+			return nil, ErrMissingUUID
+		}
+		writeICalLine(&buf, "BEGIN:VTODO")
+		writeICalLine(&buf, "UID:"+icalEscaper.Replace(todo.UUID))
+		writeICalLine(&buf, "DTSTAMP:"+todo.ModifiedAt.UTC().Format(icalDateTime))
+		writeICalLine(&buf, "SUMMARY:"+icalEscaper.Replace(todo.Title))
+		if todo.Notes != "" {
+			writeICalLine(&buf, "DESCRIPTION:"+icalEscaper.Replace(todo.Notes))
+		}
+		if len(todo.Tags) > 0 {
+			tags := make([]string, len(todo.Tags))
+			for i, tag := range todo.Tags {
+				tags[i] = icalEscaper.Replace(tag)
+			}
+			writeICalLine(&buf, "CATEGORIES:"+strings.Join(tags, ","))
+		}
+		if todo.StartDate != nil {
+			writeICalLine(&buf, "DTSTART;VALUE=DATE:"+todo.StartDate.Format(icalDate))
+		}
+		if todo.Deadline != nil {
+			writeICalLine(&buf, "DUE;VALUE=DATE:"+todo.Deadline.Format(icalDate))
+		}
+		writeICalLine(&buf, "STATUS:"+icalStatus(todo.Status))
+		if todo.CompletedAt != nil {
+			writeICalLine(&buf, "COMPLETED:"+todo.CompletedAt.UTC().Format(icalDateTime))
+		}
+		writeICalLine(&buf, "END:VTODO")
+	}
+	writeICalLine(&buf, "END:VCALENDAR")
+	return buf.Bytes(), nil
+}
+
+// icalStatus maps a Status to the VTODO STATUS value.
+func icalStatus(s Status) string {
+	switch s {
+	case StatusCompleted:
+		return "COMPLETED"
+	case StatusCanceled:
+		return "CANCELLED"
+	default:
+		return "NEEDS-ACTION"
+	}
+}
+
+// writeICalLine writes a content line with CRLF, folding it into chunks of at
+// most 75 octets, each continuation starting with a space. Folds fall
+// between UTF-8 sequences, never inside one.
+func writeICalLine(buf *bytes.Buffer, line string) {
+	limit := icalLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		buf.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		limit = icalLineOctets - 1
+	}
+	buf.WriteString(line + "\r\n")
+}
@@ -0,0 +1,135 @@
+package things3
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// icalProp is one unfolded content line: NAME;PARAMS:VALUE.
+type icalProp struct {
+	name   string
+	params string
+	value  string
+}
+
+// parseICal is a minimal RFC 5545 tokenizer: it unfolds continuation lines
+// and splits each line into name, parameters and value.
+func parseICal(t *testing.T, data []byte) []icalProp {
+	t.Helper()
+	text := string(data)
+	require.True(t, strings.HasSuffix(text, "\r\n"), "output must end with CRLF")
+	for line := range strings.SplitSeq(strings.TrimSuffix(text, "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), 75, "line exceeds 75 octets: %q", line)
+	}
+	unfolded := strings.ReplaceAll(text, "\r\n ", "")
+
+	var props []icalProp
+	for line := range strings.SplitSeq(strings.TrimSuffix(unfolded, "\r\n"), "\r\n") {
+		head, value, ok := strings.Cut(line, ":")
+		require.True(t, ok, "line has no value: %q", line)
+		name, params, _ := strings.Cut(head, ";")
+		props = append(props, icalProp{name: name, params: params, value: value})
+	}
+	return props
+}
+
+// icalTodos groups the properties of each VTODO component by name.
+func icalTodos(props []icalProp) []map[string]icalProp {
+	var todos []map[string]icalProp
+	var cur map[string]icalProp
+	for _, p := range props {
+		switch {
+		case p.name == "BEGIN" && p.value == "VTODO":
+			cur = map[string]icalProp{}
+		case p.name == "END" && p.value == "VTODO":
+			todos = append(todos, cur)
+			cur = nil
+		case cur != nil:
+			cur[p.name] = p
+		}
+	}
+	return todos
+}
+
+func TestMarshalICal(t *testing.T) {
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local)
+	due := time.Date(2024, 3, 15, 0, 0, 0, 0, time.Local)
+	done := time.Date(2024, 3, 10, 9, 30, 0, 0, time.UTC)
+	modified := time.Date(2024, 3, 10, 9, 30, 0, 0, time.UTC)
+	longNotes := strings.Repeat("ünïcode, text; ", 12)
+
+	todos := []Todo{
+		{
+			UUID:       "open-uuid",
+			Title:      "Pay rent, utilities; internet",
+			Notes:      "line one\nline two",
+			Tags:       []string{"Home", "Bills"},
+			StartDate:  &start,
+			Deadline:   &due,
+			ModifiedAt: modified,
+		},
+		{
+			UUID:        "done-uuid",
+			Title:       "Done",
+			Notes:       longNotes,
+			Status:      StatusCompleted,
+			CompletedAt: &done,
+			ModifiedAt:  modified,
+		},
+		{UUID: "canceled-uuid", Title: "Canceled", Status: StatusCanceled, ModifiedAt: modified},
+	}
+
+	data, err := MarshalICal(todos)
+	require.NoError(t, err)
+	props := parseICal(t, data)
+
+	require.NotEmpty(t, props)
+	assert.Equal(t, icalProp{name: "BEGIN", value: "VCALENDAR"}, props[0])
+	assert.Equal(t, icalProp{name: "END", value: "VCALENDAR"}, props[len(props)-1])
+	assert.Contains(t, props, icalProp{name: "VERSION", value: "2.0"})
+
+	vtodos := icalTodos(props)
+	require.Len(t, vtodos, 3)
+	for _, vt := range vtodos {
+		for _, name := range []string{"UID", "DTSTAMP", "SUMMARY", "STATUS"} {
+			assert.Contains(t, vt, name)
+		}
+		assert.Equal(t, "20240310T093000Z", vt["DTSTAMP"].value)
+	}
+
+	open := vtodos[0]
+	assert.Equal(t, "open-uuid", open["UID"].value)
+	assert.Equal(t, `Pay rent\, utilities\; internet`, open["SUMMARY"].value)
+	assert.Equal(t, `line one\nline two`, open["DESCRIPTION"].value)
+	assert.Equal(t, "Home,Bills", open["CATEGORIES"].value)
+	assert.Equal(t, icalProp{name: "DTSTART", params: "VALUE=DATE", value: "20240301"}, open["DTSTART"])
+	assert.Equal(t, icalProp{name: "DUE", params: "VALUE=DATE", value: "20240315"}, open["DUE"])
+	assert.Equal(t, "NEEDS-ACTION", open["STATUS"].value)
+	assert.NotContains(t, open, "COMPLETED")
+
+	completed := vtodos[1]
+	assert.Equal(t, "COMPLETED", completed["STATUS"].value)
+	assert.Equal(t, "20240310T093000Z", completed["COMPLETED"].value)
+	assert.Equal(t, strings.NewReplacer(",", `\,`, ";", `\;`).Replace(longNotes), completed["DESCRIPTION"].value,
+		"folded description must unfold to the escaped notes")
+	assert.NotContains(t, completed, "DUE")
+
+	assert.Equal(t, "CANCELLED", vtodos[2]["STATUS"].value)
+}
+
+func TestMarshalICalMissingUUID(t *testing.T) {
+	_, err := MarshalICal([]Todo{{Title: "No UUID"}})
+	assert.ErrorIs(t, err, ErrMissingUUID)
+}
+
+func TestMarshalICalEmpty(t *testing.T) {
+	data, err := MarshalICal(nil)
+	require.NoError(t, err)
+	props := parseICal(t, data)
+	assert.Empty(t, icalTodos(props))
+	assert.Equal(t, "VCALENDAR", props[0].value)
+}
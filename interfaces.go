@@ -15,20 +15,45 @@ import (
 type TodoQueryExecutor interface {
 	All(ctx context.Context) ([]Todo, error)
 	First(ctx context.Context) (*Todo, error)
+	// FirstOK is First with a not-found result reported as ok == false.
+	FirstOK(ctx context.Context) (*Todo, bool, error)
 	Count(ctx context.Context) (int, error)
+	// CountBy counts the results per value of a GroupColumn.
+	CountBy(ctx context.Context, col GroupColumn) (map[string]int, error)
+	// Around returns the neighbors of the todo with the given UUID in the
+	// query's order, for next/previous navigation.
+	Around(ctx context.Context, uuid string) (prev, next *Todo, err error)
+	// SQL returns the statement All would run, without executing it.
+	SQL() string
+	// Each streams the results to fn instead of collecting them.
+	Each(ctx context.Context, fn func(Todo) error) error
+	// Page returns one page of results and the cursor for the next one.
+	Page(ctx context.Context, size int) (PageResult[Todo], error)
 }
 
 // ProjectQueryExecutor executes project queries and returns results.
 type ProjectQueryExecutor interface {
 	All(ctx context.Context) ([]Project, error)
 	First(ctx context.Context) (*Project, error)
+	// FirstOK is First with a not-found result reported as ok == false.
+	FirstOK(ctx context.Context) (*Project, bool, error)
 	Count(ctx context.Context) (int, error)
+	// CountBy counts the results per value of a GroupColumn.
+	CountBy(ctx context.Context, col GroupColumn) (map[string]int, error)
+	// SQL returns the statement All would run, without executing it.
+	SQL() string
+	// Each streams the results to fn instead of collecting them.
+	Each(ctx context.Context, fn func(Project) error) error
+	// Page returns one page of results and the cursor for the next one.
+	Page(ctx context.Context, size int) (PageResult[Project], error)
 }
 
 // HeadingQueryExecutor executes heading queries and returns results.
 type HeadingQueryExecutor interface {
 	All(ctx context.Context) ([]Heading, error)
 	First(ctx context.Context) (*Heading, error)
+	// FirstOK is First with a not-found result reported as ok == false.
+	FirstOK(ctx context.Context) (*Heading, bool, error)
 	Count(ctx context.Context) (int, error)
 }
 
@@ -36,13 +61,23 @@ type HeadingQueryExecutor interface {
 type AreaQueryExecutor interface {
 	All(ctx context.Context) ([]Area, error)
 	First(ctx context.Context) (*Area, error)
+	// FirstOK is First with a not-found result reported as ok == false.
+	FirstOK(ctx context.Context) (*Area, bool, error)
 	Count(ctx context.Context) (int, error)
+	// SQL returns the statement All would run, without executing it.
+	SQL() string
 }
 
 // TagQueryExecutor executes tag queries and returns results.
 type TagQueryExecutor interface {
 	All(ctx context.Context) ([]Tag, error)
 	First(ctx context.Context) (*Tag, error)
+	// FirstOK is First with a not-found result reported as ok == false.
+	FirstOK(ctx context.Context) (*Tag, bool, error)
+	Count(ctx context.Context) (int, error)
+	// SQL returns the statement All would run, without executing it.
+	SQL() string
+	Children(ctx context.Context) ([]Tag, error)
 }
 
 // ============================================================================
@@ -74,6 +109,7 @@ type DateFilter[T any] interface {
 	OnOrBefore(date time.Time) T
 	After(date time.Time) T
 	OnOrAfter(date time.Time) T
+	Between(start, end time.Time) T
 }
 
 // ============================================================================
@@ -89,12 +125,16 @@ type TodoQueryBuilder interface {
 	TodoQueryExecutor
 
 	WithUUID(uuid string) TodoQueryBuilder
+	WithUUIDs(uuids ...string) TodoQueryBuilder
 	WithUUIDPrefix(prefix string) TodoQueryBuilder
+	ExcludeUUIDs(uuids ...string) TodoQueryBuilder
 	WithTitle(title string) TodoQueryBuilder
 
 	Status() StatusFilter[TodoQueryBuilder]
 	Start() StartFilter[TodoQueryBuilder]
+	InheritProjectStart(inherit bool) TodoQueryBuilder
 	Trashed(trashed bool) TodoQueryBuilder
+	IncludeContextTrashed(include bool) TodoQueryBuilder
 
 	InArea(uuid string) TodoQueryBuilder
 	HasArea(has bool) TodoQueryBuilder
@@ -104,15 +144,31 @@ type TodoQueryBuilder interface {
 	HasHeading(has bool) TodoQueryBuilder
 	InTag(title string) TodoQueryBuilder
 	HasTag(has bool) TodoQueryBuilder
+	IncludeRecurring(include bool) TodoQueryBuilder
+	InAllTags(titles ...string) TodoQueryBuilder
+	InAnyTags(titles ...string) TodoQueryBuilder
+	NotInTag(title string) TodoQueryBuilder
+	WhereRaw(expr string, args ...any) TodoQueryBuilder
+	Orphaned() TodoQueryBuilder
 
 	StartDate() DateFilter[TodoQueryBuilder]
 	StopDate() DateFilter[TodoQueryBuilder]
 	Deadline() DateFilter[TodoQueryBuilder]
+	ModifiedDate() DateFilter[TodoQueryBuilder]
 	CreatedAfter(t time.Time) TodoQueryBuilder
+	CreatedBefore(t time.Time) TodoQueryBuilder
+	ModifiedAfter(t time.Time) TodoQueryBuilder
 
 	Search(query string) TodoQueryBuilder
+	SearchIn(query string, mode SearchMode, fields ...SearchColumn) TodoQueryBuilder
+	OrderByIndex() TodoQueryBuilder
 	OrderByTodayIndex() TodoQueryBuilder
+	OrderByDeadline(desc bool) TodoQueryBuilder
+	OrderByStartDate(desc bool) TodoQueryBuilder
+	OrderBy(column TaskColumn, dir SortDir) TodoQueryBuilder
 	Limit(n int) TodoQueryBuilder
+	Offset(n int) TodoQueryBuilder
+	AfterCursor(cursor string) TodoQueryBuilder
 
 	IncludeChecklist() TodoQueryBuilder
 }
@@ -122,7 +178,9 @@ type ProjectQueryBuilder interface {
 	ProjectQueryExecutor
 
 	WithUUID(uuid string) ProjectQueryBuilder
+	WithUUIDs(uuids ...string) ProjectQueryBuilder
 	WithUUIDPrefix(prefix string) ProjectQueryBuilder
+	ExcludeUUIDs(uuids ...string) ProjectQueryBuilder
 	WithTitle(title string) ProjectQueryBuilder
 
 	Status() StatusFilter[ProjectQueryBuilder]
@@ -133,14 +191,30 @@ type ProjectQueryBuilder interface {
 	HasArea(has bool) ProjectQueryBuilder
 	InTag(title string) ProjectQueryBuilder
 	HasTag(has bool) ProjectQueryBuilder
+	IncludeRecurring(include bool) ProjectQueryBuilder
+	InAllTags(titles ...string) ProjectQueryBuilder
+	InAnyTags(titles ...string) ProjectQueryBuilder
+	NotInTag(title string) ProjectQueryBuilder
+	WhereRaw(expr string, args ...any) ProjectQueryBuilder
 
 	StartDate() DateFilter[ProjectQueryBuilder]
 	StopDate() DateFilter[ProjectQueryBuilder]
 	Deadline() DateFilter[ProjectQueryBuilder]
+	ModifiedDate() DateFilter[ProjectQueryBuilder]
 	CreatedAfter(t time.Time) ProjectQueryBuilder
+	CreatedBefore(t time.Time) ProjectQueryBuilder
+	ModifiedAfter(t time.Time) ProjectQueryBuilder
 
 	Search(query string) ProjectQueryBuilder
+	SearchIn(query string, mode SearchMode, fields ...SearchColumn) ProjectQueryBuilder
+	OrderByIndex() ProjectQueryBuilder
+	OrderByTodayIndex() ProjectQueryBuilder
+	OrderByDeadline(desc bool) ProjectQueryBuilder
+	OrderByStartDate(desc bool) ProjectQueryBuilder
+	OrderBy(column TaskColumn, dir SortDir) ProjectQueryBuilder
 	Limit(n int) ProjectQueryBuilder
+	Offset(n int) ProjectQueryBuilder
+	AfterCursor(cursor string) ProjectQueryBuilder
 }
 
 // HeadingQueryBuilder provides a fluent interface for building heading queries.
@@ -151,17 +225,27 @@ type HeadingQueryBuilder interface {
 	WithUUIDPrefix(prefix string) HeadingQueryBuilder
 	InProject(uuid string) HeadingQueryBuilder
 	Limit(n int) HeadingQueryBuilder
+	Offset(n int) HeadingQueryBuilder
 }
 
 // AreaQueryBuilder provides a fluent interface for building area queries.
+//
+// Every row in the area table is a user-created area. Inbox, Today, Upcoming,
+// Anytime, Someday, Logbook, and Trash are built-in lists rather than areas,
+// and the schema carries no system-area marker, so there is nothing to
+// exclude: an unfiltered query is already safe for an area picker. Hidden
+// areas are the only ones a picker may want to drop; use Visible(true).
 type AreaQueryBuilder interface {
 	AreaQueryExecutor
 
 	WithUUID(uuid string) AreaQueryBuilder
+	WithUUIDs(uuids ...string) AreaQueryBuilder
 	WithTitle(title string) AreaQueryBuilder
 	Visible(visible bool) AreaQueryBuilder
 	InTag(title string) AreaQueryBuilder
 	HasTag(has bool) AreaQueryBuilder
+	Limit(n int) AreaQueryBuilder
+	Offset(n int) AreaQueryBuilder
 }
 
 // TagQueryBuilder provides a fluent interface for building tag queries.
@@ -171,6 +255,8 @@ type TagQueryBuilder interface {
 	WithUUID(uuid string) TagQueryBuilder
 	WithTitle(title string) TagQueryBuilder
 	WithParent(parentUUID string) TagQueryBuilder
+	Limit(n int) TagQueryBuilder
+	Offset(n int) TagQueryBuilder
 }
 
 // ============================================================================
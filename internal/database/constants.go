@@ -1,5 +1,10 @@
 package database
 
+import (
+	"maps"
+	"slices"
+)
+
 // Database table names.
 const (
 	tableTask          = "TMTask"
@@ -38,7 +43,6 @@ const (
 	filterIsCompleted  = "status = 3"
 
 	// Start bucket filters
-	filterIsInbox   = "start = 0"
 	filterIsAnytime = "start = 1"
 	filterIsSomeday = "start = 2"
 
@@ -65,10 +69,87 @@ const minDatabaseVersion = 21
 // EnvDatabasePath is the environment variable name for custom database path.
 const EnvDatabasePath = "THINGSDB"
 
-// Index column names for ordering.
+// Column names for ordering.
 const (
 	// IndexDefault is the default ordering column.
 	IndexDefault = "index"
 	// IndexToday is the Today view ordering column.
 	IndexToday = "todayIndex"
+	// OrderDeadline orders tasks by their deadline column.
+	OrderDeadline = colDeadline
+	// OrderStartDate orders tasks by their start date column.
+	OrderStartDate = colStartDate
+	// OrderStopDate orders tasks by when they were completed or canceled.
+	OrderStopDate = colStopDate
+	// OrderCreationDate orders tasks by when they were created.
+	OrderCreationDate = colCreationDate
+	// OrderModificationDate orders tasks by when they were last modified.
+	OrderModificationDate = colModificationDate
+	// OrderTitle orders tasks by title, ignoring case.
+	OrderTitle = "title"
+)
+
+// Search column names accepted by WithSearchColumns.
+const (
+	// SearchColumnTitle matches the task title.
+	SearchColumnTitle = "title"
+	// SearchColumnNotes matches the task notes.
+	SearchColumnNotes = "notes"
+	// SearchColumnArea matches the title of the task's area.
+	SearchColumnArea = "area"
+	// SearchColumnProject matches the title of the task's project, including
+	// the project of the heading a task sits under.
+	SearchColumnProject = "project"
+	// SearchColumnHeading matches the title of the task's heading.
+	SearchColumnHeading = "heading"
+	// SearchColumnTag matches the titles of the task's tags.
+	SearchColumnTag = "tag"
+)
+
+// Group column names accepted by CountTasksBy.
+const (
+	// GroupByArea groups tasks by area UUID.
+	GroupByArea = "area"
+	// GroupByProject groups tasks by project UUID, including the project of
+	// the heading a task sits under.
+	GroupByProject = "project"
+	// GroupByStatus groups tasks by status: incomplete, completed or canceled.
+	GroupByStatus = "status"
+	// GroupByType groups tasks by type: to-do, project or heading.
+	GroupByType = "type"
+	// GroupByTag groups tasks by tag title.
+	GroupByTag = "tag"
 )
+
+// groupColumns is the allow-list of CountTasksBy columns.
+var groupColumns = []string{GroupByArea, GroupByProject, GroupByStatus, GroupByType, GroupByTag}
+
+// SearchMode selects how a search query is matched against a column.
+type SearchMode int
+
+// Search modes. LIKE metacharacters in the query match literally in each
+// mode except SearchRawLike.
+const (
+	// SearchContains matches the query anywhere in the column.
+	SearchContains SearchMode = iota
+	// SearchPrefix matches columns starting with the query.
+	SearchPrefix
+	// SearchExact matches columns equal to the query, ignoring ASCII case.
+	SearchExact
+	// SearchRawLike uses the query verbatim as a LIKE pattern, so % and _
+	// act as wildcards. A backslash escapes them.
+	SearchRawLike
+)
+
+// searchColumnSQL maps each search column name to the SQL columns it covers.
+var searchColumnSQL = map[string][]string{
+	SearchColumnTitle:   {"TASK.title"},
+	SearchColumnNotes:   {"TASK.notes"},
+	SearchColumnArea:    {"AREA.title"},
+	SearchColumnProject: {"PROJECT.title", "PROJECT_OF_HEADING.title"},
+	SearchColumnHeading: {"HEADING.title"},
+	SearchColumnTag:     {"TAG.title"},
+}
+
+// searchColumnNames is the allow-list of search column names.
+var searchColumnNames = slices.Sorted(maps.Keys(searchColumnSQL))
@@ -2,6 +2,7 @@ package database
 
 import (
 	"fmt"
+	"strconv"
 	"time"
 )
 
@@ -79,6 +80,19 @@ func stringToThingsDate(isoDate string) (int64, error) {
 	return timeToThingsDate(t), nil
 }
 
+// ValidateISODate checks that isoDate is a yyyy-mm-dd calendar date within the
+// year range the Things date encoding stores. The empty string is rejected.
+func ValidateISODate(isoDate string) error {
+	t, err := time.Parse(time.DateOnly, isoDate)
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", ErrInvalidDate, isoDate, err)
+	}
+	if t.Year() < minDateYear {
+		return fmt.Errorf("%w %q: year out of range", ErrInvalidDate, isoDate)
+	}
+	return nil
+}
+
 // thingsTimeToString converts a Things time integer to time string (HH:MM).
 // Things time format: hhhhhmmmmmm00000000000000000000 (31-bit binary)
 // Zero encodes a valid 00:00 reminder ("no reminder" is NULL in the
@@ -115,6 +129,24 @@ func todayThingsDateSQL() string {
 		"(strftime('%d', date('now', 'localtime')) << 7))"
 }
 
+// todayThingsDateExpr returns today's Things date as of now: a literal, or
+// todayThingsDateSQL when now is nil.
+func todayThingsDateExpr(now *time.Time) string {
+	if now == nil {
+		return todayThingsDateSQL()
+	}
+	return strconv.FormatInt(timeToThingsDate(now.In(time.Local)), 10)
+}
+
+// todayISODateExpr returns today's local yyyy-mm-dd date as of now: a quoted
+// literal, or SQLite's date('now') when now is nil.
+func todayISODateExpr(now *time.Time) string {
+	if now == nil {
+		return "date('now', 'localtime')"
+	}
+	return "'" + now.In(time.Local).Format(time.DateOnly) + "'"
+}
+
 // thingsDateExpressionToISODate creates a SQL expression to convert Things date to ISO format.
 func thingsDateExpressionToISODate(expr string) string {
 	year := fmt.Sprintf("(%s & %d) >> 16", expr, yearMask)
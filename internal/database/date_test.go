@@ -436,6 +436,33 @@ func Test_stringToThingsDate_InvalidInputs(t *testing.T) {
 	}
 }
 
+func TestValidateISODate(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{"valid", "2024-06-15", ""},
+		{"leap day", "2024-02-29", ""},
+		{"empty", "", `things3: invalid date ""`},
+		{"wrong separator", "2024/06/15", `things3: invalid date "2024/06/15"`},
+		{"month 13", "2024-13-01", "month out of range"},
+		{"non-leap feb 29", "2023-02-29", "day out of range"},
+		{"year zero", "0000-06-15", "year out of range"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateISODate(tt.input)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrInvalidDate)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
 // =============================================================================
 // Timezone Consistency Tests
 // =============================================================================
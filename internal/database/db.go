@@ -3,32 +3,40 @@ package database
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"regexp"
 	"sync/atomic"
+	"time"
 
 	_ "github.com/mattn/go-sqlite3" // SQLite driver
 )
 
 // Default database paths for Things 3.
 // Things 3.15.16+ uses a new path pattern with ThingsData-* directory.
+// All candidates live under the app's group container, whose absence means
+// Things has never been installed for this user.
 const (
-	defaultPathPattern31616 = "~/Library/Group Containers/" +
-		"JLMPQHK86H.com.culturedcode.ThingsMac/ThingsData-*/" +
+	groupContainerPath      = "~/Library/Group Containers/JLMPQHK86H.com.culturedcode.ThingsMac"
+	defaultPathPattern31616 = groupContainerPath + "/ThingsData-*/" +
 		"Things Database.thingsdatabase/main.sqlite"
-	defaultPath31516 = "~/Library/Group Containers/" +
-		"JLMPQHK86H.com.culturedcode.ThingsMac/" +
+	defaultPath31516 = groupContainerPath + "/" +
 		"Things Database.thingsdatabase/main.sqlite"
 )
 
 // DB provides low-level access to the Things 3 SQLite database.
 type DB struct {
-	sqlDB      *sql.DB
-	filepath   string
-	printSQL   bool
-	queryCount atomic.Int64
+	sqlDB         *sql.DB
+	filepath      string
+	logger        QueryLogger
+	searchColumns []string
+	skipCorrupt   bool
+	queryTimeout  time.Duration
+	clock         func() time.Time
+	queryCount    atomic.Int64
 }
 
 // Open creates a new Things 3 database connection.
@@ -38,14 +46,24 @@ func Open(opts ...Option) (*DB, error) {
 		opt(options)
 	}
 
+	searchColumns, err := resolveSearchColumns(options.SearchColumns)
+	if err != nil {
+		return nil, err
+	}
+
 	// Discover database path
 	fp, err := discoverDatabasePath(options.DatabasePath)
 	if err != nil {
 		return nil, err
 	}
 
+	// Surface permission problems before SQLite reports a generic open failure
+	if err := checkReadable(fp); err != nil {
+		return nil, err
+	}
+
 	// Open database connection
-	sqlDB, err := openDatabase(fp)
+	sqlDB, err := openDatabase(fp, options)
 	if err != nil {
 		return nil, err
 	}
@@ -56,11 +74,59 @@ func Open(opts ...Option) (*DB, error) {
 		return nil, err
 	}
 
-	return &DB{
-		sqlDB:    sqlDB,
-		filepath: fp,
-		printSQL: options.PrintSQL,
-	}, nil
+	d := &DB{
+		sqlDB:         sqlDB,
+		filepath:      fp,
+		searchColumns: searchColumns,
+		skipCorrupt:   options.SkipCorruptRows,
+		queryTimeout:  options.QueryTimeout,
+		clock:         options.Clock,
+	}
+	d.logger = options.Logger
+	if options.PrintSQL {
+		d.logger = chainLoggers(d.printQuery, options.Logger)
+	}
+	return d, nil
+}
+
+// lookupSearchColumns expands search column names into SQL columns,
+// skipping unknown names; the public SearchIn rejects them before a query
+// is built.
+func lookupSearchColumns(names []string) []string {
+	var columns []string
+	for _, name := range names {
+		columns = append(columns, searchColumnSQL[name]...)
+	}
+	return columns
+}
+
+// resolveSearchColumns expands search column names into SQL columns.
+// An empty list yields nil, selecting the default search columns.
+func resolveSearchColumns(names []string) ([]string, error) {
+	var columns []string
+	for _, name := range names {
+		if err := CheckColumn(ErrInvalidSearchColumn, name, searchColumnNames); err != nil {
+			return nil, err
+		}
+		columns = append(columns, searchColumnSQL[name]...)
+	}
+	return columns, nil
+}
+
+// CheckAccess discovers the database path and verifies that the file can be
+// read, without opening a SQLite connection. Returns ErrThingsNotInstalled,
+// ErrDatabaseNotFound, or ErrFullDiskAccessRequired as appropriate.
+func CheckAccess(opts ...Option) error {
+	options := &Options{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	fp, err := discoverDatabasePath(options.DatabasePath)
+	if err != nil {
+		return err
+	}
+	return checkReadable(fp)
 }
 
 // Close closes the database connection.
@@ -76,36 +142,79 @@ func (d *DB) Filepath() string {
 	return d.filepath
 }
 
+// SQLDB returns the underlying connection pool, opened read-only.
+func (d *DB) SQLDB() *sql.DB {
+	return d.sqlDB
+}
+
 // ExecuteQuery executes a SQL query and returns the results.
 func (d *DB) ExecuteQuery(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
-	if d.printSQL {
-		n := d.queryCount.Add(1)
-		fmt.Printf("/* Query %d */\n", n)
-		if len(args) > 0 {
-			fmt.Printf("/* Parameters: %v */\n", args)
+	start := time.Now()
+	rows, err := d.sqlDB.QueryContext(ctx, query, args...)
+	if d.logger != nil {
+		d.logger(ctx, query, args, time.Since(start), err)
+	}
+	return rows, err
+}
+
+// printQuery is the QueryLogger behind WithPrintSQL: it numbers each query
+// and prints it with its parameters to stdout, and notes skipped rows.
+func (d *DB) printQuery(_ context.Context, query string, args []any, _ time.Duration, err error) {
+	var corrupt *CorruptRowsError
+	if errors.As(err, &corrupt) {
+		fmt.Printf("/* Skipped corrupt row: %v */\n", corrupt.First)
+		return
+	}
+	n := d.queryCount.Add(1)
+	fmt.Printf("/* Query %d */\n", n)
+	if len(args) > 0 {
+		fmt.Printf("/* Parameters: %v */\n", args)
+	}
+	fmt.Println()
+	fmt.Println(query)
+	fmt.Println()
+}
+
+// chainLoggers returns a QueryLogger calling each non-nil logger in order.
+func chainLoggers(loggers ...QueryLogger) QueryLogger {
+	return func(ctx context.Context, query string, args []any, dur time.Duration, err error) {
+		for _, logger := range loggers {
+			if logger != nil {
+				logger(ctx, query, args, dur, err)
+			}
 		}
-		fmt.Println()
-		fmt.Println(query)
-		fmt.Println()
 	}
+}
 
-	return d.sqlDB.QueryContext(ctx, query, args...)
+// queryContext bounds ctx by the query timeout when one is set and ctx has no
+// deadline of its own. Callers defer the returned cancel until they are done
+// with the rows, since the driver aborts a query whose context ends.
+func (d *DB) queryContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if d.queryTimeout <= 0 {
+		return ctx, func() {}
+	}
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, d.queryTimeout)
+}
+
+// logSkippedRow reports a row of query skipped by WithSkipCorruptRows to the
+// QueryLogger, as a *CorruptRowsError for that one row.
+func (d *DB) logSkippedRow(ctx context.Context, query string, err error) {
+	if d.logger != nil {
+		d.logger(ctx, query, nil, 0, &CorruptRowsError{Skipped: 1, First: err})
+	}
 }
 
 // ExecuteQueryRow executes a SQL query that returns a single row.
 func (d *DB) ExecuteQueryRow(ctx context.Context, query string, args ...any) *sql.Row {
-	if d.printSQL {
-		n := d.queryCount.Add(1)
-		fmt.Printf("/* Query %d */\n", n)
-		if len(args) > 0 {
-			fmt.Printf("/* Parameters: %v */\n", args)
-		}
-		fmt.Println()
-		fmt.Println(query)
-		fmt.Println()
+	start := time.Now()
+	row := d.sqlDB.QueryRowContext(ctx, query, args...)
+	if d.logger != nil {
+		d.logger(ctx, query, args, time.Since(start), row.Err())
 	}
-
-	return d.sqlDB.QueryRowContext(ctx, query, args...)
+	return row
 }
 
 // discoverDatabasePath finds the Things database path.
@@ -115,7 +224,7 @@ func discoverDatabasePath(customPath string) (string, error) {
 	if customPath != "" {
 		expanded := expandPath(customPath)
 		if _, err := os.Stat(expanded); err != nil {
-			return "", fmt.Errorf("%w: %s", ErrDatabaseNotFound, expanded)
+			return "", accessError(expanded, err)
 		}
 		return expanded, nil
 	}
@@ -124,7 +233,7 @@ func discoverDatabasePath(customPath string) (string, error) {
 	if envPath := os.Getenv(EnvDatabasePath); envPath != "" {
 		expanded := expandPath(envPath)
 		if _, err := os.Stat(expanded); err != nil { //nolint:gosec // user-provided database path via env var is intentional
-			return "", fmt.Errorf("%w: %s", ErrDatabaseNotFound, expanded)
+			return "", accessError(expanded, err)
 		}
 		return expanded, nil
 	}
@@ -142,9 +251,38 @@ func discoverDatabasePath(customPath string) (string, error) {
 		return oldPath, nil
 	}
 
+	// 5. Distinguish "not installed" from "installed but no database found"
+	container := expandPath(groupContainerPath)
+	if _, err := os.Stat(container); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return "", ErrThingsNotInstalled
+		}
+		return "", accessError(container, err)
+	}
+
 	return "", ErrDatabaseNotFound
 }
 
+// checkReadable opens the database file for reading to detect permission
+// problems that SQLite would otherwise report as "unable to open database file".
+func checkReadable(path string) error {
+	f, err := os.Open(path) //nolint:gosec // path comes from discovery or explicit user configuration
+	if err != nil {
+		return accessError(path, err)
+	}
+	return f.Close()
+}
+
+// accessError maps a filesystem error for path to the package's sentinel
+// errors. Permission failures inside the Things group container are almost
+// always macOS privacy protection, so they map to ErrFullDiskAccessRequired.
+func accessError(path string, err error) error {
+	if errors.Is(err, fs.ErrPermission) {
+		return fmt.Errorf("%w: %s", ErrFullDiskAccessRequired, path)
+	}
+	return fmt.Errorf("%w: %s", ErrDatabaseNotFound, path)
+}
+
 // expandPath expands ~ to the user's home directory.
 func expandPath(path string) string {
 	if path != "" && path[0] == '~' {
@@ -158,13 +296,14 @@ func expandPath(path string) string {
 }
 
 // openDatabase opens a read-only SQLite connection to the Things database.
-func openDatabase(path string) (*sql.DB, error) {
-	// Open in read-only mode with URI
-	uri := fmt.Sprintf("file:%s?mode=ro", path)
-	sqlDB, err := sql.Open("sqlite3", uri)
+func openDatabase(path string, options *Options) (*sql.DB, error) {
+	sqlDB, err := sql.Open("sqlite3", databaseURI(path, options))
 	if err != nil {
 		return nil, fmt.Errorf("open database: %w", err)
 	}
+	if options.MaxOpenConns > 0 {
+		sqlDB.SetMaxOpenConns(options.MaxOpenConns)
+	}
 
 	// Test the connection
 	if err := sqlDB.PingContext(context.Background()); err != nil {
@@ -175,6 +314,19 @@ func openDatabase(path string) (*sql.DB, error) {
 	return sqlDB, nil
 }
 
+// databaseURI builds the SQLite URI for path. The connection is always
+// read-only; options add immutable=1 and the driver's _busy_timeout.
+func databaseURI(path string, options *Options) string {
+	uri := fmt.Sprintf("file:%s?mode=ro", path)
+	if options.Immutable {
+		uri += "&immutable=1"
+	}
+	if options.BusyTimeout > 0 {
+		uri += fmt.Sprintf("&_busy_timeout=%d", options.BusyTimeout.Milliseconds())
+	}
+	return uri
+}
+
 // getDatabaseVersion retrieves the Things database version.
 func getDatabaseVersion(sqlDB *sql.DB) (int, error) {
 	var plistValue string
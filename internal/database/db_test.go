@@ -0,0 +1,169 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withHome points auto-discovery at an empty temporary home directory.
+func withHome(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(EnvDatabasePath, "")
+	return home
+}
+
+func TestDiscoverDatabasePath_NotInstalled(t *testing.T) {
+	withHome(t)
+
+	_, err := discoverDatabasePath("")
+	require.ErrorIs(t, err, ErrThingsNotInstalled)
+	assert.ErrorIs(t, err, ErrDatabaseNotFound, "not installed must still match ErrDatabaseNotFound")
+}
+
+func TestDiscoverDatabasePath_InstalledWithoutDatabase(t *testing.T) {
+	home := withHome(t)
+	container := filepath.Join(home, "Library", "Group Containers", "JLMPQHK86H.com.culturedcode.ThingsMac")
+	require.NoError(t, os.MkdirAll(container, 0o755))
+
+	_, err := discoverDatabasePath("")
+	require.ErrorIs(t, err, ErrDatabaseNotFound)
+	assert.NotErrorIs(t, err, ErrThingsNotInstalled)
+}
+
+func TestDiscoverDatabasePath_CustomPathMissing(t *testing.T) {
+	withHome(t)
+
+	_, err := discoverDatabasePath("/nonexistent/main.sqlite")
+	require.ErrorIs(t, err, ErrDatabaseNotFound)
+	assert.NotErrorIs(t, err, ErrThingsNotInstalled)
+}
+
+func TestCheckAccess(t *testing.T) {
+	withHome(t)
+
+	require.NoError(t, CheckAccess(WithPath(fixtureDatabasePath(t))))
+	require.ErrorIs(t, CheckAccess(WithPath("/nonexistent/main.sqlite")), ErrDatabaseNotFound)
+	require.ErrorIs(t, CheckAccess(), ErrThingsNotInstalled)
+}
+
+// Permission errors cannot be provoked reliably (tests may run as root), so
+// the mapping is exercised directly.
+func TestAccessError(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		wantErr error
+	}{
+		{"permission denied", &fs.PathError{Op: "open", Path: "db", Err: fs.ErrPermission}, ErrFullDiskAccessRequired},
+		{"wrapped permission", fmt.Errorf("stat: %w", fs.ErrPermission), ErrFullDiskAccessRequired},
+		{"not exist", &fs.PathError{Op: "stat", Path: "db", Err: fs.ErrNotExist}, ErrDatabaseNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := accessError("/path/main.sqlite", tt.err)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Contains(t, err.Error(), "/path/main.sqlite")
+		})
+	}
+}
+
+func TestResolveSearchColumns(t *testing.T) {
+	columns, err := resolveSearchColumns(nil)
+	require.NoError(t, err)
+	assert.Nil(t, columns, "no names selects the defaults")
+
+	columns, err = resolveSearchColumns([]string{SearchColumnTitle, SearchColumnProject})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"TASK.title", "PROJECT.title", "PROJECT_OF_HEADING.title"}, columns)
+
+	_, err = resolveSearchColumns([]string{SearchColumnTitle, "TASK.uuid; DROP TABLE TMTask"})
+	require.ErrorIs(t, err, ErrInvalidSearchColumn)
+
+	_, err = Open(WithPath(fixtureDatabasePath(t)), WithSearchColumns("bogus"))
+	require.ErrorIs(t, err, ErrInvalidSearchColumn)
+}
+
+func TestDatabaseURI(t *testing.T) {
+	tests := []struct {
+		name    string
+		options Options
+		want    string
+	}{
+		{"default", Options{}, "file:/db/main.sqlite?mode=ro"},
+		{"immutable", Options{Immutable: true}, "file:/db/main.sqlite?mode=ro&immutable=1"},
+		{"busy timeout", Options{BusyTimeout: 1500 * time.Millisecond}, "file:/db/main.sqlite?mode=ro&_busy_timeout=1500"},
+		{
+			"both",
+			Options{Immutable: true, BusyTimeout: time.Second},
+			"file:/db/main.sqlite?mode=ro&immutable=1&_busy_timeout=1000",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, databaseURI("/db/main.sqlite", &tt.options))
+		})
+	}
+}
+
+// slowQuery never finishes on its own: it counts an unbounded recursive
+// sequence, so only an interrupt ends it.
+const slowQuery = "WITH RECURSIVE seq(n) AS (SELECT 1 UNION ALL SELECT n + 1 FROM seq) SELECT COUNT(*) FROM seq"
+
+func TestQueryTimeout(t *testing.T) {
+	db, err := Open(WithPath(fixtureDatabasePath(t)), WithQueryTimeout(50*time.Millisecond))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	t.Run("bounds a context without deadline", func(t *testing.T) {
+		ctx, cancel := db.queryContext(t.Context())
+		defer cancel()
+		var n int
+		err := db.ExecuteQueryRow(ctx, slowQuery).Scan(&n)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("keeps the caller's deadline", func(t *testing.T) {
+		parent, cancelParent := context.WithTimeout(t.Context(), time.Hour)
+		defer cancelParent()
+		ctx, cancel := db.queryContext(parent)
+		defer cancel()
+		deadline, _ := ctx.Deadline()
+		want, _ := parent.Deadline()
+		assert.Equal(t, want, deadline)
+	})
+
+	t.Run("cancellation still propagates", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+		_, err := db.CountTasks(ctx, &TaskFilter{})
+		require.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("fast queries finish", func(t *testing.T) {
+		n, err := db.CountTasks(t.Context(), &TaskFilter{})
+		require.NoError(t, err)
+		assert.Positive(t, n)
+	})
+}
+
+func TestQueryTimeoutAppliesToCalls(t *testing.T) {
+	db, err := Open(WithPath(fixtureDatabasePath(t)), WithQueryTimeout(time.Nanosecond))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	_, err = db.CountTasks(t.Context(), &TaskFilter{})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	_, err = db.QueryAreas(t.Context(), AreaFilter{})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
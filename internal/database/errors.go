@@ -1,13 +1,87 @@
 package database
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
 
 // Database errors used by the internal db package.
 var (
 	// ErrDatabaseNotFound is returned when the Things database cannot be located.
 	ErrDatabaseNotFound = errors.New("things3: database not found")
+	// ErrThingsNotInstalled is returned by auto-discovery when the Things group
+	// container does not exist. It wraps ErrDatabaseNotFound.
+	ErrThingsNotInstalled = fmt.Errorf("%w: Things 3 is not installed", ErrDatabaseNotFound)
+	// ErrFullDiskAccessRequired is returned when the database exists but macOS
+	// denies reading it, which happens when the calling app lacks Full Disk Access.
+	ErrFullDiskAccessRequired = errors.New("things3: permission denied reading the Things database " +
+		"(grant Full Disk Access in System Settings > Privacy & Security > Full Disk Access, then restart the app)")
 	// ErrDatabaseVersionTooOld is returned when the database version is not supported.
 	ErrDatabaseVersionTooOld = errors.New("things3: database version too old (requires things3 version > 21)")
+	// ErrInvalidColumn is matched by every invalid column error below, so
+	// one check covers search, group, ordering and CSV columns.
+	ErrInvalidColumn = errors.New("things3: invalid column")
+	// ErrInvalidSearchColumn is returned for a search column outside the
+	// SearchColumn constants.
+	ErrInvalidSearchColumn error = columnError("search")
+	// ErrInvalidGroupColumn is returned by CountTasksBy for a column outside
+	// the GroupBy constants.
+	ErrInvalidGroupColumn error = columnError("group")
+	// ErrInvalidTaskColumn is returned for an ordering column outside the
+	// Order constants.
+	ErrInvalidTaskColumn error = columnError("task")
+	// ErrInvalidCSVColumn is returned for a CSV column the exporter does not
+	// know.
+	ErrInvalidCSVColumn error = columnError("CSV")
+	// ErrInvalidDate is returned by ValidateISODate for a string that is not a
+	// storable yyyy-mm-dd calendar date.
+	ErrInvalidDate = errors.New("things3: invalid date")
 	// ErrAuthTokenNotFound is returned when the URL scheme auth token cannot be read.
 	ErrAuthTokenNotFound = errors.New("things3: auth token not found")
+	// ErrCorruptRows is matched by a CorruptRowsError.
+	ErrCorruptRows = errors.New("things3: corrupt rows skipped")
+	// ErrRawArgCount is returned by BindSQL when the number of ? placeholders
+	// does not match the number of arguments.
+	ErrRawArgCount = errors.New("things3: raw SQL placeholder count does not match args")
 )
+
+// columnError is the invalid column error of one column kind.
+type columnError string
+
+func (e columnError) Error() string {
+	return "things3: invalid " + string(e) + " column"
+}
+
+// Is makes every column kind match ErrInvalidColumn.
+func (e columnError) Is(target error) bool {
+	return target == ErrInvalidColumn
+}
+
+// CheckColumn is the one validation path for column names: it returns nil
+// when column is among valid, and otherwise kind, one of the invalid column
+// errors, naming the column.
+func CheckColumn[T ~string](kind error, column T, valid []T) error {
+	if slices.Contains(valid, column) {
+		return nil
+	}
+	return fmt.Errorf("%w: %q", kind, column)
+}
+
+// CorruptRowsError reports rows a query skipped because they could not be
+// scanned, returned alongside the rows that could be. It matches
+// ErrCorruptRows and the first scan error with errors.Is.
+type CorruptRowsError struct {
+	// Skipped is the number of rows left out of the result.
+	Skipped int
+	// First is the scan error of the first skipped row.
+	First error
+}
+
+func (e *CorruptRowsError) Error() string {
+	return fmt.Sprintf("%v: %d (first: %v)", ErrCorruptRows, e.Skipped, e.First)
+}
+
+func (e *CorruptRowsError) Unwrap() []error {
+	return []error{ErrCorruptRows, e.First}
+}
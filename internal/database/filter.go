@@ -2,6 +2,7 @@ package database
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -14,12 +15,15 @@ const (
 )
 
 // DateFilterValue holds a parsed date filter configuration.
-// Only one field should be set at a time.
+// Only one of HasDate, Relative, or Operator/Date should be set at a time.
+// Until may accompany Operator/Date to close the range on the upper end.
 type DateFilterValue struct {
 	HasDate  *bool      // true/false for existence check
 	Relative string     // "future" or "past"
 	Operator string     // "=", "<", "<=", ">", ">="
 	Date     *time.Time // specific date for comparison
+	Until    *time.Time // exclusive upper bound, applied alongside Date
+	Through  *time.Time // inclusive upper bound, applied alongside Date
 }
 
 // escapeString escapes a string for safe use in SQL queries.
@@ -41,6 +45,12 @@ func escapeLikePattern(s string) string {
 	return s
 }
 
+// rawLikeSQL returns "column LIKE 'pattern' ESCAPE '\'" with pattern used
+// as-is, leaving its wildcards active.
+func rawLikeSQL(column, pattern string) string {
+	return fmt.Sprintf("%s LIKE '%s' ESCAPE '%s'", column, escapeString(pattern), likeEscapeChar)
+}
+
 // likeSQL returns "column LIKE 'pattern' ESCAPE '\'" where value is matched
 // literally and prefix/suffix hold the intended wildcards ("%" or "").
 func likeSQL(column, prefix, value, suffix string) string {
@@ -78,6 +88,79 @@ func (w *whereBuilder) addStringEqual(column string, value *string) {
 	}
 }
 
+// addStringIn adds a "column IN (...)" condition (skips nil). An empty
+// non-nil list matches nothing.
+func (w *whereBuilder) addStringIn(column string, values []string) {
+	switch {
+	case values == nil:
+	case len(values) == 0:
+		w.add("FALSE")
+	default:
+		w.addRawf("%s IN (%s)", column, quoteStrings(values))
+	}
+}
+
+// addStringNotIn adds a "column NOT IN (...)" condition (skips empty).
+func (w *whereBuilder) addStringNotIn(column string, values []string) {
+	if len(values) > 0 {
+		w.addRawf("%s NOT IN (%s)", column, quoteStrings(values))
+	}
+}
+
+// quoteStrings renders values as a comma-separated list of SQL literals.
+func quoteStrings(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = "'" + escapeString(v) + "'"
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// addTagsAll adds a condition matching tasks tagged with every title (skips
+// nil). An empty non-nil list matches nothing, as in addTagsAny. The titles
+// are deduplicated so the distinct-tag count can be compared exactly.
+func (w *whereBuilder) addTagsAll(titles []string) {
+	switch {
+	case titles == nil:
+		return
+	case len(titles) == 0:
+		w.add("FALSE")
+		return
+	}
+	titles = slices.Compact(slices.Sorted(slices.Values(titles)))
+	w.addRawf(`(SELECT COUNT(DISTINCT TASK_TAG.tags) FROM %s AS TASK_TAG
+				JOIN %s ALL_TAG ON ALL_TAG.uuid = TASK_TAG.tags
+				WHERE TASK_TAG.tasks = TASK.uuid AND ALL_TAG.title IN (%s)) = %d`,
+		tableTaskTag, tableTag, quoteStrings(titles), len(titles))
+}
+
+// addTagsAny adds a condition matching tasks tagged with at least one title
+// (skips nil). An empty non-nil list matches nothing.
+func (w *whereBuilder) addTagsAny(titles []string) {
+	switch {
+	case titles == nil:
+	case len(titles) == 0:
+		w.add("FALSE")
+	default:
+		w.addRawf(`EXISTS (SELECT 1 FROM %s AS TASK_TAG
+				JOIN %s ANY_TAG ON ANY_TAG.uuid = TASK_TAG.tags
+				WHERE TASK_TAG.tasks = TASK.uuid AND ANY_TAG.title IN (%s))`,
+			tableTaskTag, tableTag, quoteStrings(titles))
+	}
+}
+
+// addTagsNone adds a condition matching tasks tagged with none of the titles
+// (skips empty). Untagged tasks match.
+func (w *whereBuilder) addTagsNone(titles []string) {
+	if len(titles) == 0 {
+		return
+	}
+	w.addRawf(`NOT EXISTS (SELECT 1 FROM %s AS TASK_TAG
+				JOIN %s NONE_TAG ON NONE_TAG.uuid = TASK_TAG.tags
+				WHERE TASK_TAG.tasks = TASK.uuid AND NONE_TAG.title IN (%s))`,
+		tableTaskTag, tableTag, quoteStrings(titles))
+}
+
 // addExists adds "column IS NOT NULL" (true) or "column IS NULL" (false).
 func (w *whereBuilder) addExists(column string, exists bool) {
 	if exists {
@@ -169,35 +252,66 @@ func (w *whereBuilder) addOr(parts ...string) {
 	}
 }
 
-// addSearch adds a full-text search condition across multiple columns.
-// LIKE metacharacters in the query match literally.
-func (w *whereBuilder) addSearch(query string) {
+// defaultSearchColumns are the columns searched when none are configured.
+var defaultSearchColumns = []string{"TASK.title", "TASK.notes", "AREA.title"}
+
+// addSearch adds a search condition matching the query in any of the given
+// columns, falling back to defaultSearchColumns when columns is empty. mode
+// decides where the query may sit in the column; LIKE metacharacters in the
+// query match literally unless mode is SearchRawLike.
+func (w *whereBuilder) addSearch(query string, columns []string, mode SearchMode) {
 	if query == "" {
 		return
 	}
-	columns := []string{"TASK.title", "TASK.notes", "AREA.title"}
+	if len(columns) == 0 {
+		columns = defaultSearchColumns
+	}
+	prefix, suffix := "%", "%"
+	switch mode {
+	case SearchPrefix:
+		prefix = ""
+	case SearchExact:
+		prefix, suffix = "", ""
+	}
 	var searches []string
 	for _, col := range columns {
-		searches = append(searches, likeSQL(col, "%", query, "%"))
+		if mode == SearchRawLike {
+			searches = append(searches, rawLikeSQL(col, query))
+			continue
+		}
+		searches = append(searches, likeSQL(col, prefix, query, suffix))
 	}
 	*w = append(*w, "("+strings.Join(searches, " OR ")+")")
 }
 
-// addCreatedAfter adds a time-based filter for creation date.
-// The instant is normalized to local time so the same instant yields
+// addTimeAfter adds a filter for a Unix timestamp column later than t, at
+// one-second resolution. The instant is normalized to local time so the same instant yields
 // identical SQL regardless of the Location carried by t.
-func (w *whereBuilder) addCreatedAfter(column string, t time.Time) {
+func (w *whereBuilder) addTimeAfter(column string, t time.Time) {
+	w.addTimeCompare(column, ">", t)
+}
+
+// addTimeBefore adds a filter for a Unix timestamp column at or before t, the
+// complement of addTimeAfter.
+func (w *whereBuilder) addTimeBefore(column string, t time.Time) {
+	w.addTimeCompare(column, "<=", t)
+}
+
+// addTimeCompare compares a Unix timestamp column against t with op. A zero
+// t adds no condition.
+func (w *whereBuilder) addTimeCompare(column, op string, t time.Time) {
 	if t.IsZero() {
 		return
 	}
 	local := t.In(time.Local).Format("2006-01-02 15:04:05")
-	w.addRawf("datetime(%s, 'unixepoch', 'localtime') > '%s'", column, local)
+	w.addRawf("datetime(%s, 'unixepoch', 'localtime') %s '%s'", column, op, local)
 }
 
 // addDateFilter adds a date filter condition.
 // isThingsDate indicates whether the column uses Things binary date format (true)
-// or Unix timestamp format (false).
-func (w *whereBuilder) addDateFilter(column string, v *DateFilterValue, isThingsDate bool) {
+// or Unix timestamp format (false). Relative filters compare against now, or
+// SQLite's clock when now is nil.
+func (w *whereBuilder) addDateFilter(column string, v *DateFilterValue, isThingsDate bool, now *time.Time) {
 	if v == nil {
 		return
 	}
@@ -212,10 +326,10 @@ func (w *whereBuilder) addDateFilter(column string, v *DateFilterValue, isThings
 	var colExpr, nowExpr string
 	if isThingsDate {
 		colExpr = column
-		nowExpr = todayThingsDateSQL()
+		nowExpr = todayThingsDateExpr(now)
 	} else {
 		colExpr = fmt.Sprintf("date(%s, 'unixepoch', 'localtime')", column)
-		nowExpr = "date('now', 'localtime')"
+		nowExpr = todayISODateExpr(now)
 	}
 
 	// Relative date (future/past)
@@ -230,14 +344,22 @@ func (w *whereBuilder) addDateFilter(column string, v *DateFilterValue, isThings
 
 	// Specific date comparison. Normalize the instant to local time so the
 	// same instant yields the same calendar date regardless of its Location.
-	if v.Date == nil {
+	w.addDateComparison(colExpr, v.Operator, v.Date, isThingsDate)
+	w.addDateComparison(colExpr, "<", v.Until, isThingsDate)
+	w.addDateComparison(colExpr, "<=", v.Through, isThingsDate)
+}
+
+// addDateComparison adds "colExpr op date" for a non-nil date, skipping
+// dates the column encoding cannot represent.
+func (w *whereBuilder) addDateComparison(colExpr, op string, date *time.Time, isThingsDate bool) {
+	if date == nil {
 		return
 	}
-	dateVal, ok := formatDateValue(clampDate(v.Date.In(time.Local)).Format(time.DateOnly), isThingsDate)
+	dateVal, ok := formatDateValue(clampDate(date.In(time.Local)).Format(time.DateOnly), isThingsDate)
 	if !ok {
 		return
 	}
-	w.addRawf("%s %s %s", colExpr, v.Operator, dateVal)
+	w.addRawf("%s %s %s", colExpr, op, dateVal)
 }
 
 // Year bounds both date encodings can express: the Things encoder parses a
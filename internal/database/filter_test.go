@@ -1,6 +1,7 @@
 package database
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -207,43 +208,61 @@ func TestWhereBuilder_addOr(t *testing.T) {
 
 func TestWhereBuilder_addSearch(t *testing.T) {
 	var w whereBuilder
-	w.addSearch("buy milk")
+	w.addSearch("buy milk", nil, SearchContains)
 	assert.Equal(t,
 		`(TASK.title LIKE '%buy milk%' ESCAPE '\' OR TASK.notes LIKE '%buy milk%' ESCAPE '\' OR AREA.title LIKE '%buy milk%' ESCAPE '\')`,
 		w.sql())
 
 	var w2 whereBuilder
-	w2.addSearch("")
+	w2.addSearch("", nil, SearchContains)
 	assert.Equal(t, sqlTrue, w2.sql())
 }
 
+func TestWhereBuilder_addSearch_customColumns(t *testing.T) {
+	var w whereBuilder
+	w.addSearch("launch", []string{"TASK.title", "PROJECT.title"}, SearchContains)
+	assert.Equal(t,
+		`(TASK.title LIKE '%launch%' ESCAPE '\' OR PROJECT.title LIKE '%launch%' ESCAPE '\')`,
+		w.sql())
+}
+
 func TestWhereBuilder_addSearch_escapesLikeMetacharacters(t *testing.T) {
 	var w whereBuilder
-	w.addSearch("%")
+	w.addSearch("%", nil, SearchContains)
 	assert.Equal(t,
 		`(TASK.title LIKE '%\%%' ESCAPE '\' OR TASK.notes LIKE '%\%%' ESCAPE '\' OR AREA.title LIKE '%\%%' ESCAPE '\')`,
 		w.sql())
 }
 
-func TestWhereBuilder_addCreatedAfter(t *testing.T) {
+func TestWhereBuilder_addTimeAfter(t *testing.T) {
 	var w whereBuilder
-	w.addCreatedAfter("creationDate", time.Date(2024, 6, 15, 10, 30, 0, 0, time.Local))
+	w.addTimeAfter("creationDate", time.Date(2024, 6, 15, 10, 30, 0, 0, time.Local))
 	assert.Equal(t, "datetime(creationDate, 'unixepoch', 'localtime') > '2024-06-15 10:30:00'", w.sql())
 
 	var w2 whereBuilder
-	w2.addCreatedAfter("creationDate", time.Time{})
+	w2.addTimeAfter("creationDate", time.Time{})
+	assert.Equal(t, sqlTrue, w2.sql())
+}
+
+func TestWhereBuilder_addTimeBefore(t *testing.T) {
+	var w whereBuilder
+	w.addTimeBefore("creationDate", time.Date(2024, 6, 15, 10, 30, 0, 0, time.Local))
+	assert.Equal(t, "datetime(creationDate, 'unixepoch', 'localtime') <= '2024-06-15 10:30:00'", w.sql())
+
+	var w2 whereBuilder
+	w2.addTimeBefore("creationDate", time.Time{})
 	assert.Equal(t, sqlTrue, w2.sql())
 }
 
 // The same instant must yield identical SQL regardless of the Location
 // carried by the time.Time value.
-func TestWhereBuilder_addCreatedAfter_locationInsensitive(t *testing.T) {
+func TestWhereBuilder_addTimeAfter_locationInsensitive(t *testing.T) {
 	instant := time.Date(2024, 6, 15, 10, 30, 0, 0, time.FixedZone("EAST", 14*3600))
 
 	var east, west, local whereBuilder
-	east.addCreatedAfter("creationDate", instant)
-	west.addCreatedAfter("creationDate", instant.In(time.FixedZone("WEST", -12*3600)))
-	local.addCreatedAfter("creationDate", instant.In(time.Local))
+	east.addTimeAfter("creationDate", instant)
+	west.addTimeAfter("creationDate", instant.In(time.FixedZone("WEST", -12*3600)))
+	local.addTimeAfter("creationDate", instant.In(time.Local))
 
 	assert.Equal(t, local.sql(), east.sql())
 	assert.Equal(t, local.sql(), west.sql())
@@ -252,64 +271,107 @@ func TestWhereBuilder_addCreatedAfter_locationInsensitive(t *testing.T) {
 func TestWhereBuilder_addDateFilter(t *testing.T) {
 	t.Run("nil value", func(t *testing.T) {
 		var w whereBuilder
-		w.addDateFilter("col", nil, true)
+		w.addDateFilter("col", nil, true, nil)
 		assert.Equal(t, sqlTrue, w.sql())
 	})
 
 	t.Run("exists true", func(t *testing.T) {
 		var w whereBuilder
-		w.addDateFilter("col", &DateFilterValue{HasDate: new(true)}, true)
+		w.addDateFilter("col", &DateFilterValue{HasDate: new(true)}, true, nil)
 		assert.Equal(t, "col IS NOT NULL", w.sql())
 	})
 
 	t.Run("exists false", func(t *testing.T) {
 		var w whereBuilder
-		w.addDateFilter("col", &DateFilterValue{HasDate: new(false)}, true)
+		w.addDateFilter("col", &DateFilterValue{HasDate: new(false)}, true, nil)
 		assert.Equal(t, "col IS NULL", w.sql())
 	})
 
 	t.Run("things date future", func(t *testing.T) {
 		var w whereBuilder
-		w.addDateFilter("startDate", &DateFilterValue{Relative: DateFuture}, true)
+		w.addDateFilter("startDate", &DateFilterValue{Relative: DateFuture}, true, nil)
 		assert.Equal(t, "startDate > "+todayThingsDateSQL(), w.sql())
 	})
 
 	t.Run("things date past", func(t *testing.T) {
 		var w whereBuilder
-		w.addDateFilter("startDate", &DateFilterValue{Relative: DatePast}, true)
+		w.addDateFilter("startDate", &DateFilterValue{Relative: DatePast}, true, nil)
 		assert.Equal(t, "startDate <= "+todayThingsDateSQL(), w.sql())
 	})
 
 	t.Run("unix time future", func(t *testing.T) {
 		var w whereBuilder
-		w.addDateFilter("stopDate", &DateFilterValue{Relative: DateFuture}, false)
+		w.addDateFilter("stopDate", &DateFilterValue{Relative: DateFuture}, false, nil)
 		assert.Equal(t, "date(stopDate, 'unixepoch', 'localtime') > date('now', 'localtime')", w.sql())
 	})
 
 	t.Run("unix time past", func(t *testing.T) {
 		var w whereBuilder
-		w.addDateFilter("stopDate", &DateFilterValue{Relative: DatePast}, false)
+		w.addDateFilter("stopDate", &DateFilterValue{Relative: DatePast}, false, nil)
 		assert.Equal(t, "date(stopDate, 'unixepoch', 'localtime') <= date('now', 'localtime')", w.sql())
 	})
 
+	t.Run("clock binds today as a literal", func(t *testing.T) {
+		now := time.Date(2024, 6, 15, 23, 30, 0, 0, time.Local)
+		var things, unix whereBuilder
+		things.addDateFilter("startDate", &DateFilterValue{Relative: DatePast}, true, &now)
+		unix.addDateFilter("stopDate", &DateFilterValue{Relative: DateFuture}, false, &now)
+		assert.Equal(t, fmt.Sprintf("startDate <= %d", timeToThingsDate(now)), things.sql())
+		assert.Equal(t, "date(stopDate, 'unixepoch', 'localtime') > '2024-06-15'", unix.sql())
+	})
+
 	t.Run("unix time specific date", func(t *testing.T) {
 		var w whereBuilder
 		w.addDateFilter("stopDate", &DateFilterValue{
 			Operator: "=",
 			Date:     new(time.Date(2024, 6, 15, 0, 0, 0, 0, time.Local)),
-		}, false)
+		}, false, nil)
 		assert.Equal(t, "date(stopDate, 'unixepoch', 'localtime') = date('2024-06-15')", w.sql())
 	})
 
+	t.Run("unix time range", func(t *testing.T) {
+		var w whereBuilder
+		w.addDateFilter("stopDate", &DateFilterValue{
+			Operator: ">=",
+			Date:     new(time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)),
+			Until:    new(time.Date(2025, 1, 1, 0, 0, 0, 0, time.Local)),
+		}, false, nil)
+		assert.Equal(t, "date(stopDate, 'unixepoch', 'localtime') >= date('2024-01-01')"+
+			"\n            AND date(stopDate, 'unixepoch', 'localtime') < date('2025-01-01')", w.sql())
+	})
+
+	t.Run("things date inclusive range", func(t *testing.T) {
+		var w whereBuilder
+		w.addDateFilter("deadline", &DateFilterValue{
+			Operator: ">=",
+			Date:     new(time.Date(2025, 1, 1, 0, 0, 0, 0, time.Local)),
+			Through:  new(time.Date(2025, 3, 31, 0, 0, 0, 0, time.Local)),
+		}, true, nil)
+		start, _ := formatDateValue("2025-01-01", true)
+		end, _ := formatDateValue("2025-03-31", true)
+		assert.Equal(t, "deadline >= "+start+"\n            AND deadline <= "+end, w.sql())
+	})
+
+	t.Run("unix time inclusive range", func(t *testing.T) {
+		var w whereBuilder
+		w.addDateFilter("stopDate", &DateFilterValue{
+			Operator: ">=",
+			Date:     new(time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)),
+			Through:  new(time.Date(2024, 12, 31, 0, 0, 0, 0, time.Local)),
+		}, false, nil)
+		assert.Equal(t, "date(stopDate, 'unixepoch', 'localtime') >= date('2024-01-01')"+
+			"\n            AND date(stopDate, 'unixepoch', 'localtime') <= date('2024-12-31')", w.sql())
+	})
+
 	t.Run("specific date is location insensitive", func(t *testing.T) {
 		instant := time.Date(2024, 6, 15, 12, 0, 0, 0, time.FixedZone("EAST", 14*3600))
 		for _, isThingsDate := range []bool{true, false} {
 			var east, west whereBuilder
-			east.addDateFilter("col", &DateFilterValue{Operator: "=", Date: new(instant)}, isThingsDate)
+			east.addDateFilter("col", &DateFilterValue{Operator: "=", Date: new(instant)}, isThingsDate, nil)
 			west.addDateFilter("col", &DateFilterValue{
 				Operator: "=",
 				Date:     new(instant.In(time.FixedZone("WEST", -12*3600))),
-			}, isThingsDate)
+			}, isThingsDate, nil)
 			assert.Equal(t, east.sql(), west.sql(), "isThingsDate=%v", isThingsDate)
 		}
 	})
@@ -328,13 +390,13 @@ func TestWhereBuilder_addDateFilter(t *testing.T) {
 		} {
 			t.Run(tc.name, func(t *testing.T) {
 				var w whereBuilder
-				w.addDateFilter("stopDate", &DateFilterValue{Operator: "<=", Date: new(tc.date)}, false)
+				w.addDateFilter("stopDate", &DateFilterValue{Operator: "<=", Date: new(tc.date)}, false, nil)
 				assert.Equal(t, tc.want, w.sql())
 			})
 
 			t.Run(tc.name+" things date", func(t *testing.T) {
 				var w whereBuilder
-				w.addDateFilter("deadline", &DateFilterValue{Operator: "<=", Date: new(tc.date)}, true)
+				w.addDateFilter("deadline", &DateFilterValue{Operator: "<=", Date: new(tc.date)}, true, nil)
 				assert.NotEqual(t, sqlTrue, w.sql(), "an unencodable date must not drop the condition")
 			})
 		}
@@ -350,3 +412,19 @@ func TestExistsSQL(t *testing.T) {
 	assert.Equal(t, "col IS NOT NULL", existsSQL("col", true))
 	assert.Equal(t, "col IS NULL", existsSQL("col", false))
 }
+
+func TestWhereBuilder_addSearch_modes(t *testing.T) {
+	cols := []string{"TASK.title"}
+
+	var prefix whereBuilder
+	prefix.addSearch("50%", cols, SearchPrefix)
+	assert.Equal(t, `(TASK.title LIKE '50\%%' ESCAPE '\')`, prefix.sql())
+
+	var exact whereBuilder
+	exact.addSearch("a_b", cols, SearchExact)
+	assert.Equal(t, `(TASK.title LIKE 'a\_b' ESCAPE '\')`, exact.sql())
+
+	var raw whereBuilder
+	raw.addSearch("50% o_f", cols, SearchRawLike)
+	assert.Equal(t, `(TASK.title LIKE '50% o_f' ESCAPE '\')`, raw.sql())
+}
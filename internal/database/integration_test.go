@@ -19,6 +19,8 @@ const (
 	fixtureTodoInHeading = "HbKGAeZKFDkWH5osSBNHvz" // deadline 2040-11-04
 	fixtureAreaWithTags  = "DciSFacytdrNG1nRaMJPgY" // tags "Errand" and "Important"
 
+	fixtureTodoWithChecklist = "3Eva4XFof6zWb9iSfYy4ej" // three checklist items
+
 	fixtureTodoInProjectCreationEpoch = int64(1616958920)
 	fixtureIncompleteTodos            = 15
 	fixtureAreas                      = 3
@@ -357,6 +359,22 @@ func TestIntegration_TagsOfTaskSkipsDanglingTagRef(t *testing.T) {
 	assert.Equal(t, []string{"Office"}, tags)
 }
 
+func TestIntegration_TagsOfTasks(t *testing.T) {
+	path := fixtureDatabasePath(t)
+	mutateFixture(t, path,
+		"INSERT INTO TMTaskTag (tasks, tags) VALUES ('"+fixtureTodoInToday+"', 'DanglingTagRef00000003')")
+	d := openDBAt(t, path)
+
+	tags, err := d.TagsOfTasks(t.Context(), []string{fixtureTodoInToday, "NoSuchTask000000000000"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{fixtureTodoInToday: {"Office"}}, tags,
+		"dangling references and untagged tasks leave no titles")
+
+	empty, err := d.TagsOfTasks(t.Context(), nil)
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+}
+
 func TestIntegration_TagsOfAreaSkipsDanglingTagRef(t *testing.T) {
 	path := fixtureDatabasePath(t)
 	mutateFixture(t, path,
@@ -368,3 +386,33 @@ func TestIntegration_TagsOfAreaSkipsDanglingTagRef(t *testing.T) {
 	require.NoError(t, err, "a dangling tag reference must not fail the query")
 	assert.ElementsMatch(t, []string{"Errand", "Important"}, tags)
 }
+
+// =============================================================================
+// Batched Checklist Items
+// =============================================================================
+
+func TestIntegration_QueryChecklistItemsOfTasks(t *testing.T) {
+	path := fixtureDatabasePath(t)
+	mutateFixture(t, path,
+		`INSERT INTO TMChecklistItem (uuid, title, status, "index", task)
+		 VALUES ('BatchChecklistItem0001', 'Second', 0, 2, '`+fixtureTodoInToday+`'),
+		        ('BatchChecklistItem0002', 'First', 3, 1, '`+fixtureTodoInToday+`')`)
+	d := openDBAt(t, path)
+
+	items, err := d.QueryChecklistItemsOfTasks(t.Context(),
+		[]string{fixtureTodoWithChecklist, fixtureTodoInToday, "NoSuchTask000000000000"})
+	require.NoError(t, err)
+
+	assert.Len(t, items, 2, "tasks without checklist items have no entry")
+	assert.Len(t, items[fixtureTodoWithChecklist], 3)
+	today := items[fixtureTodoInToday]
+	require.Len(t, today, 2)
+	assert.Equal(t, "First", today[0].Title, "items keep checklist order within a task")
+	assert.Equal(t, "completed", today[0].Status)
+	assert.Equal(t, "Second", today[1].Title)
+	assert.Equal(t, fixtureTodoInToday, today[1].TaskUUID)
+
+	empty, err := d.QueryChecklistItemsOfTasks(t.Context(), nil)
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+}
@@ -29,6 +29,9 @@ type TaskRow struct {
 	TodayIndex   int
 	Evening      bool
 	Repeating    bool
+
+	DeadlineSuppressed bool            // overdue deadline dismissed from Today
+	RecurrenceRule     *RecurrenceRule // own or template's rule; nil if none or undecodable
 }
 
 // AreaRow represents a row from an area query result.
@@ -36,18 +39,22 @@ type AreaRow struct {
 	UUID    string
 	Title   string
 	HasTags bool
+	// Visible is false only for an area the user hid; NULL counts as visible.
+	Visible bool
 }
 
 // TagRow represents a row from a tag query result.
 type TagRow struct {
-	UUID     string
-	Title    string
-	Shortcut string
+	UUID       string
+	Title      string
+	Shortcut   string
+	ParentUUID string
 }
 
 // ChecklistItemRow represents a row from a checklist item query result.
 type ChecklistItemRow struct {
 	UUID     string
+	TaskUUID string
 	Title    string
 	Status   string // "incomplete", "completed", "canceled"
 	StopDate *time.Time
@@ -1,11 +1,43 @@
 package database
 
+import (
+	"context"
+	"time"
+)
+
 // Options holds the configuration options for the DB.
 type Options struct {
-	DatabasePath string
-	PrintSQL     bool
+	DatabasePath  string
+	PrintSQL      bool
+	SearchColumns []string
+	// SkipCorruptRows makes task queries skip rows that fail to scan
+	// instead of failing; see CorruptRowsError.
+	SkipCorruptRows bool
+	// Immutable opens the file with immutable=1, so SQLite takes no locks
+	// and ignores the write-ahead log.
+	Immutable bool
+	// MaxOpenConns caps the connection pool; 0 leaves it unlimited.
+	MaxOpenConns int
+	// BusyTimeout is how long a query waits on a lock; 0 keeps the
+	// driver's default.
+	BusyTimeout time.Duration
+	// QueryTimeout bounds each call that runs queries when the caller's
+	// context has no deadline; 0 sets no bound.
+	QueryTimeout time.Duration
+	// Logger is called after each query with its SQL, bound args, run time
+	// and error.
+	Logger QueryLogger
+	// Clock supplies the current time for relative date filters and the
+	// Today view; nil leaves "now" to SQLite.
+	Clock func() time.Time
 }
 
+// QueryLogger receives each executed query. dur covers running the statement
+// up to its first row, not scanning the rest. Under WithSkipCorruptRows it is
+// also called once per skipped row, with the query, zero dur and a
+// *CorruptRowsError for that row as err.
+type QueryLogger func(ctx context.Context, query string, args []any, dur time.Duration, err error)
+
 // Option is a functional option for configuring the DB.
 type Option func(*Options)
 
@@ -22,3 +54,63 @@ func WithPrintSQL(enabled bool) Option {
 		opts.PrintSQL = enabled
 	}
 }
+
+// WithSkipCorruptRows makes task queries skip rows that cannot be scanned,
+// returning the others with a *CorruptRowsError.
+func WithSkipCorruptRows(enabled bool) Option {
+	return func(opts *Options) {
+		opts.SkipCorruptRows = enabled
+	}
+}
+
+// WithSearchColumns sets the columns matched by task search, by name
+// (see SearchColumnTitle and friends). Open rejects unknown names.
+func WithSearchColumns(columns ...string) Option {
+	return func(opts *Options) {
+		opts.SearchColumns = columns
+	}
+}
+
+// WithImmutable opens the database with immutable=1.
+func WithImmutable(enabled bool) Option {
+	return func(opts *Options) {
+		opts.Immutable = enabled
+	}
+}
+
+// WithMaxOpenConns caps the number of open connections.
+func WithMaxOpenConns(n int) Option {
+	return func(opts *Options) {
+		opts.MaxOpenConns = n
+	}
+}
+
+// WithBusyTimeout sets how long a query waits for a lock held by a writer.
+func WithBusyTimeout(d time.Duration) Option {
+	return func(opts *Options) {
+		opts.BusyTimeout = d
+	}
+}
+
+// WithQueryTimeout bounds calls made with a context that has no deadline.
+func WithQueryTimeout(d time.Duration) Option {
+	return func(opts *Options) {
+		opts.QueryTimeout = d
+	}
+}
+
+// WithLogger sets a QueryLogger called after every query. It composes with
+// WithPrintSQL, which is itself a logger.
+func WithLogger(logger QueryLogger) Option {
+	return func(opts *Options) {
+		opts.Logger = logger
+	}
+}
+
+// WithClock drives relative date filters from clock instead of SQLite's
+// date('now'), binding the resulting date into the SQL as a literal.
+func WithClock(clock func() time.Time) Option {
+	return func(opts *Options) {
+		opts.Clock = clock
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 )
 
@@ -12,6 +14,8 @@ import (
 type TaskFilter struct {
 	UUID               *string
 	UUIDPrefix         *string
+	UUIDs              []string
+	ExcludeUUIDs       []string
 	Title              *string
 	TaskType           *int
 	Status             *int
@@ -24,16 +28,62 @@ type TaskFilter struct {
 	HasHeading         *bool
 	TagTitle           *string
 	HasTags            *bool
+	AllTags            []string
+	AnyTags            []string
+	ExcludeTags        []string
+	Raw                []string
 	DeadlineSuppressed *bool
 	Trashed            *bool
 	RepeatingTemplates *bool
+	IncludeRecurring   bool
 	CreatedAfter       *time.Time
+	CreatedBefore      *time.Time
+	ModifiedAfter      *time.Time
 	SearchQuery        *string
+	SearchMode         SearchMode
+	// SearchFields names the search columns (see SearchColumnTitle and
+	// friends) for this query, overriding WithSearchColumns; nil keeps it.
+	// Unknown names match nothing.
+	SearchFields       []string
 	Index              string
+	OrderBy            []TaskOrder
 	StartDateFilter    *DateFilterValue
 	StopDateFilter     *DateFilterValue
 	DeadlineFilter     *DateFilterValue
+	ModifiedDateFilter *DateFilterValue
 	Limit              *int
+	Offset             *int
+	// Keyset breaks ties in the index ordering by task UUID, giving the rows
+	// a total order that After can resume from.
+	Keyset bool
+	// After keeps the tasks ordered after the key under the Keyset ordering.
+	// It only applies to the index ordering: OrderBy and TodayView must be
+	// unset and Column must match Index. A Column outside the index columns
+	// matches nothing.
+	After *TaskKey
+
+	// TodayView selects the Things Today view in one query: tasks scheduled
+	// into Today, Someday tasks whose start date has arrived, and tasks with
+	// an undismissed overdue deadline and no start date. It also replaces the
+	// ordering with the view's display order; see todayViewOrder.
+	TodayView bool
+	// InheritProjectStart makes the Start filter and the returned start
+	// bucket use the effective bucket: Someday when the task or its project
+	// (direct or through a heading) is in Someday, the task's own otherwise.
+	InheritProjectStart bool
+	// IncludeContextTrashed keeps untrashed tasks whose project or heading
+	// is trashed, which are excluded by default.
+	IncludeContextTrashed bool
+	// ChecklistComplete selects tasks that have a checklist with no open
+	// items left, counting canceled items as done.
+	ChecklistComplete bool
+
+	// searchColumns overrides the columns Search matches; set by the DB from
+	// its WithSearchColumns configuration.
+	searchColumns []string
+	// now is the time relative date filters compare against; set by the DB
+	// from its WithClock configuration. nil uses SQLite's clock.
+	now *time.Time
 }
 
 // wantsTemplates reports whether the query targets repeating templates rather
@@ -47,35 +97,43 @@ func (f *TaskFilter) buildWhere() string {
 	var w whereBuilder
 
 	// Recurring templates are excluded by default; a template query inverts the
-	// filter to select only them.
-	if f.wantsTemplates() {
+	// filter to select only them, and IncludeRecurring drops it.
+	switch {
+	case f.wantsTemplates():
 		w.add("TASK." + filterIsRecurring)
-	} else {
+	case !f.IncludeRecurring:
 		w.add("TASK." + filterIsNotRecurring)
 	}
 
 	// Trashed filter (default: not trashed)
 	// When viewing trash, only check the task's own trashed flag.
-	// Otherwise, also exclude tasks whose parent project is trashed.
+	// Otherwise, also exclude tasks whose project, heading, or heading's
+	// project is trashed, as the app hides them with their container, unless
+	// the query opts back in with IncludeContextTrashed.
 	if f.Trashed != nil && *f.Trashed {
 		w.add("TASK." + filterIsTrashed)
 	} else {
 		w.add("TASK." + filterIsNotTrashed)
-		notTrashed := false
-		w.addTruthy("PROJECT.trashed", &notTrashed, 0)
-		w.addTruthy("PROJECT_OF_HEADING.trashed", &notTrashed, 0)
+		if !f.IncludeContextTrashed {
+			notTrashed := false
+			w.addTruthy("PROJECT.trashed", &notTrashed, 0)
+			w.addTruthy("HEADING.trashed", &notTrashed, 0)
+			w.addTruthy("PROJECT_OF_HEADING.trashed", &notTrashed, 0)
+		}
 	}
 
 	// Integer field filters
 	w.addIntEqual("TASK.type", f.TaskType)
 	w.addIntEqual("TASK.status", f.Status)
-	w.addIntEqual("TASK.start", f.Start)
+	w.addIntEqual(startExpr(f.InheritProjectStart), f.Start)
 
 	// Identity filters
 	w.addStringEqual("TASK.uuid", f.UUID)
 	if f.UUIDPrefix != nil {
 		w.addLikePrefix("TASK.uuid", *f.UUIDPrefix)
 	}
+	w.addStringIn("TASK.uuid", f.UUIDs)
+	w.addStringNotIn("TASK.uuid", f.ExcludeUUIDs)
 	if f.Title != nil {
 		w.addLikeContains("TASK.title", *f.Title)
 	}
@@ -85,6 +143,9 @@ func (f *TaskFilter) buildWhere() string {
 	w.addOrFilter("TASK.project", "PROJECT_OF_HEADING.uuid", f.ProjectUUID, f.HasProject)
 	w.addFilter("TASK.heading", f.HeadingUUID, f.HasHeading)
 	w.addFilter("TAG.title", f.TagTitle, f.HasTags)
+	w.addTagsAll(f.AllTags)
+	w.addTagsAny(f.AnyTags)
+	w.addTagsNone(f.ExcludeTags)
 
 	// Deadline suppressed
 	if f.DeadlineSuppressed != nil {
@@ -97,28 +158,143 @@ func (f *TaskFilter) buildWhere() string {
 	if f.wantsTemplates() {
 		startDateColumn = colNextInstanceStartDate
 	}
-	w.addDateFilter("TASK."+startDateColumn, f.StartDateFilter, true)
-	w.addDateFilter("TASK."+colStopDate, f.StopDateFilter, false)
-	w.addDateFilter("TASK."+colDeadline, f.DeadlineFilter, true)
+	w.addDateFilter("TASK."+startDateColumn, f.StartDateFilter, true, f.now)
+	w.addDateFilter("TASK."+colStopDate, f.StopDateFilter, false, f.now)
+	w.addDateFilter("TASK."+colDeadline, f.DeadlineFilter, true, f.now)
+	w.addDateFilter("TASK."+colModificationDate, f.ModifiedDateFilter, false, f.now)
 
 	// Time-based filters
 	if f.CreatedAfter != nil {
-		w.addCreatedAfter("TASK."+colCreationDate, *f.CreatedAfter)
+		w.addTimeAfter("TASK."+colCreationDate, *f.CreatedAfter)
+	}
+	if f.CreatedBefore != nil {
+		w.addTimeBefore("TASK."+colCreationDate, *f.CreatedBefore)
+	}
+	if f.ModifiedAfter != nil {
+		w.addTimeAfter("TASK."+colModificationDate, *f.ModifiedAfter)
 	}
 	if f.SearchQuery != nil {
-		w.addSearch(*f.SearchQuery)
+		columns := f.searchColumns
+		if f.SearchFields != nil {
+			columns = lookupSearchColumns(f.SearchFields)
+			if len(columns) == 0 {
+				w.add("FALSE")
+			}
+		}
+		w.addSearch(*f.SearchQuery, columns, f.SearchMode)
+	}
+	if f.TodayView {
+		w.add(todayViewPredicate(f.now))
+	}
+	if f.ChecklistComplete {
+		w.addRawf("TASK.uuid IN (SELECT task FROM %s GROUP BY task HAVING SUM(%s) = 0)",
+			tableChecklistItem, filterIsIncomplete)
+	}
+	for _, expr := range f.Raw {
+		w.add("(" + expr + ")")
+	}
+	switch {
+	case f.After == nil:
+	case f.After.Column != IndexDefault && f.After.Column != IndexToday:
+		w.add("FALSE")
+	default:
+		w.addRawf("(TASK.%q, TASK.uuid) > (%d, '%s')",
+			f.After.Column, f.After.Index, escapeString(f.After.UUID))
 	}
 
 	return w.sql()
 }
 
-// buildOrder builds the ORDER BY clause.
+// TaskKey is a task's position in the Keyset ordering: its value in an index
+// column plus its UUID.
+type TaskKey struct {
+	Column string // IndexDefault or IndexToday
+	Index  int
+	UUID   string
+}
+
+// startExpr returns the SQL expression for a task's start bucket. Buckets
+// order Inbox < Anytime < Someday, and a project is never in the Inbox, so the
+// larger of the task's and its project's bucket is the effective one.
+func startExpr(inheritProjectStart bool) string {
+	if !inheritProjectStart {
+		return "TASK.start"
+	}
+	return "MAX(TASK.start, COALESCE(PROJECT.start, PROJECT_OF_HEADING.start, 0))"
+}
+
+// todayViewPredicate returns the condition selecting the three Today groups
+// as of now (SQLite's clock when nil). The groups are disjoint, so each row
+// falls into exactly one of them.
+func todayViewPredicate(now *time.Time) string {
+	today := todayThingsDateExpr(now)
+	return fmt.Sprintf("((TASK.%[1]s IS NOT NULL AND TASK.%[2]s)"+
+		" OR (TASK.%[1]s IS NOT NULL AND TASK.%[3]s AND TASK.%[1]s <= %[4]s)"+
+		" OR (TASK.%[1]s IS NULL AND TASK.deadlineSuppressionDate IS NULL AND TASK.%[5]s <= %[4]s))",
+		colStartDate, filterIsAnytime, filterIsSomeday, today, colDeadline)
+}
+
+// todayViewOrder returns the Today display order: the scheduled group with
+// This Evening rows last, then arrived Someday rows, each by todayIndex, then
+// overdue rows by their list index.
+func todayViewOrder() string {
+	return fmt.Sprintf(`CASE
+				WHEN TASK.%[1]s IS NULL THEN 3
+				WHEN TASK.%[2]s THEN 2
+				WHEN TASK.startBucket = %[3]d THEN 1
+				ELSE 0
+			END,
+			CASE WHEN TASK.%[1]s IS NULL THEN TASK.%[4]q ELSE TASK.%[5]q END`,
+		colStartDate, filterIsSomeday, startBucketEvening, IndexDefault, IndexToday)
+}
+
+// TaskOrder orders tasks by a column ahead of the index ordering.
+type TaskOrder struct {
+	Column string // one of the Order* constants or IndexDefault
+	Desc   bool
+}
+
+// buildOrder builds the ORDER BY clause from OrderBy, in sequence. Columns
+// outside the Order* set are skipped, so no caller-supplied name reaches the
+// SQL. A nullable date ordering sorts rows without that date last in either
+// direction. The index column always comes last to keep ties in display
+// order.
 func (f *TaskFilter) buildOrder() string {
+	if f.TodayView {
+		return todayViewOrder()
+	}
 	index := f.Index
 	if index == "" {
 		index = IndexDefault
 	}
-	return fmt.Sprintf("TASK.%q", index)
+	indexOrder := fmt.Sprintf("TASK.%q", index)
+
+	terms := make([]string, 0, 2*len(f.OrderBy)+1)
+	for _, order := range f.OrderBy {
+		direction := "ASC"
+		if order.Desc {
+			direction = "DESC"
+		}
+		switch order.Column {
+		case OrderStartDate, OrderDeadline, OrderStopDate:
+			column := "TASK." + order.Column
+			if order.Column == OrderStartDate && f.wantsTemplates() {
+				column = "TASK." + colNextInstanceStartDate
+			}
+			terms = append(terms, column+" IS NULL", column+" "+direction)
+		case OrderCreationDate, OrderModificationDate:
+			terms = append(terms, "TASK."+order.Column+" "+direction)
+		case OrderTitle:
+			terms = append(terms, "TASK.title COLLATE NOCASE "+direction)
+		case IndexDefault:
+			terms = append(terms, fmt.Sprintf("TASK.%q %s", IndexDefault, direction))
+		}
+	}
+	terms = append(terms, indexOrder)
+	if f.Keyset || f.After != nil {
+		terms = append(terms, "TASK.uuid")
+	}
+	return strings.Join(terms, ", ")
 }
 
 // AreaFilter captures all parameters for an area query.
@@ -128,6 +304,11 @@ type AreaFilter struct {
 	Visible  *bool
 	TagTitle *string
 	HasTag   *bool
+	// UUIDs selects the areas with any of the UUIDs. A non-nil empty slice
+	// matches nothing.
+	UUIDs  []string
+	Limit  *int
+	Offset *int
 }
 
 // buildWhere builds the WHERE clause for an area query.
@@ -135,6 +316,7 @@ func (f *AreaFilter) buildWhere() string {
 	var w whereBuilder
 
 	w.addStringEqual("AREA.uuid", f.UUID)
+	w.addStringIn("AREA.uuid", f.UUIDs)
 	w.addStringEqual("AREA.title", f.Title)
 	// NULL visible means the user never hid the area, so NULL defaults to 1.
 	w.addTruthy("AREA.visible", f.Visible, 1)
@@ -148,6 +330,16 @@ type TagFilter struct {
 	UUID       *string
 	Title      *string
 	ParentUUID *string
+	// ParentUUIDs selects the children of any of the tags; an empty,
+	// non-nil list matches nothing.
+	ParentUUIDs []string
+	// UsedInArea selects tags applied to an untrashed task in the area,
+	// directly or through the task's project or its heading's project.
+	UsedInArea *string
+	// Unused selects tags with no row in TMTaskTag or TMAreaTag.
+	Unused bool
+	Limit  *int
+	Offset *int
 }
 
 // buildWhere builds the WHERE clause for a tag query.
@@ -157,39 +349,105 @@ func (f *TagFilter) buildWhere() string {
 	w.addStringEqual("uuid", f.UUID)
 	w.addStringEqual("title", f.Title)
 	w.addStringEqual("parent", f.ParentUUID)
+	w.addStringIn("parent", f.ParentUUIDs)
+	if f.UsedInArea != nil {
+		w.add(buildTagsUsedInAreaSQL(*f.UsedInArea))
+	}
+	if f.Unused {
+		w.add(buildTagsUnusedSQL())
+	}
 
 	return w.sql()
 }
 
-// QueryTasks executes a task query and returns matching rows.
+// configure returns a copy of f carrying the DB-level query settings, so
+// the caller's filter is never mutated.
+func (d *DB) configure(f *TaskFilter) *TaskFilter {
+	c := *f
+	c.searchColumns = d.searchColumns
+	if d.clock != nil {
+		c.now = new(d.clock())
+	}
+	return &c
+}
+
+// QueryTasks executes a task query and returns matching rows. With
+// WithSkipCorruptRows, rows that fail to scan are left out and the rest are
+// returned together with a *CorruptRowsError.
 func (d *DB) QueryTasks(ctx context.Context, f *TaskFilter) ([]TaskRow, error) {
-	where := f.buildWhere()
-	order := f.buildOrder()
-	query := buildTasksSQL(where, order, f.Limit, f.wantsTemplates())
+	var tasks []TaskRow
+	err := d.EachTask(ctx, f, func(task *TaskRow) error {
+		tasks = append(tasks, *task)
+		return nil
+	})
+	var corrupt *CorruptRowsError
+	if err != nil && !errors.As(err, &corrupt) {
+		return nil, err
+	}
+	return tasks, err
+}
 
+// EachTask executes a task query and calls fn with each matching row as it
+// is scanned, without collecting the result. It stops at the first error fn
+// returns and passes that error back unchanged. Rows skipped under
+// WithSkipCorruptRows are reported by a *CorruptRowsError once the rows run
+// out. The result set holds a connection while fn runs, so fn must not query
+// the database when the pool has a single connection.
+func (d *DB) EachTask(ctx context.Context, f *TaskFilter, fn func(*TaskRow) error) error {
+	ctx, cancel := d.queryContext(ctx)
+	defer cancel()
+
+	query := d.TasksSQL(f)
 	rows, err := d.ExecuteQuery(ctx, query)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer rows.Close()
 
-	var tasks []TaskRow
+	var corrupt *CorruptRowsError
 	for rows.Next() {
 		task, err := scanTaskRow(rows)
 		if err != nil {
-			return nil, err
+			if !d.skipCorrupt {
+				return err
+			}
+			d.logSkippedRow(ctx, query, err)
+			if corrupt == nil {
+				corrupt = &CorruptRowsError{First: err}
+			}
+			corrupt.Skipped++
+			continue
 		}
-		tasks = append(tasks, *task)
+		if err := fn(task); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if corrupt != nil {
+		return corrupt
 	}
+	return nil
+}
 
-	return tasks, rows.Err()
+// TasksSQL returns the statement QueryTasks runs for the filter, without
+// executing it. Filter values are escaped into the SQL text rather than
+// bound, so the statement needs no arguments.
+func (d *DB) TasksSQL(f *TaskFilter) string {
+	f = d.configure(f)
+	return buildTasksSQL(f.buildWhere(), f.buildOrder(), f.Limit, f.Offset, f.wantsTemplates(), startExpr(f.InheritProjectStart))
 }
 
 // CountTasks returns the count of tasks matching the filter.
 func (d *DB) CountTasks(ctx context.Context, f *TaskFilter) (int, error) {
+	ctx, cancel := d.queryContext(ctx)
+	defer cancel()
+
+	f = d.configure(f)
 	where := f.buildWhere()
 	order := f.buildOrder()
-	taskSQL := buildTasksSQL(where, order, nil, f.wantsTemplates())
+	taskSQL := buildTasksSQL(where, order, nil, nil, f.wantsTemplates(), startExpr(f.InheritProjectStart))
 	countSQL := buildCountSQL(taskSQL)
 
 	var count int
@@ -200,10 +458,46 @@ func (d *DB) CountTasks(ctx context.Context, f *TaskFilter) (int, error) {
 	return count, nil
 }
 
+// CountTasksBy returns the count of tasks matching the filter for each value
+// of a GroupBy column, attributing a todo under a heading to the heading's
+// project. Values with no matching task are absent from the map, and tasks
+// without a value (no area, no tag, ...) are not counted. It returns
+// ErrInvalidGroupColumn for an unknown column.
+func (d *DB) CountTasksBy(ctx context.Context, f *TaskFilter, column string) (map[string]int, error) {
+	ctx, cancel := d.queryContext(ctx)
+	defer cancel()
+
+	f = d.configure(f)
+	taskSQL := buildTasksSQL(f.buildWhere(), f.buildOrder(), nil, nil, f.wantsTemplates(), startExpr(f.InheritProjectStart))
+	countSQL, err := buildCountBySQL(taskSQL, column)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := d.ExecuteQuery(ctx, countSQL)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var key string
+		var count int
+		if err := rows.Scan(&key, &count); err != nil {
+			return nil, err
+		}
+		counts[key] = count
+	}
+	return counts, rows.Err()
+}
+
 // QueryAreas executes an area query and returns matching rows.
 func (d *DB) QueryAreas(ctx context.Context, f AreaFilter) ([]AreaRow, error) {
-	query := buildAreasSQL(f.buildWhere())
-	rows, err := d.ExecuteQuery(ctx, query)
+	ctx, cancel := d.queryContext(ctx)
+	defer cancel()
+
+	rows, err := d.ExecuteQuery(ctx, d.AreasSQL(f))
 	if err != nil {
 		return nil, err
 	}
@@ -221,8 +515,17 @@ func (d *DB) QueryAreas(ctx context.Context, f AreaFilter) ([]AreaRow, error) {
 	return areas, rows.Err()
 }
 
+// AreasSQL returns the statement QueryAreas runs for the filter, without
+// executing it. Like TasksSQL, values are inlined.
+func (d *DB) AreasSQL(f AreaFilter) string {
+	return buildAreasSQL(f.buildWhere()) + pageSQL(f.Limit, f.Offset)
+}
+
 // CountAreas returns the count of areas matching the filter.
 func (d *DB) CountAreas(ctx context.Context, f AreaFilter) (int, error) {
+	ctx, cancel := d.queryContext(ctx)
+	defer cancel()
+
 	areaSQL := buildAreasSQL(f.buildWhere())
 	countSQL := buildCountSQL(areaSQL)
 
@@ -236,8 +539,10 @@ func (d *DB) CountAreas(ctx context.Context, f AreaFilter) (int, error) {
 
 // QueryTags executes a tag query and returns matching rows.
 func (d *DB) QueryTags(ctx context.Context, f TagFilter) ([]TagRow, error) {
-	query := buildTagsSQL(f.buildWhere())
-	rows, err := d.ExecuteQuery(ctx, query)
+	ctx, cancel := d.queryContext(ctx)
+	defer cancel()
+
+	rows, err := d.ExecuteQuery(ctx, d.TagsSQL(f))
 	if err != nil {
 		return nil, err
 	}
@@ -255,8 +560,32 @@ func (d *DB) QueryTags(ctx context.Context, f TagFilter) ([]TagRow, error) {
 	return tags, rows.Err()
 }
 
+// TagsSQL returns the statement QueryTags runs for the filter, without
+// executing it. Like TasksSQL, values are inlined.
+func (d *DB) TagsSQL(f TagFilter) string {
+	return buildTagsSQL(f.buildWhere()) + pageSQL(f.Limit, f.Offset)
+}
+
+// CountTags returns the count of tags matching the filter.
+func (d *DB) CountTags(ctx context.Context, f TagFilter) (int, error) {
+	ctx, cancel := d.queryContext(ctx)
+	defer cancel()
+
+	countSQL := buildCountSQL(buildTagsSQL(f.buildWhere()))
+
+	var count int
+	if err := d.ExecuteQueryRow(ctx, countSQL).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
 // TagsOfTask returns the tag titles for a task.
 func (d *DB) TagsOfTask(ctx context.Context, taskUUID string) ([]string, error) {
+	ctx, cancel := d.queryContext(ctx)
+	defer cancel()
+
 	query := buildTagsOfTaskSQL()
 	rows, err := d.ExecuteQuery(ctx, query, taskUUID)
 	if err != nil {
@@ -267,8 +596,55 @@ func (d *DB) TagsOfTask(ctx context.Context, taskUUID string) ([]string, error)
 	return collectTagTitles(rows)
 }
 
+// TagsOfTasks returns the tag titles of several tasks, keyed by task UUID,
+// querying in chunks like QueryChecklistItemsOfTasks. Tasks without tags
+// have no entry.
+func (d *DB) TagsOfTasks(ctx context.Context, taskUUIDs []string) (map[string][]string, error) {
+	tags := make(map[string][]string)
+	for chunk := range slices.Chunk(taskUUIDs, maxBatchQueryTasks) {
+		args := make([]any, len(chunk))
+		for i, uuid := range chunk {
+			args[i] = uuid
+		}
+		if err := d.collectTaskTags(ctx, tags, buildTagsOfTasksSQL(len(chunk)), args); err != nil {
+			return nil, err
+		}
+	}
+	return tags, nil
+}
+
+// collectTaskTags runs one batched tag query and appends its titles to tags,
+// skipping dangling tag references as collectTagTitles does.
+func (d *DB) collectTaskTags(ctx context.Context, tags map[string][]string, query string, args []any) error {
+	ctx, cancel := d.queryContext(ctx)
+	defer cancel()
+
+	rows, err := d.ExecuteQuery(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var task string
+		var title sql.NullString
+		if err := rows.Scan(&task, &title); err != nil {
+			return err
+		}
+		if !title.Valid {
+			continue
+		}
+		tags[task] = append(tags[task], title.String)
+	}
+
+	return rows.Err()
+}
+
 // TagsOfArea returns the tag titles for an area.
 func (d *DB) TagsOfArea(ctx context.Context, areaUUID string) ([]string, error) {
+	ctx, cancel := d.queryContext(ctx)
+	defer cancel()
+
 	query := buildTagsOfAreaSQL()
 	rows, err := d.ExecuteQuery(ctx, query, areaUUID)
 	if err != nil {
@@ -299,27 +675,97 @@ func collectTagTitles(rows *sql.Rows) ([]string, error) {
 
 // QueryChecklistItems returns checklist items for a task.
 func (d *DB) QueryChecklistItems(ctx context.Context, taskUUID string) ([]ChecklistItemRow, error) {
-	query := buildChecklistItemsSQL()
-	rows, err := d.ExecuteQuery(ctx, query, taskUUID)
+	items, err := d.QueryChecklistItemsOfTasks(ctx, []string{taskUUID})
+	if err != nil {
+		return nil, err
+	}
+	return items[taskUUID], nil
+}
+
+// QueryChecklistItem returns the checklist item with the given UUID, or nil
+// when there is none.
+func (d *DB) QueryChecklistItem(ctx context.Context, uuid string) (*ChecklistItemRow, error) {
+	ctx, cancel := d.queryContext(ctx)
+	defer cancel()
+
+	rows, err := d.ExecuteQuery(ctx, buildChecklistItemSQL(), uuid)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var items []ChecklistItemRow
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+	return scanChecklistItemRow(rows)
+}
+
+// maxBatchQueryTasks caps the task UUIDs bound into one checklist or tag
+// query, staying under SQLite's historical limit of 999 host parameters.
+const maxBatchQueryTasks = 500
+
+// QueryChecklistItemsOfTasks returns the checklist items of several tasks,
+// keyed by task UUID. Tasks without checklist items have no entry. Large UUID
+// lists are split into chunks, so the number of queries grows with
+// len(taskUUIDs)/500 rather than with len(taskUUIDs).
+func (d *DB) QueryChecklistItemsOfTasks(ctx context.Context, taskUUIDs []string) (map[string][]ChecklistItemRow, error) {
+	items := make(map[string][]ChecklistItemRow)
+	for chunk := range slices.Chunk(taskUUIDs, maxBatchQueryTasks) {
+		args := make([]any, len(chunk))
+		for i, uuid := range chunk {
+			args[i] = uuid
+		}
+		if err := d.collectChecklistItems(ctx, items, buildChecklistItemsSQL(len(chunk)), args); err != nil {
+			return nil, err
+		}
+	}
+	return items, nil
+}
+
+// collectChecklistItems runs one checklist query and appends its rows to items.
+func (d *DB) collectChecklistItems(ctx context.Context, items map[string][]ChecklistItemRow, query string, args []any) error {
+	ctx, cancel := d.queryContext(ctx)
+	defer cancel()
+
+	rows, err := d.ExecuteQuery(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
 	for rows.Next() {
 		item, err := scanChecklistItemRow(rows)
 		if err != nil {
-			return nil, err
+			return err
 		}
-		items = append(items, *item)
+		items[item.TaskUUID] = append(items[item.TaskUUID], *item)
 	}
 
-	return items, rows.Err()
+	return rows.Err()
+}
+
+// LastModified returns the latest modification time of any task, or the zero
+// time for a database without tasks. Sub-second precision is kept so that
+// two edits in the same second are told apart.
+func (d *DB) LastModified(ctx context.Context) (time.Time, error) {
+	ctx, cancel := d.queryContext(ctx)
+	defer cancel()
+
+	var modified sql.NullFloat64
+	if err := d.ExecuteQueryRow(ctx, buildLastModifiedSQL()).Scan(&modified); err != nil {
+		return time.Time{}, err
+	}
+	if !modified.Valid {
+		return time.Time{}, nil
+	}
+	return time.UnixMicro(int64(modified.Float64 * 1e6)).Local(), nil
 }
 
 // AuthToken returns the Things URL scheme authentication token.
 func (d *DB) AuthToken(ctx context.Context) (string, error) {
+	ctx, cancel := d.queryContext(ctx)
+	defer cancel()
+
 	query := buildAuthTokenSQL()
 	var token sql.NullString
 	if err := d.ExecuteQueryRow(ctx, query).Scan(&token); err != nil {
@@ -16,12 +16,14 @@ func TestTaskFilter_buildWhere(t *testing.T) {
 	defaultPrefix := "TASK.rt1_recurrenceRule IS NULL" + and +
 		"TASK.trashed = 0" + and +
 		"NOT IFNULL(PROJECT.trashed, 0)" + and +
+		"NOT IFNULL(HEADING.trashed, 0)" + and +
 		"NOT IFNULL(PROJECT_OF_HEADING.trashed, 0)"
 	// templatePrefix mirrors defaultPrefix but selects repeating templates
 	// instead of excluding them.
 	templatePrefix := "TASK.rt1_recurrenceRule IS NOT NULL" + and +
 		"TASK.trashed = 0" + and +
 		"NOT IFNULL(PROJECT.trashed, 0)" + and +
+		"NOT IFNULL(HEADING.trashed, 0)" + and +
 		"NOT IFNULL(PROJECT_OF_HEADING.trashed, 0)"
 
 	tests := []struct {
@@ -39,6 +41,11 @@ func TestTaskFilter_buildWhere(t *testing.T) {
 			filter: TaskFilter{Trashed: new(true)},
 			want:   "TASK.rt1_recurrenceRule IS NULL" + and + "TASK.trashed = 1",
 		},
+		{
+			name:   "context trashed included",
+			filter: TaskFilter{IncludeContextTrashed: true},
+			want:   "TASK.rt1_recurrenceRule IS NULL" + and + "TASK.trashed = 0",
+		},
 		{
 			name:   "trashed false explicit",
 			filter: TaskFilter{Trashed: new(false)},
@@ -59,6 +66,11 @@ func TestTaskFilter_buildWhere(t *testing.T) {
 			filter: TaskFilter{Start: new(1)},
 			want:   defaultPrefix + and + "TASK.start = 1",
 		},
+		{
+			name:   "start inherited from project",
+			filter: TaskFilter{Start: new(2), InheritProjectStart: true},
+			want:   defaultPrefix + and + "MAX(TASK.start, COALESCE(PROJECT.start, PROJECT_OF_HEADING.start, 0)) = 2",
+		},
 		{
 			name:   "uuid",
 			filter: TaskFilter{UUID: new("ABC-123")},
@@ -74,6 +86,21 @@ func TestTaskFilter_buildWhere(t *testing.T) {
 			filter: TaskFilter{UUIDPrefix: new("AB_C")},
 			want:   defaultPrefix + and + `TASK.uuid LIKE 'AB\_C%' ESCAPE '\'`,
 		},
+		{
+			name:   "uuids",
+			filter: TaskFilter{UUIDs: []string{"A", "B'C"}},
+			want:   defaultPrefix + and + "TASK.uuid IN ('A', 'B''C')",
+		},
+		{
+			name:   "exclude uuids",
+			filter: TaskFilter{ExcludeUUIDs: []string{"A", "B'C"}},
+			want:   defaultPrefix + and + "TASK.uuid NOT IN ('A', 'B''C')",
+		},
+		{
+			name:   "any tags empty matches nothing",
+			filter: TaskFilter{AnyTags: []string{}},
+			want:   defaultPrefix + and + "FALSE",
+		},
 		{
 			name:   "title contains",
 			filter: TaskFilter{Title: new("milk")},
@@ -241,6 +268,16 @@ func TestTaskFilter_buildWhere(t *testing.T) {
 			want: defaultPrefix + and +
 				`(TASK.title LIKE '%\%%' ESCAPE '\' OR TASK.notes LIKE '%\%%' ESCAPE '\' OR AREA.title LIKE '%\%%' ESCAPE '\')`,
 		},
+		{
+			name:   "keyset after",
+			filter: TaskFilter{After: &TaskKey{Column: IndexDefault, Index: 42, UUID: "it's"}},
+			want:   defaultPrefix + and + `(TASK."index", TASK.uuid) > (42, 'it''s')`,
+		},
+		{
+			name:   "keyset after unknown column matches nothing",
+			filter: TaskFilter{After: &TaskKey{Column: "title", UUID: "a"}},
+			want:   defaultPrefix + and + "FALSE",
+		},
 		{
 			name: "complex filter combination",
 			filter: TaskFilter{
@@ -276,6 +313,33 @@ func TestTaskFilter_buildOrder(t *testing.T) {
 		{"default", TaskFilter{}, `TASK."index"`},
 		{"explicit default", TaskFilter{Index: IndexDefault}, `TASK."index"`},
 		{"today index", TaskFilter{Index: IndexToday}, `TASK."todayIndex"`},
+		{"keyset", TaskFilter{Keyset: true}, `TASK."index", TASK.uuid`},
+		{"keyset after", TaskFilter{Index: IndexToday, After: &TaskKey{}}, `TASK."todayIndex", TASK.uuid`},
+		{
+			"stop date descending",
+			TaskFilter{OrderBy: []TaskOrder{{Column: OrderStopDate, Desc: true}}},
+			`TASK.stopDate IS NULL, TASK.stopDate DESC, TASK."index"`,
+		},
+		{
+			"stacked orderings",
+			TaskFilter{OrderBy: []TaskOrder{
+				{Column: OrderDeadline},
+				{Column: OrderTitle, Desc: true},
+				{Column: OrderCreationDate},
+			}},
+			`TASK.deadline IS NULL, TASK.deadline ASC, TASK.title COLLATE NOCASE DESC, ` +
+				`TASK.creationDate ASC, TASK."index"`,
+		},
+		{
+			"unknown column is skipped",
+			TaskFilter{OrderBy: []TaskOrder{{Column: "title; DROP TABLE TMTask"}}},
+			`TASK."index"`,
+		},
+		{
+			"template start date",
+			TaskFilter{RepeatingTemplates: new(true), OrderBy: []TaskOrder{{Column: OrderStartDate}}},
+			`TASK.rt1_nextInstanceStartDate IS NULL, TASK.rt1_nextInstanceStartDate ASC, TASK."index"`,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -302,6 +366,16 @@ func TestAreaFilter_buildWhere(t *testing.T) {
 			filter: AreaFilter{UUID: new("area-1")},
 			want:   "AREA.uuid = 'area-1'",
 		},
+		{
+			name:   "uuids",
+			filter: AreaFilter{UUIDs: []string{"area-1", "area-2"}},
+			want:   "AREA.uuid IN ('area-1', 'area-2')",
+		},
+		{
+			name:   "empty uuids matches nothing",
+			filter: AreaFilter{UUIDs: []string{}},
+			want:   "FALSE",
+		},
 		{
 			name:   "title",
 			filter: AreaFilter{Title: new("Work")},
@@ -394,3 +468,22 @@ func TestTagFilter_buildWhere(t *testing.T) {
 		})
 	}
 }
+
+func TestPageSQL(t *testing.T) {
+	tests := []struct {
+		name          string
+		limit, offset *int
+		want          string
+	}{
+		{name: "none", want: ""},
+		{name: "zero limit", limit: new(0), want: ""},
+		{name: "limit", limit: new(10), want: " LIMIT 10"},
+		{name: "limit and offset", limit: new(10), offset: new(20), want: " LIMIT 10 OFFSET 20"},
+		{name: "offset alone", offset: new(5), want: " LIMIT -1 OFFSET 5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, pageSQL(tt.limit, tt.offset))
+		})
+	}
+}
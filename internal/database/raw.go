@@ -0,0 +1,94 @@
+package database
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BindSQL substitutes each ? placeholder in expr with args rendered as SQL
+// literals, escaped like every other filter value, so an argument can never
+// change the shape of the expression. A ? inside a quoted string or
+// identifier or a -- or /* */ comment is left alone. Strings and other values
+// are quoted, nil becomes NULL, booleans 1 or 0, numbers stay numeric, a
+// []byte becomes a blob literal, and a time.Time becomes its Unix seconds to
+// match the timestamp columns. A trailing -- comment is ended with a newline.
+// It returns ErrRawArgCount when the placeholders and args differ in number.
+func BindSQL(expr string, args ...any) (string, error) {
+	var b strings.Builder
+	n := 0
+	for i := 0; i < len(expr); i++ {
+		c := expr[i]
+		var open, end string
+		switch {
+		case c == '\'' || c == '"':
+			open, end = string(c), string(c)
+		case strings.HasPrefix(expr[i:], "--"):
+			open, end = "--", "\n"
+		case strings.HasPrefix(expr[i:], "/*"):
+			open, end = "/*", "*/"
+		case c == '?':
+			if n < len(args) {
+				b.WriteString(sqlLiteral(args[n]))
+			}
+			n++
+			continue
+		default:
+			b.WriteByte(c)
+			continue
+		}
+		// Copy a quoted or commented span through its terminator untouched.
+		j := len(expr)
+		if k := strings.Index(expr[i+len(open):], end); k >= 0 {
+			j = i + len(open) + k + len(end)
+		}
+		b.WriteString(expr[i:j])
+		if j == len(expr) && end == "\n" {
+			// End a trailing line comment so the expression can be wrapped.
+			b.WriteString(end)
+		}
+		i = j - 1
+	}
+	if n != len(args) {
+		return "", fmt.Errorf("%w: %d placeholders, %d args", ErrRawArgCount, n, len(args))
+	}
+	return b.String(), nil
+}
+
+// sqlLiteral renders v as an SQL literal.
+func sqlLiteral(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "NULL"
+	case string:
+		return "'" + escapeString(v) + "'"
+	case bool:
+		if v {
+			return "1"
+		}
+		return "0"
+	case time.Time:
+		return strconv.FormatInt(v.Unix(), 10)
+	case []byte:
+		return "X'" + hex.EncodeToString(v) + "'"
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return "NULL"
+		}
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	default:
+		return "'" + escapeString(fmt.Sprint(v)) + "'"
+	}
+}
@@ -0,0 +1,55 @@
+package database
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBindSQL(t *testing.T) {
+	type label string
+	tests := []struct {
+		name string
+		expr string
+		args []any
+		want string
+	}{
+		{"no placeholders", "TASK.status = 0", nil, "TASK.status = 0"},
+		{"string", "TASK.title LIKE ?", []any{"%milk%"}, "TASK.title LIKE '%milk%'"},
+		{"quote is escaped", "TASK.title = ?", []any{"x' OR 1=1 --"}, "TASK.title = 'x'' OR 1=1 --'"},
+		{"ints and floats", "TASK.status = ? AND TASK.x > ?", []any{3, 1.5}, "TASK.status = 3 AND TASK.x > 1.5"},
+		{"unsigned", "TASK.x = ?", []any{uint8(7)}, "TASK.x = 7"},
+		{"bool", "TASK.trashed = ?", []any{true}, "TASK.trashed = 1"},
+		{"nil", "TASK.notes IS ?", []any{nil}, "TASK.notes IS NULL"},
+		{"non-finite float", "TASK.x = ?", []any{math.Inf(1)}, "TASK.x = NULL"},
+		{
+			"time as unix seconds", "TASK.creationDate < ?",
+			[]any{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+			"TASK.creationDate < 1704067200",
+		},
+		{"other types are quoted", "TASK.title = ?", []any{label("it's")}, "TASK.title = 'it''s'"},
+		{"? in quotes is literal", `TASK.title = '?' OR "?" = ?`, []any{1}, `TASK.title = '?' OR "?" = 1`},
+		{"? in line comment is literal", "TASK.status = ? -- why?\nAND TASK.x = ?", []any{1, 2}, "TASK.status = 1 -- why?\nAND TASK.x = 2"},
+		{"? in block comment is literal", "TASK.status = ? /* or ? */", []any{1}, "TASK.status = 1 /* or ? */"},
+		{"comment runs to the end", "TASK.status = ? -- ?", []any{1}, "TASK.status = 1 -- ?\n"},
+		{"bytes as blob", "TASK.x = ?", []any{[]byte{0x01, 0xab}}, "TASK.x = X'01ab'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BindSQL(tt.expr, tt.args...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBindSQL_argCount(t *testing.T) {
+	_, err := BindSQL("TASK.title = ? AND TASK.status = ?", "x")
+	require.ErrorIs(t, err, ErrRawArgCount)
+
+	_, err = BindSQL("TASK.status = 0", 1)
+	require.ErrorIs(t, err, ErrRawArgCount)
+}
@@ -0,0 +1,181 @@
+package database
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Calendar units of a recurrence rule's "fu" key, as NSCalendarUnit values.
+const (
+	RecurrenceUnitYear  = 4
+	RecurrenceUnitMonth = 8
+	RecurrenceUnitDay   = 16
+	RecurrenceUnitWeek  = 256
+)
+
+// recurrenceNoEnd is the "ed" value Things stores for a rule without an end
+// date: 4001-01-01, Foundation's distantFuture, in Unix seconds.
+const recurrenceNoEnd = 64092211200
+
+// errUnsupportedPlist is returned for a rule not stored as an XML plist.
+var errUnsupportedPlist = errors.New("recurrence rule is not an XML property list")
+
+// RecurrenceRule is the schedule of a repeating template, decoded from the
+// property list in rt1_recurrenceRule. Only the common keys are read.
+type RecurrenceRule struct {
+	Unit        int        // "fu": one of the RecurrenceUnit* constants
+	Interval    int        // "fa": every Interval units
+	Weekdays    []int      // "of"/"wd": 0 = Sunday
+	DaysOfMonth []int      // "of"/"dy": -1 = last day of the month
+	Until       *time.Time // "ed": last date of the series; nil for none
+	Count       int        // "rc": number of occurrences; 0 for no limit
+}
+
+// parseRecurrenceRule decodes a stored recurrence rule. Things writes it as
+// an XML plist dictionary with short keys; binary plists are not supported.
+func parseRecurrenceRule(blob []byte) (*RecurrenceRule, error) {
+	if !bytes.HasPrefix(bytes.TrimSpace(blob), []byte("<?xml")) {
+		return nil, errUnsupportedPlist
+	}
+	value, err := decodePlist(xml.NewDecoder(bytes.NewReader(blob)))
+	if err != nil {
+		return nil, fmt.Errorf("decode recurrence rule: %w", err)
+	}
+	dict, ok := value.(map[string]any)
+	if !ok {
+		return nil, errors.New("decode recurrence rule: top level is not a dict")
+	}
+
+	rule := &RecurrenceRule{
+		Unit:     plistInt(dict["fu"]),
+		Interval: plistInt(dict["fa"]),
+		Count:    plistInt(dict["rc"]),
+	}
+	if end, ok := dict["ed"].(float64); ok && end > 0 && end < recurrenceNoEnd {
+		until := unixToTime(end)
+		rule.Until = &until
+	}
+	offsets, _ := dict["of"].([]any)
+	for _, o := range offsets {
+		offset, _ := o.(map[string]any)
+		if wd, ok := offset["wd"]; ok {
+			rule.Weekdays = append(rule.Weekdays, plistInt(wd))
+		}
+		if dy, ok := offset["dy"]; ok {
+			rule.DaysOfMonth = append(rule.DaysOfMonth, plistInt(dy))
+		}
+	}
+	return rule, nil
+}
+
+// plistInt returns a plist integer or real as an int, or 0 for anything else.
+func plistInt(v any) int {
+	switch n := v.(type) {
+	case int64:
+		return int(n)
+	case float64:
+		return int(n)
+	}
+	return 0
+}
+
+// decodePlist decodes the first value inside the plist element.
+func decodePlist(d *xml.Decoder) (any, error) {
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return nil, err
+		}
+		if start, ok := tok.(xml.StartElement); ok && start.Name.Local != "plist" {
+			return decodePlistValue(d, start)
+		}
+	}
+}
+
+// decodePlistValue decodes the element opened by start: dict as
+// map[string]any, array as []any, integer as int64, real as float64, string,
+// true and false. Other elements decode as nil.
+func decodePlistValue(d *xml.Decoder, start xml.StartElement) (any, error) {
+	switch start.Name.Local {
+	case "dict":
+		return decodePlistDict(d)
+	case "array":
+		return decodePlistArray(d)
+	}
+
+	var text string
+	if err := d.DecodeElement(&text, &start); err != nil {
+		return nil, err
+	}
+	switch start.Name.Local {
+	case "integer":
+		return strconv.ParseInt(text, 10, 64)
+	case "real":
+		return strconv.ParseFloat(text, 64)
+	case "string":
+		return text, nil
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	}
+	return nil, nil
+}
+
+// decodePlistDict decodes alternating key and value elements up to </dict>.
+func decodePlistDict(d *xml.Decoder) (map[string]any, error) {
+	dict := make(map[string]any)
+	var key *string
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.EndElement:
+			return dict, nil
+		case xml.StartElement:
+			if key == nil {
+				if t.Name.Local != "key" {
+					return nil, fmt.Errorf("plist dict: expected key, got %s", t.Name.Local)
+				}
+				key = new(string)
+				if err := d.DecodeElement(key, &t); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			value, err := decodePlistValue(d, t)
+			if err != nil {
+				return nil, err
+			}
+			dict[*key] = value
+			key = nil
+		}
+	}
+}
+
+// decodePlistArray decodes values up to </array>.
+func decodePlistArray(d *xml.Decoder) ([]any, error) {
+	var array []any
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.EndElement:
+			return array, nil
+		case xml.StartElement:
+			value, err := decodePlistValue(d, t)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, value)
+		}
+	}
+}
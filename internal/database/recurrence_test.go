@@ -0,0 +1,59 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// plistRule wraps dict entries in the XML plist envelope Things writes.
+func plistRule(entries string) []byte {
+	return []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>` + entries + `</dict>
+</plist>
+`)
+}
+
+func TestParseRecurrenceRule(t *testing.T) {
+	t.Run("weekly without end", func(t *testing.T) {
+		rule, err := parseRecurrenceRule(plistRule(`
+	<key>ed</key><real>64092211200</real>
+	<key>fa</key><integer>1</integer>
+	<key>fu</key><integer>256</integer>
+	<key>of</key><array><dict><key>wd</key><integer>0</integer></dict></array>
+	<key>rc</key><integer>0</integer>
+	<key>tp</key><integer>1</integer>`))
+		require.NoError(t, err)
+		assert.Equal(t, &RecurrenceRule{Unit: RecurrenceUnitWeek, Interval: 1, Weekdays: []int{0}}, rule)
+	})
+
+	t.Run("monthly with end date and count", func(t *testing.T) {
+		rule, err := parseRecurrenceRule(plistRule(`
+	<key>ed</key><real>1735689600</real>
+	<key>fa</key><integer>2</integer>
+	<key>fu</key><integer>8</integer>
+	<key>of</key><array><dict><key>dy</key><integer>-1</integer></dict><dict><key>dy</key><integer>15</integer></dict></array>
+	<key>rc</key><integer>6</integer>`))
+		require.NoError(t, err)
+		assert.Equal(t, RecurrenceUnitMonth, rule.Unit)
+		assert.Equal(t, 2, rule.Interval)
+		assert.Equal(t, []int{-1, 15}, rule.DaysOfMonth)
+		assert.Equal(t, 6, rule.Count)
+		require.NotNil(t, rule.Until)
+		assert.True(t, rule.Until.Equal(time.Unix(1735689600, 0)))
+	})
+
+	t.Run("binary plist is unsupported", func(t *testing.T) {
+		_, err := parseRecurrenceRule([]byte("bplist00\x01\x02"))
+		assert.ErrorIs(t, err, errUnsupportedPlist)
+	})
+
+	t.Run("truncated xml", func(t *testing.T) {
+		_, err := parseRecurrenceRule([]byte(`<?xml version="1.0"?><plist><dict><key>fu</key>`))
+		assert.Error(t, err)
+	})
+}
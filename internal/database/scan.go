@@ -20,7 +20,7 @@ func scanTaskRow(rows *sql.Rows) (*TaskRow, error) {
 		&s.headingUUID, &s.headingTitle, &s.notes, &s.tags, &s.start,
 		&s.checklist, &s.startDate, &s.deadline, &s.reminderTime,
 		&s.stopDate, &s.created, &s.modified, &s.index, &s.todayIndex,
-		&s.startBucket, &s.repeating,
+		&s.startBucket, &s.repeating, &s.deadlineSuppressed, &s.recurrenceRule,
 	)
 	if err != nil {
 		return nil, err
@@ -34,10 +34,12 @@ type taskScanRow struct {
 	index, todayIndex                                int
 	typeStr, statusStr                               sql.NullString
 	trashed, tags, checklist, startBucket, repeating sql.NullInt64
+	deadlineSuppressed                               sql.NullInt64
 	areaUUID, areaTitle, projectUUID, projectTitle   sql.NullString
 	headingUUID, headingTitle, notes, start          sql.NullString
 	startDate, deadline, reminderTime                sql.NullString
 	stopDate, created, modified                      sql.NullFloat64
+	recurrenceRule                                   []byte
 }
 
 // toTaskRow converts raw scan values into a TaskRow.
@@ -68,6 +70,13 @@ func (s *taskScanRow) toTaskRow() *TaskRow {
 		TodayIndex:   s.todayIndex,
 		Evening:      s.startBucket.Valid && s.startBucket.Int64 == startBucketEvening,
 		Repeating:    nullBool(s.repeating),
+
+		DeadlineSuppressed: nullBool(s.deadlineSuppressed),
+	}
+	// The rule is best-effort: a format this package cannot decode leaves
+	// RecurrenceRule nil rather than failing the row.
+	if len(s.recurrenceRule) > 0 {
+		row.RecurrenceRule, _ = parseRecurrenceRule(s.recurrenceRule)
 	}
 	return row
 }
@@ -76,14 +85,15 @@ func (s *taskScanRow) toTaskRow() *TaskRow {
 func scanAreaRow(rows *sql.Rows) (*AreaRow, error) {
 	var row AreaRow
 	var typeStr sql.NullString
-	var tags sql.NullInt64
+	var tags, visible sql.NullInt64
 
-	err := rows.Scan(&row.UUID, &typeStr, &row.Title, &tags)
+	err := rows.Scan(&row.UUID, &typeStr, &row.Title, &tags, &visible)
 	if err != nil {
 		return nil, err
 	}
 
 	row.HasTags = nullBool(tags)
+	row.Visible = nullBool(visible)
 
 	return &row, nil
 }
@@ -91,14 +101,15 @@ func scanAreaRow(rows *sql.Rows) (*AreaRow, error) {
 // scanTagRow scans a sql.Rows into a TagRow.
 func scanTagRow(rows *sql.Rows) (*TagRow, error) {
 	var row TagRow
-	var typeStr, shortcut sql.NullString
+	var typeStr, shortcut, parent sql.NullString
 
-	err := rows.Scan(&row.UUID, &typeStr, &row.Title, &shortcut)
+	err := rows.Scan(&row.UUID, &typeStr, &row.Title, &shortcut, &parent)
 	if err != nil {
 		return nil, err
 	}
 
 	row.Shortcut = nullStringValue(shortcut)
+	row.ParentUUID = nullStringValue(parent)
 
 	return &row, nil
 }
@@ -109,7 +120,7 @@ func scanChecklistItemRow(rows *sql.Rows) (*ChecklistItemRow, error) {
 	var typeStr, stopDate sql.NullString
 	var created, modified sql.NullFloat64
 
-	err := rows.Scan(&row.Title, &row.Status, &stopDate, &typeStr, &row.UUID, &created, &modified)
+	err := rows.Scan(&row.Title, &row.Status, &stopDate, &typeStr, &row.UUID, &created, &modified, &row.TaskUUID)
 	if err != nil {
 		return nil, err
 	}
@@ -1,15 +1,22 @@
 package database
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // sqlTrue is the default WHERE predicate.
 const sqlTrue = "TRUE"
 
-// buildTasksSQL builds the SQL query for fetching tasks. When templateStartDate
-// is true the start_date column is sourced from rt1_nextInstanceStartDate, so a
-// repeating template surfaces its next occurrence as its start date and flows
-// through the shared scan/convert pipeline unchanged.
-func buildTasksSQL(wherePredicate, orderPredicate string, limit *int, templateStartDate bool) string {
+// buildTasksSQL builds the SQL query for fetching tasks. A todo filed under a
+// heading has no project column of its own, so its project and project_title
+// come from the heading's project. When templateStartDate is true the
+// start_date column is sourced from rt1_nextInstanceStartDate, so a repeating
+// template surfaces its next occurrence as its start date and flows through
+// the shared scan/convert pipeline unchanged. A generated instance of a
+// repeating series reports its template's recurrence rule. startBucket is the expression
+// the start column is derived from; see startExpr.
+func buildTasksSQL(wherePredicate, orderPredicate string, limit, offset *int, templateStartDate bool, startBucket string) string {
 	if wherePredicate == "" {
 		wherePredicate = sqlTrue
 	}
@@ -50,9 +57,11 @@ func buildTasksSQL(wherePredicate, orderPredicate string, limit *int, templateSt
 			END AS area_title,
 			CASE
 				WHEN PROJECT.uuid IS NOT NULL THEN PROJECT.uuid
+				WHEN PROJECT_OF_HEADING.uuid IS NOT NULL THEN PROJECT_OF_HEADING.uuid
 			END AS project,
 			CASE
 				WHEN PROJECT.uuid IS NOT NULL THEN PROJECT.title
+				WHEN PROJECT_OF_HEADING.uuid IS NOT NULL THEN PROJECT_OF_HEADING.title
 			END AS project_title,
 			CASE
 				WHEN HEADING.uuid IS NOT NULL THEN HEADING.uuid
@@ -64,10 +73,10 @@ func buildTasksSQL(wherePredicate, orderPredicate string, limit *int, templateSt
 			CASE
 				WHEN TAG.uuid IS NOT NULL THEN 1
 			END AS tags,
-			CASE
-				WHEN TASK.%s THEN 'Inbox'
-				WHEN TASK.%s THEN 'Anytime'
-				WHEN TASK.%s THEN 'Someday'
+			CASE %s
+				WHEN 0 THEN 'Inbox'
+				WHEN 1 THEN 'Anytime'
+				WHEN 2 THEN 'Someday'
 			END AS start,
 			CASE
 				WHEN CHECKLIST_ITEM.uuid IS NOT NULL THEN 1
@@ -83,7 +92,14 @@ func buildTasksSQL(wherePredicate, orderPredicate string, limit *int, templateSt
 			TASK.startBucket AS start_bucket,
 			CASE
 				WHEN TASK.rt1_repeatingTemplate IS NOT NULL OR TASK.rt1_recurrenceRule IS NOT NULL THEN 1
-			END AS repeating
+			END AS repeating,
+			CASE
+				WHEN TASK.deadlineSuppressionDate IS NOT NULL THEN 1
+			END AS deadline_suppressed,
+			COALESCE(
+				TASK.rt1_recurrenceRule,
+				(SELECT TEMPLATE.rt1_recurrenceRule FROM %s TEMPLATE WHERE TEMPLATE.uuid = TASK.rt1_repeatingTemplate)
+			) AS recurrence_rule
 		FROM
 			%s AS TASK
 		LEFT OUTER JOIN
@@ -108,19 +124,33 @@ func buildTasksSQL(wherePredicate, orderPredicate string, limit *int, templateSt
 		filterIsTodo, filterIsProject, filterIsHeading,
 		filterIsTrashed,
 		filterIsIncomplete, filterIsCanceled, filterIsCompleted,
-		filterIsInbox, filterIsAnytime, filterIsSomeday,
+		startBucket,
 		startDateExpr, deadlineExpr, reminderTimeExpr,
 		colStopDate, colCreationDate, colModificationDate,
+		tableTask,
 		tableTask, tableTask, tableArea, tableTask, tableTask,
 		tableTaskTag, tableTag, tableChecklistItem,
 		wherePredicate, orderPredicate,
 	)
 
-	if limit != nil && *limit > 0 {
-		sql += fmt.Sprintf(" LIMIT %d", *limit)
-	}
+	return sql + pageSQL(limit, offset)
+}
 
-	return sql
+// pageSQL builds the LIMIT/OFFSET clause of a query. A nil or non-positive
+// limit means no limit, and a nil or non-positive offset skips nothing.
+// SQLite only accepts OFFSET after a LIMIT, so an offset alone uses LIMIT -1.
+func pageSQL(limit, offset *int) string {
+	hasLimit := limit != nil && *limit > 0
+	hasOffset := offset != nil && *offset > 0
+	switch {
+	case hasLimit && hasOffset:
+		return fmt.Sprintf(" LIMIT %d OFFSET %d", *limit, *offset)
+	case hasLimit:
+		return fmt.Sprintf(" LIMIT %d", *limit)
+	case hasOffset:
+		return fmt.Sprintf(" LIMIT -1 OFFSET %d", *offset)
+	}
+	return ""
 }
 
 // buildAreasSQL builds the SQL query for fetching areas.
@@ -136,7 +166,8 @@ func buildAreasSQL(wherePredicate string) string {
 			AREA.title,
 			CASE
 				WHEN AREA_TAG.areas IS NOT NULL THEN 1
-			END AS tags
+			END AS tags,
+			IFNULL(AREA.visible, 1) != 0 AS visible
 		FROM
 			%s AS AREA
 		LEFT OUTER JOIN
@@ -157,7 +188,7 @@ func buildTagsSQL(wherePredicate string) string {
 
 	return fmt.Sprintf(`
 		SELECT
-			uuid, 'tag' AS type, title, shortcut
+			uuid, 'tag' AS type, title, shortcut, parent
 		FROM
 			%s
 		WHERE
@@ -166,8 +197,48 @@ func buildTagsSQL(wherePredicate string) string {
 	`, tableTag, wherePredicate)
 }
 
-// buildChecklistItemsSQL builds the SQL query for fetching checklist items.
-func buildChecklistItemsSQL() string {
+// buildTagsUsedInAreaSQL builds a tag predicate matching the tags of every
+// untrashed task in the area, following the same project and heading joins
+// as buildTasksSQL.
+func buildTagsUsedInAreaSQL(areaUUID string) string {
+	return fmt.Sprintf(`uuid IN (
+			SELECT TASK_TAG.tags
+			FROM %s AS TASK_TAG
+			JOIN %s TASK ON TASK.uuid = TASK_TAG.tasks
+			LEFT OUTER JOIN %s PROJECT ON TASK.project = PROJECT.uuid
+			LEFT OUTER JOIN %s HEADING ON TASK.heading = HEADING.uuid
+			LEFT OUTER JOIN %s PROJECT_OF_HEADING ON HEADING.project = PROJECT_OF_HEADING.uuid
+			WHERE TASK.%s
+				AND '%s' IN (TASK.area, PROJECT.area, PROJECT_OF_HEADING.area)
+		)`, tableTaskTag, tableTask, tableTask, tableTask, tableTask,
+		filterIsNotTrashed, escapeString(areaUUID))
+}
+
+// buildTagsUnusedSQL builds a tag predicate matching tags that appear in
+// neither join table. Any task row counts, trashed or not, since Things keeps
+// the tag on a trashed task until the trash is emptied.
+func buildTagsUnusedSQL() string {
+	return fmt.Sprintf(`NOT EXISTS (SELECT 1 FROM %[1]s WHERE %[1]s.tags = %[3]s.uuid)
+			AND NOT EXISTS (SELECT 1 FROM %[2]s WHERE %[2]s.tags = %[3]s.uuid)`,
+		tableTaskTag, tableAreaTag, tableTag)
+}
+
+// buildChecklistItemsSQL builds the SQL query for fetching the checklist items
+// of n tasks, bound as n positional parameters.
+func buildChecklistItemsSQL(n int) string {
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+	return buildChecklistItemSelectSQL(fmt.Sprintf("CHECKLIST_ITEM.task IN (%s)", placeholders))
+}
+
+// buildChecklistItemSQL builds the SQL query for fetching one checklist item
+// by UUID.
+func buildChecklistItemSQL() string {
+	return buildChecklistItemSelectSQL("CHECKLIST_ITEM.uuid = ?")
+}
+
+// buildChecklistItemSelectSQL builds the checklist item query for a WHERE
+// predicate, ordered by owning task and then checklist position.
+func buildChecklistItemSelectSQL(wherePredicate string) string {
 	return fmt.Sprintf(`
 		SELECT
 			CHECKLIST_ITEM.title,
@@ -180,14 +251,15 @@ func buildChecklistItemsSQL() string {
 			'checklist-item' as type,
 			CHECKLIST_ITEM.uuid,
 			CHECKLIST_ITEM.%s AS created,
-			CHECKLIST_ITEM.%s AS modified
+			CHECKLIST_ITEM.%s AS modified,
+			CHECKLIST_ITEM.task
 		FROM
 			%s AS CHECKLIST_ITEM
 		WHERE
-			CHECKLIST_ITEM.task = ?
-		ORDER BY CHECKLIST_ITEM."index"
+			%s
+		ORDER BY CHECKLIST_ITEM.task, CHECKLIST_ITEM."index"
 	`, filterIsIncomplete, filterIsCanceled, filterIsCompleted,
-		colCreationDate, colModificationDate, tableChecklistItem)
+		colCreationDate, colModificationDate, tableChecklistItem, wherePredicate)
 }
 
 // buildTagsOfTaskSQL builds the SQL query for fetching tags of a task.
@@ -205,6 +277,24 @@ func buildTagsOfTaskSQL() string {
 	`, tableTaskTag, tableTag)
 }
 
+// buildTagsOfTasksSQL builds the SQL query for fetching the tags of n tasks
+// at once, ordered by task and then tag position.
+func buildTagsOfTasksSQL(n int) string {
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+	return fmt.Sprintf(`
+		SELECT
+			TASK_TAG.tasks,
+			TAG.title
+		FROM
+			%s AS TASK_TAG
+		LEFT OUTER JOIN
+			%s TAG ON TAG.uuid = TASK_TAG.tags
+		WHERE
+			TASK_TAG.tasks IN (%s)
+		ORDER BY TASK_TAG.tasks, TAG."index"
+	`, tableTaskTag, tableTag, placeholders)
+}
+
 // buildTagsOfAreaSQL builds the SQL query for fetching tags of an area.
 func buildTagsOfAreaSQL() string {
 	return fmt.Sprintf(`
@@ -225,6 +315,31 @@ func buildCountSQL(sql string) string {
 	return fmt.Sprintf("SELECT COUNT(uuid) FROM (\n%s\n)", sql)
 }
 
+// buildCountBySQL wraps a task query to count its rows per value of a
+// GroupBy column, leaving out rows without one. A task with several tags
+// counts once under each.
+func buildCountBySQL(sql, column string) (string, error) {
+	if err := CheckColumn(ErrInvalidGroupColumn, column, groupColumns); err != nil {
+		return "", err
+	}
+	if column == GroupByTag {
+		return fmt.Sprintf(`SELECT GROUP_TAG.title, COUNT(DISTINCT GROUPED.uuid) FROM (
+%s
+) AS GROUPED
+JOIN %s GROUP_TASK_TAG ON GROUP_TASK_TAG.tasks = GROUPED.uuid
+JOIN %s GROUP_TAG ON GROUP_TAG.uuid = GROUP_TASK_TAG.tags
+GROUP BY GROUP_TAG.title`, sql, tableTaskTag, tableTag), nil
+	}
+	return fmt.Sprintf("SELECT %[2]s, COUNT(uuid) FROM (\n%[1]s\n) WHERE %[2]s IS NOT NULL GROUP BY %[2]s",
+		sql, column), nil
+}
+
+// buildLastModifiedSQL builds the query for the latest task modification time,
+// including trashed tasks so that moving one to the Trash counts.
+func buildLastModifiedSQL() string {
+	return fmt.Sprintf("SELECT MAX(%s) FROM %s", colModificationDate, tableTask)
+}
+
 // buildAuthTokenSQL builds the SQL query for fetching the auth token.
 func buildAuthTokenSQL() string {
 	return fmt.Sprintf(`
@@ -87,6 +87,8 @@ var (
 	AreaIDParam       = StrParam{Key: KeyAreaID}
 	PrependNotesParam = StrParam{Key: KeyPrependNotes}
 	AppendNotesParam  = StrParam{Key: KeyAppendNotes}
+	XSuccessParam     = StrParam{Key: KeyXSuccess}
+	XErrorParam       = StrParam{Key: KeyXError}
 
 	// Boolean parameters
 	CompletedParam      = BoolParam{Key: KeyCompleted}
@@ -183,9 +185,36 @@ func SetDate[T AttrBuilder](b T, p DateParam, year int, month time.Month, day in
 	return b
 }
 
+// ErrInvalidWhen is returned when a "when" value is neither a scheduling
+// keyword nor a yyyy-mm-dd date. Things silently ignores such values, so
+// builders reject them instead of sending a no-op.
+var ErrInvalidWhen = errors.New("things3: invalid when value (want today, tomorrow, evening, anytime, someday, or yyyy-mm-dd)")
+
+// NormalizeWhen trims and lowercases a "when" value, returning
+// ErrInvalidWhen unless the result is a keyword Things understands (today,
+// tomorrow, evening, anytime, someday) or a valid yyyy-mm-dd date.
+func NormalizeWhen(w When) (When, error) {
+	normalized := When(strings.ToLower(strings.TrimSpace(string(w))))
+	switch normalized {
+	case "today", "tomorrow", WhenEvening, WhenAnytime, WhenSomeday:
+		return normalized, nil
+	}
+	if _, err := time.Parse(time.DateOnly, string(normalized)); err != nil {
+		return "", ErrInvalidWhen
+	}
+	return normalized, nil
+}
+
 // SetWhenStr sets the when attribute using a When constant.
+// The value is normalized (trimmed, lowercased) and must be a known keyword
+// or a yyyy-mm-dd date; anything else sets ErrInvalidWhen.
 func SetWhenStr[T AttrBuilder](b T, w When) T {
-	b.GetStore().SetString(KeyWhen, string(w))
+	normalized, err := NormalizeWhen(w)
+	if err != nil {
+		b.SetErr(err)
+		return b
+	}
+	b.GetStore().SetString(KeyWhen, string(normalized))
 	return b
 }
 
@@ -200,13 +229,24 @@ func SetWhenTime[T AttrBuilder](b T, t time.Time) T {
 	return b
 }
 
+// ErrDeadlineHasTime is returned when a deadline carries a time of day.
+// Neither the URL scheme's deadline parameter nor the database's deadline
+// column can hold one, so the time would otherwise be dropped silently.
+var ErrDeadlineHasTime = errors.New("things3: deadline has a time of day, but Things deadlines are dates only " +
+	"(pass midnight, e.g. via time.Date(y, m, d, 0, 0, 0, 0, loc))")
+
 // SetDeadlineTime sets the deadline attribute using a time.Time value.
 // The time is formatted as yyyy-mm-dd for the Things URL scheme.
-// If the time is zero, the parameter is not set.
+// If the time is zero, the parameter is not set; a time other than midnight
+// records ErrDeadlineHasTime.
 func SetDeadlineTime[T AttrBuilder](b T, t time.Time) T {
 	if t.IsZero() {
 		return b
 	}
+	if h, m, s := t.Clock(); h != 0 || m != 0 || s != 0 || t.Nanosecond() != 0 {
+		b.SetErr(ErrDeadlineHasTime)
+		return b
+	}
 	b.GetStore().SetDate(KeyDeadline, t.Year(), t.Month(), t.Day())
 	return b
 }
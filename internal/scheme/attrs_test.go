@@ -393,6 +393,38 @@ func TestSetWhenStr(t *testing.T) {
 	assert.Equal(t, "evening", b.attrs.Params["when"])
 }
 
+func TestSetWhenStr_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		when    When
+		want    string
+		wantErr error
+	}{
+		{"keyword", WhenSomeday, "someday", nil},
+		{"today", "today", "today", nil},
+		{"tomorrow", "tomorrow", "tomorrow", nil},
+		{"normalized case and space", " Evening ", "evening", nil},
+		{"date", "2025-06-15", "2025-06-15", nil},
+		{"unknown keyword", "later", "", ErrInvalidWhen},
+		{"invalid date", "2025-02-30", "", ErrInvalidWhen},
+		{"empty", "", "", ErrInvalidWhen},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newMockJSONBuilder()
+			SetWhenStr(b, tt.when)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, b.err, tt.wantErr)
+				assert.NotContains(t, b.attrs.Attrs, KeyWhen)
+				return
+			}
+			require.NoError(t, b.err)
+			assert.Equal(t, tt.want, b.attrs.Attrs[KeyWhen])
+		})
+	}
+}
+
 func TestSetWhenTime(t *testing.T) {
 	b := newMockBuilder()
 	testDate := time.Date(2025, 6, 15, 14, 30, 0, 0, time.Local)
@@ -54,6 +54,20 @@ func (b *addTodoBuilder) Notes(notes string) TodoAdder {
 	return SetStr(b, NotesParam, notes)
 }
 
+// AddLink appends a Markdown link to the todo notes on its own line.
+// An empty title writes the bare URL, which Things still renders as a link.
+// The combined notes remain subject to MaxNotesLength.
+func (b *addTodoBuilder) AddLink(title, link string) TodoAdder {
+	entry := link
+	if title != "" {
+		entry = fmt.Sprintf("[%s](%s)", title, link)
+	}
+	if notes := b.attrs.Params[KeyNotes]; notes != "" {
+		entry = notes + "\n" + entry
+	}
+	return SetStr(b, NotesParam, entry)
+}
+
 // When sets the scheduling date using a time.Time value.
 // The date portion is used; time-of-day is ignored.
 func (b *addTodoBuilder) When(t time.Time) TodoAdder {
@@ -78,8 +92,25 @@ func (b *addTodoBuilder) WhenSomeday() TodoAdder {
 	return SetWhenStr(b, WhenSomeday)
 }
 
-// Deadline sets the deadline date using a time.Time value.
-// The date portion is used; time-of-day is ignored.
+// WhenNextMonday schedules the todo for the next Monday after today.
+func (b *addTodoBuilder) WhenNextMonday() TodoAdder {
+	return SetWhenTime(b, nextWeekday(b.scheme.clock(), time.Monday))
+}
+
+// WhenThisWeekend schedules the todo for this Saturday, or today when
+// today is already Saturday or Sunday.
+func (b *addTodoBuilder) WhenThisWeekend() TodoAdder {
+	return SetWhenTime(b, thisWeekend(b.scheme.clock()))
+}
+
+// WhenInDays schedules the todo n days from today.
+func (b *addTodoBuilder) WhenInDays(n int) TodoAdder {
+	return SetWhenTime(b, inDays(b.scheme.clock(), n))
+}
+
+// Deadline sets the deadline date using a time.Time value. Things deadlines
+// are dates only, so t must be midnight; a time of day fails the build with
+// ErrDeadlineHasTime.
 func (b *addTodoBuilder) Deadline(t time.Time) TodoAdder {
 	return SetDeadlineTime(b, t)
 }
@@ -131,10 +162,31 @@ func (b *addTodoBuilder) ShowQuickEntry(show bool) TodoAdder {
 }
 
 // Reveal navigates to the newly created todo.
+// The add command cannot report the new todo's UUID without an x-callback
+// handler, so revealing it is the way to show the user what was just made.
+// When Titles creates several todos, the first one is shown.
 func (b *addTodoBuilder) Reveal(reveal bool) TodoAdder {
 	return SetBool(b, RevealParam, reveal)
 }
 
+// RevealAfterCreate is Reveal(true), spelled out for call sites that want the
+// app to open the todo once it exists.
+func (b *addTodoBuilder) RevealAfterCreate() TodoAdder {
+	return b.Reveal(true)
+}
+
+// XSuccess sets the x-callback-url Things opens after adding the todo,
+// passing the new todo's ID as x-things-id. The callback is encoded as one
+// parameter value, so its own query string survives intact.
+func (b *addTodoBuilder) XSuccess(callback string) TodoAdder {
+	return SetStr(b, XSuccessParam, callback)
+}
+
+// XError sets the x-callback-url Things opens when adding the todo fails.
+func (b *addTodoBuilder) XError(callback string) TodoAdder {
+	return SetStr(b, XErrorParam, callback)
+}
+
 // Reminder sets a reminder time for the todo.
 // The reminder is combined with the scheduling date (When/WhenEvening).
 // If no scheduling date is set, defaults to "today".
@@ -160,6 +212,12 @@ func (b *addTodoBuilder) CompletionDate(date time.Time) TodoAdder {
 // Build is pure: it never mutates the builder, so it can be called
 // repeatedly (including via Execute) with identical results.
 func (b *addTodoBuilder) Build() (string, error) {
+	return b.build(nil)
+}
+
+// build returns the URL with extra parameters layered over the builder's
+// own, leaving the builder untouched.
+func (b *addTodoBuilder) build(extra map[string]string) (string, error) {
 	if b.err != nil {
 		return "", b.err
 	}
@@ -168,6 +226,9 @@ func (b *addTodoBuilder) Build() (string, error) {
 	if err != nil {
 		return "", err
 	}
+	for k, v := range extra {
+		query.Set(k, v)
+	}
 
 	return fmt.Sprintf("things:///%s?%s", CommandAdd, EncodeQuery(query)), nil
 }
@@ -182,6 +243,17 @@ func (b *addTodoBuilder) Execute(ctx context.Context) error {
 	return b.scheme.Execute(ctx, uri)
 }
 
+// ExecuteAndWait executes the add URL and returns the ID Things reports for
+// the new todo. It points the x-success and x-error callbacks at a local
+// listener, overriding XSuccess and XError for this call, and waits for one
+// of them or for ctx to end. When ctx has no deadline the wait is capped at
+// one minute and then fails with ErrCallbackTimeout, since a closed app or a
+// denied request never calls back. Things hands the callback to the default
+// browser, so a browser tab opens on the listener's page.
+func (b *addTodoBuilder) ExecuteAndWait(ctx context.Context) (string, error) {
+	return executeWithCallback(ctx, b.build, b.scheme.Execute)
+}
+
 // addProjectBuilder builds URLs for creating new projects via the add-project command.
 type addProjectBuilder struct {
 	scheme *Scheme
@@ -231,8 +303,25 @@ func (b *addProjectBuilder) WhenSomeday() ProjectAdder {
 	return SetWhenStr(b, WhenSomeday)
 }
 
-// Deadline sets the deadline date using a time.Time value.
-// The date portion is used; time-of-day is ignored.
+// WhenNextMonday schedules the project for the next Monday after today.
+func (b *addProjectBuilder) WhenNextMonday() ProjectAdder {
+	return SetWhenTime(b, nextWeekday(b.scheme.clock(), time.Monday))
+}
+
+// WhenThisWeekend schedules the project for this Saturday, or today when
+// today is already Saturday or Sunday.
+func (b *addProjectBuilder) WhenThisWeekend() ProjectAdder {
+	return SetWhenTime(b, thisWeekend(b.scheme.clock()))
+}
+
+// WhenInDays schedules the project n days from today.
+func (b *addProjectBuilder) WhenInDays(n int) ProjectAdder {
+	return SetWhenTime(b, inDays(b.scheme.clock(), n))
+}
+
+// Deadline sets the deadline date using a time.Time value. Things deadlines
+// are dates only, so t must be midnight; a time of day fails the build with
+// ErrDeadlineHasTime.
 func (b *addProjectBuilder) Deadline(t time.Time) ProjectAdder {
 	return SetDeadlineTime(b, t)
 }
@@ -273,6 +362,22 @@ func (b *addProjectBuilder) Reveal(reveal bool) ProjectAdder {
 	return SetBool(b, RevealParam, reveal)
 }
 
+// RevealAfterCreate is Reveal(true) for the project.
+func (b *addProjectBuilder) RevealAfterCreate() ProjectAdder {
+	return b.Reveal(true)
+}
+
+// XSuccess sets the x-callback-url Things opens after adding the project,
+// passing the new project's ID as x-things-id.
+func (b *addProjectBuilder) XSuccess(callback string) ProjectAdder {
+	return SetStr(b, XSuccessParam, callback)
+}
+
+// XError sets the x-callback-url Things opens when adding the project fails.
+func (b *addProjectBuilder) XError(callback string) ProjectAdder {
+	return SetStr(b, XErrorParam, callback)
+}
+
 // Reminder sets a reminder time for the project.
 // The reminder is combined with the scheduling date (When).
 // If no scheduling date is set, defaults to "today".
@@ -296,6 +401,12 @@ func (b *addProjectBuilder) CompletionDate(date time.Time) ProjectAdder {
 // Build is pure: it never mutates the builder, so it can be called
 // repeatedly (including via Execute) with identical results.
 func (b *addProjectBuilder) Build() (string, error) {
+	return b.build(nil)
+}
+
+// build returns the URL with extra parameters layered over the builder's
+// own, leaving the builder untouched.
+func (b *addProjectBuilder) build(extra map[string]string) (string, error) {
 	if b.err != nil {
 		return "", b.err
 	}
@@ -304,6 +415,9 @@ func (b *addProjectBuilder) Build() (string, error) {
 	if err != nil {
 		return "", err
 	}
+	for k, v := range extra {
+		query.Set(k, v)
+	}
 
 	return fmt.Sprintf("things:///%s?%s", CommandAddProject, EncodeQuery(query)), nil
 }
@@ -317,3 +431,14 @@ func (b *addProjectBuilder) Execute(ctx context.Context) error {
 	}
 	return b.scheme.Execute(ctx, uri)
 }
+
+// ExecuteAndWait executes the add URL and returns the ID Things reports for
+// the new project. It points the x-success and x-error callbacks at a local
+// listener, overriding XSuccess and XError for this call, and waits for one
+// of them or for ctx to end. When ctx has no deadline the wait is capped at
+// one minute and then fails with ErrCallbackTimeout, since a closed app or a
+// denied request never calls back. Things hands the callback to the default
+// browser, so a browser tab opens on the listener's page.
+func (b *addProjectBuilder) ExecuteAndWait(ctx context.Context) (string, error) {
+	return executeWithCallback(ctx, b.build, b.scheme.Execute)
+}
@@ -127,7 +127,7 @@ func TestLengthLimitsCountCharactersNotBytes(t *testing.T) {
 	})
 
 	t.Run("batch todo title", func(t *testing.T) {
-		item := newBatchTodoBuilder()
+		item := newBatchTodoBuilder(time.Now)
 		item.Title(cjkTitle)
 		require.NoError(t, item.err)
 	})
@@ -184,3 +184,57 @@ func TestSeparatorValidationAllowsCleanValues(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "home,work", parseQuery(t, thingsURL).Get(KeyFilter))
 }
+
+// An unknown "when" value in a batch item must surface at Build instead of
+// being serialized and silently ignored by Things.
+func TestBatchRejectsInvalidWhen(t *testing.T) {
+	s := New()
+
+	_, err := NewBatch(s).
+		AddTodo(func(todo BatchTodoConfigurator) {
+			todo.Title("T")
+			SetWhenStr(todo.(*batchTodoBuilder), When("next week"))
+		}).
+		Build()
+	require.ErrorIs(t, err, ErrInvalidWhen)
+
+	thingsURL, err := NewBatch(s).
+		AddProject(func(p BatchProjectConfigurator) { p.Title("P").WhenSomeday() }).
+		Build()
+	require.NoError(t, err)
+	assert.Contains(t, parseQuery(t, thingsURL).Get(KeyData), `"when":"someday"`)
+}
+
+func TestXCallbackParams(t *testing.T) {
+	s := New()
+	const (
+		success = "shortcuts://x-callback-url/run-shortcut?name=Done&input=text&text=a b"
+		failure = "myapp://failed?reason=things&retry=1"
+	)
+
+	tests := []struct {
+		name    string
+		builder interface{ Build() (string, error) }
+	}{
+		{"todo adder", NewTodoAdder(s).Title("Task").XSuccess(success).XError(failure)},
+		{"project adder", NewProjectAdder(s).Title("Project").XSuccess(success).XError(failure)},
+		{"todo updater", NewTodoUpdater(s, staticTokenFunc("token"), "uuid-1").XSuccess(success).XError(failure)},
+		{"project updater", NewProjectUpdater(s, staticTokenFunc("token"), "uuid-1").XSuccess(success).XError(failure)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uri, err := tt.builder.Build()
+			require.NoError(t, err)
+
+			// The callback's own separators stay escaped, so they cannot
+			// leak into the Things query.
+			assert.Contains(t, uri, "x-success=shortcuts%3A%2F%2Fx-callback-url%2Frun-shortcut%3Fname%3DDone%26input")
+			assert.NotContains(t, uri, "&input=")
+
+			query := parseQuery(t, uri)
+			assert.Equal(t, success, query.Get(KeyXSuccess))
+			assert.Equal(t, failure, query.Get(KeyXError))
+		})
+	}
+}
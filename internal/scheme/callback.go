@@ -0,0 +1,96 @@
+package scheme
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Query keys Things sets on the x-callback-url it opens.
+const (
+	// callbackKeyID carries the IDs of the created items, comma-separated.
+	callbackKeyID = "x-things-id"
+	// callbackKeyErrorMessage carries the reason on the x-error callback.
+	callbackKeyErrorMessage = "errorMessage"
+)
+
+// callbackPage is the body served to the browser that delivered a callback.
+const callbackPage = "Things responded. You can close this tab.\n"
+
+// defaultCallbackTimeout bounds the wait for a callback when the caller's
+// context has no deadline, so a closed app or a denied token cannot block
+// ExecuteAndWait forever. A variable so tests can shorten it.
+var defaultCallbackTimeout = time.Minute
+
+// callbackResult is what one callback request delivered.
+type callbackResult struct {
+	id  string
+	err error
+}
+
+// executeWithCallback serves x-success and x-error on a loopback listener,
+// builds the URL with both pointing at it, runs execute, and returns the
+// first callback's x-things-id. Only the first callback counts; later ones,
+// such as a browser retry, are answered and dropped. Without a deadline on
+// ctx it gives up after defaultCallbackTimeout with ErrCallbackTimeout.
+func executeWithCallback(
+	ctx context.Context,
+	build func(extra map[string]string) (string, error),
+	execute func(context.Context, string) error,
+) (string, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, defaultCallbackTimeout, ErrCallbackTimeout)
+		defer cancel()
+	}
+	var lc net.ListenConfig
+	ln, err := lc.Listen(ctx, "tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", fmt.Errorf("things3: failed to start callback listener: %w", err)
+	}
+
+	results := make(chan callbackResult, 1)
+	deliver := func(r callbackResult) {
+		select {
+		case results <- r:
+		default:
+		}
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/success", func(w http.ResponseWriter, r *http.Request) {
+		deliver(callbackResult{id: r.URL.Query().Get(callbackKeyID)})
+		fmt.Fprint(w, callbackPage)
+	})
+	mux.HandleFunc("/error", func(w http.ResponseWriter, r *http.Request) {
+		err := ErrCallbackFailed
+		if msg := r.URL.Query().Get(callbackKeyErrorMessage); msg != "" {
+			err = fmt.Errorf("%w: %s", ErrCallbackFailed, msg)
+		}
+		deliver(callbackResult{err: err})
+		fmt.Fprint(w, callbackPage)
+	})
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+	go func() { _ = srv.Serve(ln) }()
+	defer srv.Close()
+
+	base := "http://" + ln.Addr().String()
+	uri, err := build(map[string]string{
+		KeyXSuccess: base + "/success",
+		KeyXError:   base + "/error",
+	})
+	if err != nil {
+		return "", err
+	}
+	if err := execute(ctx, uri); err != nil {
+		return "", err
+	}
+
+	select {
+	case r := <-results:
+		return r.id, r.err
+	case <-ctx.Done():
+		return "", context.Cause(ctx)
+	}
+}
@@ -0,0 +1,84 @@
+package scheme
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeThings returns an execute func that answers like Things would, by
+// requesting the callback URL named by key with the given query appended.
+func fakeThings(t *testing.T, key string, query url.Values) func(context.Context, string) error {
+	t.Helper()
+	return func(ctx context.Context, uri string) error {
+		callback := parseQuery(t, uri).Get(key)
+		require.NotEmpty(t, callback, "URL must carry %s", key)
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, callback+"?"+query.Encode(), http.NoBody)
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp.Body.Close()
+	}
+}
+
+func TestExecuteWithCallback(t *testing.T) {
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	t.Run("success returns the new id", func(t *testing.T) {
+		b := NewTodoAdder(New()).Title("Task").(*addTodoBuilder)
+		id, err := executeWithCallback(ctx, b.build, fakeThings(t, KeyXSuccess, url.Values{"x-things-id": {"ABC123"}}))
+		require.NoError(t, err)
+		assert.Equal(t, "ABC123", id)
+
+		// The callbacks were layered on for the call only.
+		uri, err := b.Build()
+		require.NoError(t, err)
+		assert.NotContains(t, uri, KeyXSuccess)
+	})
+
+	t.Run("x-error returns the message", func(t *testing.T) {
+		b := NewProjectAdder(New()).Title("Project").(*addProjectBuilder)
+		_, err := executeWithCallback(ctx, b.build, fakeThings(t, KeyXError, url.Values{"errorMessage": {"no such area"}}))
+		require.ErrorIs(t, err, ErrCallbackFailed)
+		assert.Contains(t, err.Error(), "no such area")
+	})
+
+	t.Run("build error skips execute", func(t *testing.T) {
+		b := NewTodoAdder(New()).Tags("a,b").(*addTodoBuilder)
+		_, err := executeWithCallback(ctx, b.build, func(context.Context, string) error {
+			t.Fatal("execute must not run")
+			return nil
+		})
+		assert.ErrorIs(t, err, ErrTagContainsComma)
+	})
+
+	t.Run("gives up when ctx ends", func(t *testing.T) {
+		short, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		b := NewTodoAdder(New()).Title("Task").(*addTodoBuilder)
+		_, err := executeWithCallback(short, b.build, func(context.Context, string) error { return nil })
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("no deadline falls back to the default timeout", func(t *testing.T) {
+		saved := defaultCallbackTimeout
+		defaultCallbackTimeout = 50 * time.Millisecond
+		defer func() { defaultCallbackTimeout = saved }()
+
+		b := NewTodoAdder(New()).Title("Task").(*addTodoBuilder)
+		var execDeadline bool
+		_, err := executeWithCallback(t.Context(), b.build, func(ctx context.Context, _ string) error {
+			_, execDeadline = ctx.Deadline()
+			return nil
+		})
+		require.ErrorIs(t, err, ErrCallbackTimeout)
+		assert.True(t, execDeadline, "execute must run under the default deadline")
+	})
+}
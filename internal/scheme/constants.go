@@ -76,6 +76,15 @@ const (
 	KeyDuplicate = "duplicate"
 )
 
+// x-callback-url parameters, accepted by every command.
+const (
+	// KeyXSuccess is the URL Things opens when the command succeeds. For add
+	// and add-project it receives the new item's ID as x-things-id.
+	KeyXSuccess = "x-success"
+	// KeyXError is the URL Things opens when the command fails.
+	KeyXError = "x-error"
+)
+
 // Show command parameters.
 const (
 	// KeyQuery searches for area/project/tag by name.
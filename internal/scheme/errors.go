@@ -11,4 +11,23 @@ var (
 	ErrIDRequired = errors.New("things3: id required for update operation")
 	// ErrNoJSONItems is returned when building a JSON URL with no items.
 	ErrNoJSONItems = errors.New("things3: no items provided for JSON operation")
+	// ErrExecuteTimeout is returned when opening a URL takes longer than the
+	// limit set by WithExecuteTimeout.
+	ErrExecuteTimeout = errors.New("things3: URL scheme execution timed out")
+	// ErrCallbackFailed is returned by ExecuteAndWait when Things opens the
+	// x-error callback instead of x-success.
+	ErrCallbackFailed = errors.New("things3: Things reported an error")
+	// ErrCallbackTimeout is returned by ExecuteAndWait when no callback
+	// arrives within a minute and the caller's context set no deadline.
+	ErrCallbackTimeout = errors.New("things3: timed out waiting for the Things callback")
+)
+
+// Parse errors for ParseURL.
+var (
+	// ErrInvalidURL is returned when a URL is malformed, is not a things:
+	// URL, or carries a json data parameter that does not decode.
+	ErrInvalidURL = errors.New("things3: invalid Things URL")
+	// ErrUnknownCommand is returned when a URL names a command the URL
+	// scheme does not define.
+	ErrUnknownCommand = errors.New("things3: unknown URL scheme command")
 )
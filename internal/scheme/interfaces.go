@@ -22,10 +22,14 @@ type TodoAdder interface {
 	Title(title string) TodoAdder
 	Titles(titles ...string) TodoAdder
 	Notes(notes string) TodoAdder
+	AddLink(title, link string) TodoAdder
 	When(t time.Time) TodoAdder
 	WhenEvening() TodoAdder
 	WhenAnytime() TodoAdder
 	WhenSomeday() TodoAdder
+	WhenNextMonday() TodoAdder
+	WhenThisWeekend() TodoAdder
+	WhenInDays(n int) TodoAdder
 	Deadline(t time.Time) TodoAdder
 	Reminder(hour, minute int) TodoAdder
 	Tags(tags ...string) TodoAdder
@@ -38,6 +42,10 @@ type TodoAdder interface {
 	Canceled(canceled bool) TodoAdder
 	ShowQuickEntry(show bool) TodoAdder
 	Reveal(reveal bool) TodoAdder
+	RevealAfterCreate() TodoAdder
+	XSuccess(callback string) TodoAdder
+	XError(callback string) TodoAdder
+	ExecuteAndWait(ctx context.Context) (string, error)
 	CreationDate(date time.Time) TodoAdder
 	CompletionDate(date time.Time) TodoAdder
 }
@@ -52,6 +60,9 @@ type ProjectAdder interface {
 	WhenEvening() ProjectAdder
 	WhenAnytime() ProjectAdder
 	WhenSomeday() ProjectAdder
+	WhenNextMonday() ProjectAdder
+	WhenThisWeekend() ProjectAdder
+	WhenInDays(n int) ProjectAdder
 	Deadline(t time.Time) ProjectAdder
 	Reminder(hour, minute int) ProjectAdder
 	Tags(tags ...string) ProjectAdder
@@ -61,6 +72,10 @@ type ProjectAdder interface {
 	Completed(completed bool) ProjectAdder
 	Canceled(canceled bool) ProjectAdder
 	Reveal(reveal bool) ProjectAdder
+	RevealAfterCreate() ProjectAdder
+	XSuccess(callback string) ProjectAdder
+	XError(callback string) ProjectAdder
+	ExecuteAndWait(ctx context.Context) (string, error)
 	CreationDate(date time.Time) ProjectAdder
 	CompletionDate(date time.Time) ProjectAdder
 }
@@ -77,6 +92,9 @@ type TodoUpdater interface {
 	WhenEvening() TodoUpdater
 	WhenAnytime() TodoUpdater
 	WhenSomeday() TodoUpdater
+	WhenNextMonday() TodoUpdater
+	WhenThisWeekend() TodoUpdater
+	WhenInDays(n int) TodoUpdater
 	Deadline(t time.Time) TodoUpdater
 	ClearDeadline() TodoUpdater
 	Reminder(hour, minute int) TodoUpdater
@@ -93,6 +111,8 @@ type TodoUpdater interface {
 	Canceled(canceled bool) TodoUpdater
 	Duplicate(duplicate bool) TodoUpdater
 	Reveal(reveal bool) TodoUpdater
+	XSuccess(callback string) TodoUpdater
+	XError(callback string) TodoUpdater
 	CreationDate(date time.Time) TodoUpdater
 	CompletionDate(date time.Time) TodoUpdater
 }
@@ -109,6 +129,9 @@ type ProjectUpdater interface {
 	WhenEvening() ProjectUpdater
 	WhenAnytime() ProjectUpdater
 	WhenSomeday() ProjectUpdater
+	WhenNextMonday() ProjectUpdater
+	WhenThisWeekend() ProjectUpdater
+	WhenInDays(n int) ProjectUpdater
 	Deadline(t time.Time) ProjectUpdater
 	ClearDeadline() ProjectUpdater
 	Reminder(hour, minute int) ProjectUpdater
@@ -119,6 +142,8 @@ type ProjectUpdater interface {
 	Completed(completed bool) ProjectUpdater
 	Canceled(canceled bool) ProjectUpdater
 	Reveal(reveal bool) ProjectUpdater
+	XSuccess(callback string) ProjectUpdater
+	XError(callback string) ProjectUpdater
 }
 
 // ShowNavigator builds URLs for navigating to items or lists.
@@ -141,6 +166,7 @@ type BatchCreator interface {
 	AddTodo(configure func(BatchTodoConfigurator)) BatchCreator
 	AddProject(configure func(BatchProjectConfigurator)) BatchCreator
 	Reveal(reveal bool) BatchCreator
+	DefaultArea(name string) BatchCreator
 	Build() (string, error)
 	Execute(ctx context.Context) error
 }
@@ -152,6 +178,7 @@ type AuthBatchCreator interface {
 	UpdateTodo(id string, configure func(BatchTodoConfigurator)) AuthBatchCreator
 	UpdateProject(id string, configure func(BatchProjectConfigurator)) AuthBatchCreator
 	Reveal(reveal bool) AuthBatchCreator
+	DefaultArea(name string) AuthBatchCreator
 	Build() (string, error)
 	Execute(ctx context.Context) error
 }
@@ -166,6 +193,9 @@ type BatchTodoConfigurator interface {
 	WhenEvening() BatchTodoConfigurator
 	WhenAnytime() BatchTodoConfigurator
 	WhenSomeday() BatchTodoConfigurator
+	WhenNextMonday() BatchTodoConfigurator
+	WhenThisWeekend() BatchTodoConfigurator
+	WhenInDays(n int) BatchTodoConfigurator
 	Deadline(t time.Time) BatchTodoConfigurator
 	Tags(tags ...string) BatchTodoConfigurator
 	AddTags(tags ...string) BatchTodoConfigurator
@@ -189,12 +219,17 @@ type BatchProjectConfigurator interface {
 	WhenEvening() BatchProjectConfigurator
 	WhenAnytime() BatchProjectConfigurator
 	WhenSomeday() BatchProjectConfigurator
+	WhenNextMonday() BatchProjectConfigurator
+	WhenThisWeekend() BatchProjectConfigurator
+	WhenInDays(n int) BatchProjectConfigurator
 	Deadline(t time.Time) BatchProjectConfigurator
 	Tags(tags ...string) BatchProjectConfigurator
 	AddTags(tags ...string) BatchProjectConfigurator
 	Area(name string) BatchProjectConfigurator
 	AreaID(id string) BatchProjectConfigurator
 	Todos(configs ...func(BatchTodoConfigurator)) BatchProjectConfigurator
+	AddHeading(title string) BatchProjectConfigurator
+	AddTodo(configure func(BatchTodoConfigurator)) BatchProjectConfigurator
 	Completed(completed bool) BatchProjectConfigurator
 	Canceled(canceled bool) BatchProjectConfigurator
 	CreationDate(date time.Time) BatchProjectConfigurator
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"net/url"
 	"time"
 )
@@ -12,6 +13,7 @@ import (
 type batchTodoBuilder struct {
 	item      JSONItem
 	jsonAttrs JSONAttrs
+	now       func() time.Time
 	err       error
 }
 
@@ -22,7 +24,7 @@ func (t *batchTodoBuilder) GetStore() AttrStore { return &t.jsonAttrs }
 func (t *batchTodoBuilder) SetErr(err error) { t.err = err }
 
 // newBatchTodoBuilder creates a new batchTodoBuilder for create operations.
-func newBatchTodoBuilder() *batchTodoBuilder {
+func newBatchTodoBuilder(now func() time.Time) *batchTodoBuilder {
 	attrs := make(map[string]any)
 	return &batchTodoBuilder{
 		item: JSONItem{
@@ -31,11 +33,12 @@ func newBatchTodoBuilder() *batchTodoBuilder {
 			Attributes: attrs,
 		},
 		jsonAttrs: JSONAttrs{Attrs: attrs},
+		now:       now,
 	}
 }
 
 // newBatchTodoBuilderUpdate creates a new batchTodoBuilder for update operations.
-func newBatchTodoBuilderUpdate(id string) *batchTodoBuilder {
+func newBatchTodoBuilderUpdate(now func() time.Time, id string) *batchTodoBuilder {
 	attrs := make(map[string]any)
 	return &batchTodoBuilder{
 		item: JSONItem{
@@ -45,6 +48,7 @@ func newBatchTodoBuilderUpdate(id string) *batchTodoBuilder {
 			Attributes: attrs,
 		},
 		jsonAttrs: JSONAttrs{Attrs: attrs},
+		now:       now,
 	}
 }
 
@@ -79,8 +83,25 @@ func (t *batchTodoBuilder) WhenSomeday() BatchTodoConfigurator {
 	return SetWhenStr(t, WhenSomeday)
 }
 
-// Deadline sets the deadline date using a time.Time value.
-// The date portion is used; time-of-day is ignored.
+// WhenNextMonday schedules the todo for the next Monday after today.
+func (t *batchTodoBuilder) WhenNextMonday() BatchTodoConfigurator {
+	return SetWhenTime(t, nextWeekday(t.now(), time.Monday))
+}
+
+// WhenThisWeekend schedules the todo for this Saturday, or today when
+// today is already Saturday or Sunday.
+func (t *batchTodoBuilder) WhenThisWeekend() BatchTodoConfigurator {
+	return SetWhenTime(t, thisWeekend(t.now()))
+}
+
+// WhenInDays schedules the todo n days from today.
+func (t *batchTodoBuilder) WhenInDays(n int) BatchTodoConfigurator {
+	return SetWhenTime(t, inDays(t.now(), n))
+}
+
+// Deadline sets the deadline date using a time.Time value. Things deadlines
+// are dates only, so t must be midnight; a time of day fails the build with
+// ErrDeadlineHasTime.
 func (t *batchTodoBuilder) Deadline(tm time.Time) BatchTodoConfigurator {
 	return SetDeadlineTime(t, tm)
 }
@@ -166,6 +187,7 @@ func (t *batchTodoBuilder) build() (JSONItem, error) {
 type batchProjectBuilder struct {
 	item      JSONItem
 	jsonAttrs JSONAttrs
+	now       func() time.Time
 	err       error
 }
 
@@ -176,7 +198,7 @@ func (p *batchProjectBuilder) GetStore() AttrStore { return &p.jsonAttrs }
 func (p *batchProjectBuilder) SetErr(err error) { p.err = err }
 
 // newBatchProjectBuilder creates a new batchProjectBuilder for create operations.
-func newBatchProjectBuilder() *batchProjectBuilder {
+func newBatchProjectBuilder(now func() time.Time) *batchProjectBuilder {
 	attrs := make(map[string]any)
 	return &batchProjectBuilder{
 		item: JSONItem{
@@ -185,11 +207,12 @@ func newBatchProjectBuilder() *batchProjectBuilder {
 			Attributes: attrs,
 		},
 		jsonAttrs: JSONAttrs{Attrs: attrs},
+		now:       now,
 	}
 }
 
 // newBatchProjectBuilderUpdate creates a new batchProjectBuilder for update operations.
-func newBatchProjectBuilderUpdate(id string) *batchProjectBuilder {
+func newBatchProjectBuilderUpdate(now func() time.Time, id string) *batchProjectBuilder {
 	attrs := make(map[string]any)
 	return &batchProjectBuilder{
 		item: JSONItem{
@@ -199,6 +222,7 @@ func newBatchProjectBuilderUpdate(id string) *batchProjectBuilder {
 			Attributes: attrs,
 		},
 		jsonAttrs: JSONAttrs{Attrs: attrs},
+		now:       now,
 	}
 }
 
@@ -233,8 +257,25 @@ func (p *batchProjectBuilder) WhenSomeday() BatchProjectConfigurator {
 	return SetWhenStr(p, WhenSomeday)
 }
 
-// Deadline sets the deadline date using a time.Time value.
-// The date portion is used; time-of-day is ignored.
+// WhenNextMonday schedules the project for the next Monday after today.
+func (p *batchProjectBuilder) WhenNextMonday() BatchProjectConfigurator {
+	return SetWhenTime(p, nextWeekday(p.now(), time.Monday))
+}
+
+// WhenThisWeekend schedules the project for this Saturday, or today when
+// today is already Saturday or Sunday.
+func (p *batchProjectBuilder) WhenThisWeekend() BatchProjectConfigurator {
+	return SetWhenTime(p, thisWeekend(p.now()))
+}
+
+// WhenInDays schedules the project n days from today.
+func (p *batchProjectBuilder) WhenInDays(n int) BatchProjectConfigurator {
+	return SetWhenTime(p, inDays(p.now(), n))
+}
+
+// Deadline sets the deadline date using a time.Time value. Things deadlines
+// are dates only, so t must be midnight; a time of day fails the build with
+// ErrDeadlineHasTime.
 func (p *batchProjectBuilder) Deadline(t time.Time) BatchProjectConfigurator {
 	return SetDeadlineTime(p, t)
 }
@@ -289,22 +330,46 @@ func (p *batchProjectBuilder) AddTags(tags ...string) BatchProjectConfigurator {
 	return SetStrs(p, AddTagsParam, tags)
 }
 
-// Todos sets the child todo items using configuration functions.
+// AddHeading appends a heading to the project's items. Todos appended with
+// AddTodo after it are filed under it, as Things groups a project's items
+// under the heading that precedes them.
+func (p *batchProjectBuilder) AddHeading(title string) BatchProjectConfigurator {
+	return p.appendItem(map[string]any{
+		KeyType:       string(JSONItemTypeHeading),
+		KeyAttributes: map[string]any{KeyTitle: title},
+	})
+}
+
+// AddTodo appends a todo to the project's items, after any headings and
+// todos already added.
+func (p *batchProjectBuilder) AddTodo(configure func(BatchTodoConfigurator)) BatchProjectConfigurator {
+	return p.Todos(configure)
+}
+
+// appendItem adds an entry to the end of the project's items.
+func (p *batchProjectBuilder) appendItem(entry map[string]any) *batchProjectBuilder {
+	items, _ := p.item.Attributes["items"].([]map[string]any)
+	p.item.Attributes["items"] = append(items, entry)
+	return p
+}
+
+// Todos appends child todos in order, after any headings and todos already
+// added, so calls can be interleaved with AddHeading to group todos under
+// headings. A todo that fails to configure stops the list and fails the
+// project.
 func (p *batchProjectBuilder) Todos(configs ...func(BatchTodoConfigurator)) BatchProjectConfigurator {
-	todos := make([]map[string]any, 0, len(configs))
 	for _, configure := range configs {
-		item := newBatchTodoBuilder()
+		item := newBatchTodoBuilder(p.now)
 		configure(item)
 		if item.err != nil {
 			p.err = item.err
 			return p
 		}
-		todos = append(todos, map[string]any{
-			KeyType:       "to-do",
+		p.appendItem(map[string]any{
+			KeyType:       string(JSONItemTypeTodo),
 			KeyAttributes: item.item.Attributes,
 		})
 	}
-	p.item.Attributes["items"] = todos
 	return p
 }
 
@@ -313,13 +378,32 @@ func (p *batchProjectBuilder) build() (JSONItem, error) {
 	return p.item, p.err
 }
 
+// withDefaultArea returns the attributes to serialize for item, adding area
+// when item is a project create without an area of its own. The item's map
+// is copied rather than modified so repeated builds stay independent.
+func withDefaultArea(item JSONItem, area string) map[string]any {
+	if area == "" || item.Type != JSONItemTypeProject || item.Operation != JSONOperationCreate {
+		return item.Attributes
+	}
+	if _, ok := item.Attributes[KeyArea]; ok {
+		return item.Attributes
+	}
+	if _, ok := item.Attributes[KeyAreaID]; ok {
+		return item.Attributes
+	}
+	attrs := maps.Clone(item.Attributes)
+	attrs[KeyArea] = area
+	return attrs
+}
+
 // batchBuilder builds URLs for batch create operations via the json command.
 // Does not support update operations; use authBatchBuilder for updates.
 type batchBuilder struct {
-	scheme *Scheme
-	items  []JSONItem
-	reveal bool
-	err    error
+	scheme      *Scheme
+	items       []JSONItem
+	reveal      bool
+	defaultArea string
+	err         error
 }
 
 // NewBatch creates a new BatchCreator for batch create operations.
@@ -329,7 +413,7 @@ func NewBatch(s *Scheme) BatchCreator {
 
 // AddTodo adds a todo creation to the batch.
 func (b *batchBuilder) AddTodo(configure func(BatchTodoConfigurator)) BatchCreator {
-	item := newBatchTodoBuilder()
+	item := newBatchTodoBuilder(b.scheme.clock)
 	configure(item)
 	built, err := item.build()
 	if err != nil {
@@ -342,7 +426,7 @@ func (b *batchBuilder) AddTodo(configure func(BatchTodoConfigurator)) BatchCreat
 
 // AddProject adds a project creation to the batch.
 func (b *batchBuilder) AddProject(configure func(BatchProjectConfigurator)) BatchCreator {
-	item := newBatchProjectBuilder()
+	item := newBatchProjectBuilder(b.scheme.clock)
 	configure(item)
 	built, err := item.build()
 	if err != nil {
@@ -354,11 +438,22 @@ func (b *batchBuilder) AddProject(configure func(BatchProjectConfigurator)) Batc
 }
 
 // Reveal navigates to the first created item after processing.
+//
+// The json command has no way to target another item: Things always reveals
+// the first entry in the data array. Items are serialized in the order they
+// are added, so to reveal a particular item, add it before the others.
 func (b *batchBuilder) Reveal(reveal bool) BatchCreator {
 	b.reveal = reveal
 	return b
 }
 
+// DefaultArea sets the area name for every created project that sets
+// neither Area nor AreaID. Todos are left alone.
+func (b *batchBuilder) DefaultArea(name string) BatchCreator {
+	b.defaultArea = name
+	return b
+}
+
 // Build returns the Things URL for the JSON batch operation.
 func (b *batchBuilder) Build() (string, error) {
 	if b.err != nil {
@@ -372,7 +467,7 @@ func (b *batchBuilder) Build() (string, error) {
 	for i, item := range b.items {
 		data[i] = map[string]any{
 			KeyType:       string(item.Type),
-			KeyAttributes: item.Attributes,
+			KeyAttributes: withDefaultArea(item, b.defaultArea),
 		}
 	}
 
@@ -403,12 +498,13 @@ func (b *batchBuilder) Execute(ctx context.Context) error {
 // authBatchBuilder builds URLs for batch operations including updates via the json command.
 // Requires authentication token for update operations.
 type authBatchBuilder struct {
-	scheme    *Scheme
-	token     string
-	tokenFunc func(context.Context) (string, error) // Optional lazy token loader
-	items     []JSONItem
-	reveal    bool
-	err       error
+	scheme      *Scheme
+	token       string
+	tokenFunc   func(context.Context) (string, error) // Optional lazy token loader
+	items       []JSONItem
+	reveal      bool
+	defaultArea string
+	err         error
 }
 
 // NewAuthBatch creates a new AuthBatchCreator for batch operations including updates.
@@ -422,7 +518,7 @@ func NewAuthBatch(s *Scheme, tokenFunc func(context.Context) (string, error)) Au
 
 // AddTodo adds a todo creation to the batch.
 func (b *authBatchBuilder) AddTodo(configure func(BatchTodoConfigurator)) AuthBatchCreator {
-	item := newBatchTodoBuilder()
+	item := newBatchTodoBuilder(b.scheme.clock)
 	configure(item)
 	built, err := item.build()
 	if err != nil {
@@ -435,7 +531,7 @@ func (b *authBatchBuilder) AddTodo(configure func(BatchTodoConfigurator)) AuthBa
 
 // AddProject adds a project creation to the batch.
 func (b *authBatchBuilder) AddProject(configure func(BatchProjectConfigurator)) AuthBatchCreator {
-	item := newBatchProjectBuilder()
+	item := newBatchProjectBuilder(b.scheme.clock)
 	configure(item)
 	built, err := item.build()
 	if err != nil {
@@ -448,7 +544,7 @@ func (b *authBatchBuilder) AddProject(configure func(BatchProjectConfigurator))
 
 // UpdateTodo adds a todo update to the batch.
 func (b *authBatchBuilder) UpdateTodo(id string, configure func(BatchTodoConfigurator)) AuthBatchCreator {
-	item := newBatchTodoBuilderUpdate(id)
+	item := newBatchTodoBuilderUpdate(b.scheme.clock, id)
 	configure(item)
 	built, err := item.build()
 	if err != nil {
@@ -461,7 +557,7 @@ func (b *authBatchBuilder) UpdateTodo(id string, configure func(BatchTodoConfigu
 
 // UpdateProject adds a project update to the batch.
 func (b *authBatchBuilder) UpdateProject(id string, configure func(BatchProjectConfigurator)) AuthBatchCreator {
-	item := newBatchProjectBuilderUpdate(id)
+	item := newBatchProjectBuilderUpdate(b.scheme.clock, id)
 	configure(item)
 	built, err := item.build()
 	if err != nil {
@@ -473,11 +569,21 @@ func (b *authBatchBuilder) UpdateProject(id string, configure func(BatchProjectC
 }
 
 // Reveal navigates to the first item after processing.
+// As with batchBuilder.Reveal, only the first entry can be revealed; order
+// the calls so the item to open comes first.
 func (b *authBatchBuilder) Reveal(reveal bool) AuthBatchCreator {
 	b.reveal = reveal
 	return b
 }
 
+// DefaultArea sets the area name for every created project that sets
+// neither Area nor AreaID. Todos and project updates are left alone, so an
+// update never moves a project it does not mention an area for.
+func (b *authBatchBuilder) DefaultArea(name string) AuthBatchCreator {
+	b.defaultArea = name
+	return b
+}
+
 // hasUpdates reports whether any item in the batch is an update operation.
 func (b *authBatchBuilder) hasUpdates() bool {
 	for _, item := range b.items {
@@ -511,7 +617,7 @@ func (b *authBatchBuilder) build(ctx context.Context) (string, error) {
 	for i, item := range b.items {
 		entry := map[string]any{
 			KeyType:       string(item.Type),
-			KeyAttributes: item.Attributes,
+			KeyAttributes: withDefaultArea(item, b.defaultArea),
 		}
 		if item.Operation == JSONOperationUpdate {
 			entry["operation"] = "update"
@@ -1,5 +1,7 @@
 package scheme
 
+import "time"
+
 // Option configures Scheme behavior.
 type Option func(*Scheme)
 
@@ -16,3 +18,27 @@ func WithBackground() Option {
 		s.background = true
 	}
 }
+
+// WithClock sets the clock used to resolve relative scheduling helpers.
+func WithClock(now func() time.Time) Option {
+	return func(s *Scheme) {
+		s.now = now
+	}
+}
+
+// WithOpenMethod fixes the command used for every URL, overriding the
+// per-operation choice made by WithForeground and WithBackground.
+func WithOpenMethod(method OpenMethod) Option {
+	return func(s *Scheme) {
+		s.openMethod = method
+	}
+}
+
+// WithExecuteTimeout bounds how long opening a URL may take. A command still
+// running after d is killed and reported as ErrExecuteTimeout. Zero, the
+// default, leaves the command bounded only by the caller's context.
+func WithExecuteTimeout(d time.Duration) Option {
+	return func(s *Scheme) {
+		s.timeout = d
+	}
+}
@@ -0,0 +1,59 @@
+package scheme
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// ParsedCommand is a Things URL decoded back into its parts, the inverse of
+// a builder's Build.
+type ParsedCommand struct {
+	// Command is the URL scheme command, such as CommandAdd or CommandJSON.
+	Command Command
+	// Params holds the percent-decoded query parameters, data included.
+	Params url.Values
+	// Items holds the decoded data payload of a json command; nil otherwise.
+	Items []JSONItem
+}
+
+// knownCommands lists the commands ParseURL accepts.
+var knownCommands = []Command{
+	CommandShow, CommandAdd, CommandAddProject, CommandUpdate,
+	CommandUpdateProject, CommandSearch, CommandVersion, CommandJSON,
+}
+
+// ParseURL decodes a things:/// URL into its command and parameters. The
+// command is taken from the path, so "things:///add?..." and the host form
+// "things://add?..." both parse. For the json command the data parameter is
+// also decoded into Items.
+func ParseURL(raw string) (*ParsedCommand, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidURL, err)
+	}
+	if u.Scheme != "things" {
+		return nil, fmt.Errorf("%w: scheme %q", ErrInvalidURL, u.Scheme)
+	}
+
+	name := strings.Trim(u.Host+u.Path, "/")
+	cmd := Command(name)
+	if !slices.Contains(knownCommands, cmd) {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownCommand, name)
+	}
+
+	query, err := url.ParseQuery(u.RawQuery)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidURL, err)
+	}
+	parsed := &ParsedCommand{Command: cmd, Params: query}
+
+	if cmd == CommandJSON {
+		if err := json.Unmarshal([]byte(query.Get(KeyData)), &parsed.Items); err != nil {
+			return nil, fmt.Errorf("%w: data: %w", ErrInvalidURL, err)
+		}
+	}
+	return parsed, nil
+}
@@ -0,0 +1,93 @@
+package scheme
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseURLRoundTrip(t *testing.T) {
+	s := New()
+
+	t.Run("add", func(t *testing.T) {
+		uri, err := NewTodoAdder(s).Title("Buy milk & eggs").Notes("2% + whole").Tags("Errand", "Home").Build()
+		require.NoError(t, err)
+
+		parsed, err := ParseURL(uri)
+		require.NoError(t, err)
+		assert.Equal(t, CommandAdd, parsed.Command)
+		assert.Equal(t, "Buy milk & eggs", parsed.Params.Get(KeyTitle))
+		assert.Equal(t, "2% + whole", parsed.Params.Get(KeyNotes))
+		assert.Equal(t, "Errand,Home", parsed.Params.Get(KeyTags))
+		assert.Nil(t, parsed.Items)
+	})
+
+	t.Run("add-project", func(t *testing.T) {
+		uri, err := NewProjectAdder(s).Title("Launch").Area("Work").Todos("One", "Two").Build()
+		require.NoError(t, err)
+
+		parsed, err := ParseURL(uri)
+		require.NoError(t, err)
+		assert.Equal(t, CommandAddProject, parsed.Command)
+		assert.Equal(t, "Launch", parsed.Params.Get(KeyTitle))
+		assert.Equal(t, "Work", parsed.Params.Get(KeyArea))
+		assert.Equal(t, "One\nTwo", parsed.Params.Get(KeyTodos))
+	})
+
+	t.Run("update", func(t *testing.T) {
+		uri, err := NewTodoUpdater(s, staticTokenFunc("test-token"), "uuid-1").Completed(true).Build()
+		require.NoError(t, err)
+
+		parsed, err := ParseURL(uri)
+		require.NoError(t, err)
+		assert.Equal(t, CommandUpdate, parsed.Command)
+		assert.Equal(t, "uuid-1", parsed.Params.Get(KeyID))
+		assert.Equal(t, "test-token", parsed.Params.Get(KeyAuthToken))
+		assert.Equal(t, "true", parsed.Params.Get(KeyCompleted))
+	})
+
+	t.Run("json", func(t *testing.T) {
+		uri, err := NewAuthBatch(s, staticTokenFunc("test-token")).
+			AddTodo(func(todo BatchTodoConfigurator) { todo.Title("New & shiny") }).
+			UpdateTodo("uuid-2", func(todo BatchTodoConfigurator) { todo.Completed(true) }).
+			Build()
+		require.NoError(t, err)
+
+		parsed, err := ParseURL(uri)
+		require.NoError(t, err)
+		assert.Equal(t, CommandJSON, parsed.Command)
+		require.Len(t, parsed.Items, 2)
+		assert.Equal(t, JSONItemTypeTodo, parsed.Items[0].Type)
+		assert.Equal(t, "New & shiny", parsed.Items[0].Attributes[KeyTitle])
+		assert.Equal(t, JSONOperationUpdate, parsed.Items[1].Operation)
+		assert.Equal(t, "uuid-2", parsed.Items[1].ID)
+		assert.Equal(t, true, parsed.Items[1].Attributes[KeyCompleted])
+	})
+}
+
+func TestParseURLErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want error
+	}{
+		{"unknown command", "things:///delete?id=1", ErrUnknownCommand},
+		{"empty command", "things:///?title=x", ErrUnknownCommand},
+		{"other scheme", "https://example.com/add?title=x", ErrInvalidURL},
+		{"bad escape", "things:///add?title=%zz", ErrInvalidURL},
+		{"json without data", "things:///json", ErrInvalidURL},
+		{"json with bad data", "things:///json?data=%5Bnot-json", ErrInvalidURL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseURL(tt.raw)
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+
+	parsed, err := ParseURL("things://show?id=today")
+	require.NoError(t, err, "host form names the command too")
+	assert.Equal(t, CommandShow, parsed.Command)
+}
@@ -3,15 +3,20 @@ package scheme
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"os/exec"
 	"strings"
+	"time"
 )
 
 // Scheme provides URL scheme execution for Things 3.
 type Scheme struct {
-	foreground bool // For create/update operations: if true, bring Things to foreground
-	background bool // For navigation operations: if true, run in background
+	foreground bool             // For create/update operations: if true, bring Things to foreground
+	background bool             // For navigation operations: if true, run in background
+	now        func() time.Time // Clock for relative scheduling (WhenNextMonday, WhenInDays, ...)
+	openMethod OpenMethod       // Command used to open URLs; OpenMethodAuto decides per operation
+	timeout    time.Duration    // Limit on a single URL open; zero means none
 }
 
 // New creates a new Scheme with the given options.
@@ -23,6 +28,15 @@ func New(opts ...Option) *Scheme {
 	return s
 }
 
+// clock returns the current time from the configured clock, defaulting to
+// time.Now.
+func (s *Scheme) clock() time.Time {
+	if s.now == nil {
+		return time.Now()
+	}
+	return s.now()
+}
+
 // wrapExecError combines a command failure with its captured stderr output,
 // so causes like AppleEvents permission denials remain distinguishable from
 // malformed URLs. Returns nil when err is nil; the original error stays
@@ -46,18 +60,59 @@ func run(cmd *exec.Cmd) error {
 
 // Execute opens a Things URL scheme for create/update operations.
 func (s *Scheme) Execute(ctx context.Context, uri string) error {
-	if s.foreground {
-		return run(exec.CommandContext(ctx, "open", uri))
-	}
-	script := fmt.Sprintf(`tell application "Things3" to open location %q`, uri)
-	return run(exec.CommandContext(ctx, "osascript", "-e", script))
+	return s.runTimed(ctx, func(ctx context.Context) *exec.Cmd {
+		return s.command(ctx, uri, s.foreground)
+	})
 }
 
 // ExecuteNavigation opens a Things URL scheme for navigation operations.
 func (s *Scheme) ExecuteNavigation(ctx context.Context, uri string) error {
-	if !s.background {
-		return run(exec.CommandContext(ctx, "open", uri))
+	return s.runTimed(ctx, func(ctx context.Context) *exec.Cmd {
+		return s.command(ctx, uri, !s.background)
+	})
+}
+
+// runTimed runs the command built by newCmd under the configured timeout.
+// When the timeout fires, the error wraps ErrExecuteTimeout alongside the
+// command's own failure.
+func (s *Scheme) runTimed(ctx context.Context, newCmd func(context.Context) *exec.Cmd) error {
+	if s.timeout <= 0 {
+		return run(newCmd(ctx))
+	}
+
+	ctx, cancel := context.WithTimeoutCause(ctx, s.timeout, ErrExecuteTimeout)
+	defer cancel()
+
+	cmd := newCmd(ctx)
+	// Do not wait indefinitely on stderr held open by a killed command's
+	// children.
+	cmd.WaitDelay = time.Second
+	err := run(cmd)
+	if err != nil && errors.Is(context.Cause(ctx), ErrExecuteTimeout) {
+		return fmt.Errorf("%w after %s: %w", ErrExecuteTimeout, s.timeout, err)
+	}
+	return err
+}
+
+// command builds the command that opens uri. Under OpenMethodAuto,
+// foreground selects between `open` and `open -g`; any other method is used
+// as configured.
+func (s *Scheme) command(ctx context.Context, uri string, foreground bool) *exec.Cmd {
+	method := s.openMethod
+	if method == OpenMethodAuto {
+		method = OpenMethodBackground
+		if foreground {
+			method = OpenMethodForeground
+		}
+	}
+
+	switch method {
+	case OpenMethodForeground:
+		return exec.CommandContext(ctx, "open", uri)
+	case OpenMethodBackground:
+		return exec.CommandContext(ctx, "open", "-g", uri)
+	default:
+		script := fmt.Sprintf(`tell application "Things3" to open location %q`, uri)
+		return exec.CommandContext(ctx, "osascript", "-e", script)
 	}
-	script := fmt.Sprintf(`tell application "Things3" to open location %q`, uri)
-	return run(exec.CommandContext(ctx, "osascript", "-e", script))
 }
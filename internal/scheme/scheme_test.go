@@ -1,8 +1,11 @@
 package scheme
 
 import (
+	"context"
 	"errors"
+	"os/exec"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -47,3 +50,86 @@ func TestWrapExecError(t *testing.T) {
 		})
 	}
 }
+
+func TestSchemeCommand(t *testing.T) {
+	const uri = "things:///add?title=Test"
+	script := []string{"osascript", "-e", `tell application "Things3" to open location "` + uri + `"`}
+
+	tests := []struct {
+		name         string
+		opts         []Option
+		wantWrite    []string
+		wantNavigate []string
+	}{
+		{
+			name:         "defaults",
+			wantWrite:    []string{"open", "-g", uri},
+			wantNavigate: []string{"open", uri},
+		},
+		{
+			name:         "foreground writes, background navigation",
+			opts:         []Option{WithForeground(), WithBackground()},
+			wantWrite:    []string{"open", uri},
+			wantNavigate: []string{"open", "-g", uri},
+		},
+		{
+			name:         "open -g everywhere",
+			opts:         []Option{WithOpenMethod(OpenMethodBackground), WithForeground()},
+			wantWrite:    []string{"open", "-g", uri},
+			wantNavigate: []string{"open", "-g", uri},
+		},
+		{
+			name:         "script everywhere",
+			opts:         []Option{WithOpenMethod(OpenMethodScript)},
+			wantWrite:    script,
+			wantNavigate: script,
+		},
+		{
+			name:         "open everywhere",
+			opts:         []Option{WithOpenMethod(OpenMethodForeground), WithBackground()},
+			wantWrite:    []string{"open", uri},
+			wantNavigate: []string{"open", uri},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(tt.opts...)
+			assert.Equal(t, tt.wantWrite, s.command(t.Context(), uri, s.foreground).Args)
+			assert.Equal(t, tt.wantNavigate, s.command(t.Context(), uri, !s.background).Args)
+		})
+	}
+}
+
+func TestSchemeRunTimed(t *testing.T) {
+	sleep := func(ctx context.Context) *exec.Cmd { return exec.CommandContext(ctx, "sleep", "5") }
+	fail := func(ctx context.Context) *exec.Cmd {
+		return exec.CommandContext(ctx, "sh", "-c", "echo boom >&2; exit 3")
+	}
+
+	t.Run("times out", func(t *testing.T) {
+		s := New(WithExecuteTimeout(50 * time.Millisecond))
+		start := time.Now()
+		err := s.runTimed(t.Context(), sleep)
+		require.ErrorIs(t, err, ErrExecuteTimeout)
+		assert.Contains(t, err.Error(), "50ms")
+		assert.Less(t, time.Since(start), 3*time.Second)
+	})
+
+	t.Run("failure keeps stderr and is not a timeout", func(t *testing.T) {
+		s := New(WithExecuteTimeout(5 * time.Second))
+		err := s.runTimed(t.Context(), fail)
+		require.Error(t, err)
+		require.NotErrorIs(t, err, ErrExecuteTimeout)
+		assert.Contains(t, err.Error(), "boom")
+	})
+
+	t.Run("caller cancellation is not a timeout", func(t *testing.T) {
+		s := New(WithExecuteTimeout(5 * time.Second))
+		ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+		defer cancel()
+		err := s.runTimed(ctx, sleep)
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, ErrExecuteTimeout)
+	})
+}
@@ -19,7 +19,9 @@ func NewShowNavigator(s *Scheme) ShowNavigator {
 	return &showBuilder{scheme: s, params: make(map[string]string)}
 }
 
-// ID sets the target item UUID or built-in list ID.
+// ID sets the target UUID or built-in list ID. Things resolves the UUID of a
+// to-do, project, heading, area, or tag alike, so an area UUID opens that
+// area's view in the sidebar without a separate method.
 func (b *showBuilder) ID(id string) ShowNavigator {
 	b.params[KeyID] = id
 	return b
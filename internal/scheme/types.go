@@ -27,6 +27,24 @@ func (c Command) String() string {
 	return string(c)
 }
 
+// OpenMethod selects the command that hands a URL to Things.
+type OpenMethod int
+
+const (
+	// OpenMethodAuto picks per operation between `open -g` and `open`:
+	// create/update URLs stay in the background unless foreground execution
+	// is enabled, and navigation URLs come to the foreground unless
+	// background navigation is enabled.
+	OpenMethodAuto OpenMethod = iota
+	// OpenMethodForeground runs `open`, bringing Things to the foreground.
+	OpenMethodForeground
+	// OpenMethodBackground runs `open -g`, leaving Things in the background.
+	OpenMethodBackground
+	// OpenMethodScript runs osascript to tell Things to open the URL, which
+	// keeps focus on setups where `open -g` still activates the app.
+	OpenMethodScript
+)
+
 // ListID represents built-in Things list identifiers for the show command.
 type ListID string
 
@@ -78,6 +96,8 @@ const (
 	JSONItemTypeTodo JSONItemType = "to-do"
 	// JSONItemTypeProject represents a project item.
 	JSONItemTypeProject JSONItemType = "project"
+	// JSONItemTypeHeading represents a heading inside a project's items.
+	JSONItemTypeHeading JSONItemType = "heading"
 )
 
 // JSONItem represents a single item in a JSON batch operation.
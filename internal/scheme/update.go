@@ -104,10 +104,28 @@ func (b *updateTodoBuilder) WhenAnytime() TodoUpdater {
 }
 
 // WhenSomeday schedules the todo for someday (indefinite future).
+// Things clears the start date when it moves an item to Someday, so
+// when=someday is all the update needs; it replaces any earlier When.
 func (b *updateTodoBuilder) WhenSomeday() TodoUpdater {
 	return SetWhenStr(b, WhenSomeday)
 }
 
+// WhenNextMonday schedules the todo for the next Monday after today.
+func (b *updateTodoBuilder) WhenNextMonday() TodoUpdater {
+	return SetWhenTime(b, nextWeekday(b.scheme.clock(), time.Monday))
+}
+
+// WhenThisWeekend schedules the todo for this Saturday, or today when
+// today is already Saturday or Sunday.
+func (b *updateTodoBuilder) WhenThisWeekend() TodoUpdater {
+	return SetWhenTime(b, thisWeekend(b.scheme.clock()))
+}
+
+// WhenInDays schedules the todo n days from today.
+func (b *updateTodoBuilder) WhenInDays(n int) TodoUpdater {
+	return SetWhenTime(b, inDays(b.scheme.clock(), n))
+}
+
 // Reminder sets a reminder time for the todo.
 // The reminder is combined with the scheduling date (When).
 // If no scheduling date is set, defaults to "today".
@@ -117,8 +135,9 @@ func (b *updateTodoBuilder) Reminder(hour, minute int) TodoUpdater {
 	return SetReminder(b, hour, minute)
 }
 
-// Deadline sets the deadline date using a time.Time value.
-// The date portion is used; time-of-day is ignored.
+// Deadline sets the deadline date using a time.Time value. Things deadlines
+// are dates only, so t must be midnight; a time of day fails the build with
+// ErrDeadlineHasTime.
 func (b *updateTodoBuilder) Deadline(t time.Time) TodoUpdater {
 	return SetDeadlineTime(b, t)
 }
@@ -194,6 +213,17 @@ func (b *updateTodoBuilder) Reveal(reveal bool) TodoUpdater {
 	return SetBool(b, RevealParam, reveal)
 }
 
+// XSuccess sets the x-callback-url Things opens once the update is applied.
+func (b *updateTodoBuilder) XSuccess(callback string) TodoUpdater {
+	return SetStr(b, XSuccessParam, callback)
+}
+
+// XError sets the x-callback-url Things opens when the update fails, for
+// example because the auth token was rejected.
+func (b *updateTodoBuilder) XError(callback string) TodoUpdater {
+	return SetStr(b, XErrorParam, callback)
+}
+
 // CreationDate sets the creation timestamp.
 func (b *updateTodoBuilder) CreationDate(date time.Time) TodoUpdater {
 	return SetTime(b, CreationDateParam, date)
@@ -309,10 +339,28 @@ func (b *updateProjectBuilder) WhenAnytime() ProjectUpdater {
 }
 
 // WhenSomeday schedules the project for someday (indefinite future).
+// Things clears the start date when it moves an item to Someday, so
+// when=someday is all the update needs; it replaces any earlier When.
 func (b *updateProjectBuilder) WhenSomeday() ProjectUpdater {
 	return SetWhenStr(b, WhenSomeday)
 }
 
+// WhenNextMonday schedules the project for the next Monday after today.
+func (b *updateProjectBuilder) WhenNextMonday() ProjectUpdater {
+	return SetWhenTime(b, nextWeekday(b.scheme.clock(), time.Monday))
+}
+
+// WhenThisWeekend schedules the project for this Saturday, or today when
+// today is already Saturday or Sunday.
+func (b *updateProjectBuilder) WhenThisWeekend() ProjectUpdater {
+	return SetWhenTime(b, thisWeekend(b.scheme.clock()))
+}
+
+// WhenInDays schedules the project n days from today.
+func (b *updateProjectBuilder) WhenInDays(n int) ProjectUpdater {
+	return SetWhenTime(b, inDays(b.scheme.clock(), n))
+}
+
 // Reminder sets a reminder time for the project.
 // The reminder is combined with the scheduling date (When).
 // If no scheduling date is set, defaults to "today".
@@ -322,8 +370,9 @@ func (b *updateProjectBuilder) Reminder(hour, minute int) ProjectUpdater {
 	return SetReminder(b, hour, minute)
 }
 
-// Deadline sets the deadline date using a time.Time value.
-// The date portion is used; time-of-day is ignored.
+// Deadline sets the deadline date using a time.Time value. Things deadlines
+// are dates only, so t must be midnight; a time of day fails the build with
+// ErrDeadlineHasTime.
 func (b *updateProjectBuilder) Deadline(t time.Time) ProjectUpdater {
 	return SetDeadlineTime(b, t)
 }
@@ -371,6 +420,16 @@ func (b *updateProjectBuilder) Reveal(reveal bool) ProjectUpdater {
 	return SetBool(b, RevealParam, reveal)
 }
 
+// XSuccess sets the x-callback-url Things opens once the update is applied.
+func (b *updateProjectBuilder) XSuccess(callback string) ProjectUpdater {
+	return SetStr(b, XSuccessParam, callback)
+}
+
+// XError sets the x-callback-url Things opens when the update fails.
+func (b *updateProjectBuilder) XError(callback string) ProjectUpdater {
+	return SetStr(b, XErrorParam, callback)
+}
+
 // validate checks all builder requirements before building the URL.
 func (b *updateProjectBuilder) validate() error {
 	if b.err != nil {
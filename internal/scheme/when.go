@@ -0,0 +1,29 @@
+package scheme
+
+import "time"
+
+// nextWeekday returns the first date strictly after now that falls on day,
+// so asking for Monday on a Monday yields the following week.
+func nextWeekday(now time.Time, day time.Weekday) time.Time {
+	days := (int(day) - int(now.Weekday()) + 7) % 7
+	if days == 0 {
+		days = 7
+	}
+	return now.AddDate(0, 0, days)
+}
+
+// thisWeekend returns the Saturday of the current week, or today when today
+// is already Saturday or Sunday.
+func thisWeekend(now time.Time) time.Time {
+	switch now.Weekday() {
+	case time.Saturday, time.Sunday:
+		return now
+	default:
+		return nextWeekday(now, time.Saturday)
+	}
+}
+
+// inDays returns the date n days after now; negative n counts backwards.
+func inDays(now time.Time, n int) time.Time {
+	return now.AddDate(0, 0, n)
+}
@@ -0,0 +1,81 @@
+package scheme
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelativeWhenDates(t *testing.T) {
+	// 2025-06-11 is a Wednesday.
+	wednesday := time.Date(2025, 6, 11, 15, 0, 0, 0, time.Local)
+	monday := time.Date(2025, 6, 9, 9, 0, 0, 0, time.Local)
+	saturday := time.Date(2025, 6, 14, 9, 0, 0, 0, time.Local)
+	sunday := time.Date(2025, 6, 15, 9, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name string
+		got  time.Time
+		want string
+	}{
+		{"next monday from wednesday", nextWeekday(wednesday, time.Monday), "2025-06-16"},
+		{"next monday from monday skips a week", nextWeekday(monday, time.Monday), "2025-06-16"},
+		{"next monday from sunday", nextWeekday(sunday, time.Monday), "2025-06-16"},
+		{"weekend from wednesday", thisWeekend(wednesday), "2025-06-14"},
+		{"weekend on saturday", thisWeekend(saturday), "2025-06-14"},
+		{"weekend on sunday", thisWeekend(sunday), "2025-06-15"},
+		{"in 3 days", inDays(wednesday, 3), "2025-06-14"},
+		{"in 0 days", inDays(wednesday, 0), "2025-06-11"},
+		{"across month end", inDays(wednesday, 20), "2025-07-01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.got.Format(time.DateOnly))
+		})
+	}
+}
+
+// Every builder resolves relative helpers against the scheme clock.
+func TestRelativeWhenUsesSchemeClock(t *testing.T) {
+	wednesday := time.Date(2025, 6, 11, 15, 0, 0, 0, time.Local)
+	s := New(WithClock(func() time.Time { return wednesday }))
+	token := staticTokenFunc("token")
+
+	tests := []struct {
+		name    string
+		builder interface{ Build() (string, error) }
+		want    string
+	}{
+		{"todo adder next monday", NewTodoAdder(s).Title("T").WhenNextMonday(), "2025-06-16"},
+		{"project adder weekend", NewProjectAdder(s).Title("P").WhenThisWeekend(), "2025-06-14"},
+		{"todo updater in days", NewTodoUpdater(s, token, "id").WhenInDays(2), "2025-06-13"},
+		{"project updater next monday", NewProjectUpdater(s, token, "id").WhenNextMonday(), "2025-06-16"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			thingsURL, err := tt.builder.Build()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, parseQuery(t, thingsURL).Get(KeyWhen))
+		})
+	}
+
+	t.Run("batch items", func(t *testing.T) {
+		thingsURL, err := NewAuthBatch(s, token).
+			AddTodo(func(b BatchTodoConfigurator) { b.Title("T").WhenThisWeekend() }).
+			UpdateProject("id", func(b BatchProjectConfigurator) { b.WhenInDays(1) }).
+			AddProject(func(b BatchProjectConfigurator) {
+				b.Title("P").Todos(func(c BatchTodoConfigurator) { c.Title("C").WhenNextMonday() })
+			}).
+			Build()
+		require.NoError(t, err)
+
+		data := parseQuery(t, thingsURL).Get(KeyData)
+		assert.Contains(t, data, `"when":"2025-06-14"`)
+		assert.Contains(t, data, `"when":"2025-06-12"`)
+		assert.Contains(t, data, `"when":"2025-06-16"`)
+	})
+}
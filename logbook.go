@@ -0,0 +1,76 @@
+package things3
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// LogbookByMonth returns the completed and canceled todos of a year, bucketed
+// by the local month of their stop date. The year window is applied in SQL,
+// so only that year's logbook is loaded. Within each month, todos are ordered
+// most recently closed first, as in the Things Logbook. Months without closed
+// todos are absent from the map; the map itself is never nil. Under
+// WithSkipCorruptRows the readable todos are bucketed and returned with a
+// *CorruptRowsError.
+func (c *Client) LogbookByMonth(ctx context.Context, year int) (map[time.Month][]Todo, error) {
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local)
+	until := from.AddDate(1, 0, 0)
+
+	var skipped skippedRows
+	todos, err := c.database.Todos().
+		stoppedBetween(from, until).
+		All(ctx)
+	if err := skipped.add(err); err != nil {
+		return nil, err
+	}
+
+	slices.SortStableFunc(todos, func(a, b Todo) int {
+		return stopTime(&b).Compare(stopTime(&a))
+	})
+
+	months := make(map[time.Month][]Todo)
+	for i := range todos {
+		month := stopTime(&todos[i]).In(time.Local).Month()
+		months[month] = append(months[month], todos[i])
+	}
+	return months, skipped.result()
+}
+
+// RecentlyCompleted returns the limit most recently completed or canceled
+// todos, newest first. Ordering and limit apply in SQL, so only those rows
+// are loaded. A limit of zero or less returns an empty result; the result is
+// never nil.
+func (c *Client) RecentlyCompleted(ctx context.Context, limit int) ([]Todo, error) {
+	if limit <= 0 {
+		return []Todo{}, nil
+	}
+	return c.database.Todos().
+		orderByStopDate(true).
+		StopDate().Exists(true).
+		Limit(limit).
+		All(ctx)
+}
+
+// LoggedProjects returns the completed and canceled projects, most recently
+// closed first, as listed in the Things Logged Projects view. The result is
+// never nil.
+func (c *Client) LoggedProjects(ctx context.Context) ([]Project, error) {
+	return c.database.Projects().
+		orderByStopDate(true).
+		StopDate().Exists(true).
+		All(ctx)
+}
+
+// stopTime returns when the todo was closed: its completion or cancellation
+// time, or the zero time for an open todo.
+func stopTime(t *Todo) time.Time {
+	switch {
+	case t.CompletedAt != nil:
+		return *t.CompletedAt
+	case t.CanceledAt != nil:
+		return *t.CanceledAt
+	default:
+		return time.Time{}
+	}
+}
@@ -0,0 +1,81 @@
+package things3
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientLogbookByMonth(t *testing.T) {
+	client := newTestClient(t)
+	ctx := t.Context()
+
+	t.Run("buckets by stop month, newest first", func(t *testing.T) {
+		months, err := client.LogbookByMonth(ctx, 2024)
+		require.NoError(t, err)
+		require.Len(t, months, 1)
+		june := months[time.June]
+		require.Len(t, june, 2)
+		assert.Equal(t, "JM91cry5BMFP7R3vXDns9z", june[0].UUID, "closed after midnight sorts first")
+		assert.Equal(t, "LnGwkFDZw78ydwp98jqo3z", june[1].UUID)
+	})
+
+	t.Run("includes completed and canceled, excludes trashed", func(t *testing.T) {
+		months, err := client.LogbookByMonth(ctx, 2021)
+		require.NoError(t, err)
+		require.Len(t, months, 1)
+		march := months[time.March]
+		require.NotEmpty(t, march)
+
+		statuses := map[Status]bool{}
+		for i := range march {
+			statuses[march[i].Status] = true
+			assert.True(t, march[i].Status.IsClosed(), "todo %q is not closed", march[i].UUID)
+			assert.False(t, march[i].Trashed, "todo %q is trashed", march[i].UUID)
+		}
+		assert.True(t, statuses[StatusCompleted])
+		assert.True(t, statuses[StatusCanceled])
+	})
+
+	t.Run("empty year", func(t *testing.T) {
+		months, err := client.LogbookByMonth(ctx, 1999)
+		require.NoError(t, err)
+		assert.NotNil(t, months)
+		assert.Empty(t, months)
+	})
+}
+
+func TestClientLoggedProjects(t *testing.T) {
+	client := newTestClient(t)
+
+	projects, err := client.LoggedProjects(t.Context())
+	require.NoError(t, err)
+	require.Len(t, projects, 2)
+	assert.Equal(t, "SkLdfSe1MXR5vMV1gMYkHE", projects[0].UUID, "canceled most recently")
+	assert.Equal(t, StatusCanceled, projects[0].Status)
+	assert.Equal(t, "CmpltdProjTestFixture01", projects[1].UUID)
+	assert.Equal(t, StatusCompleted, projects[1].Status)
+}
+
+func TestClientRecentlyCompleted(t *testing.T) {
+	client := newTestClient(t)
+	ctx := t.Context()
+
+	todos, err := client.RecentlyCompleted(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"JM91cry5BMFP7R3vXDns9z", "LnGwkFDZw78ydwp98jqo3z"}, extractTodoUUIDs(todos))
+
+	all, err := client.RecentlyCompleted(ctx, 1000)
+	require.NoError(t, err)
+	require.Greater(t, len(all), 2)
+	for i := 1; i < len(all); i++ {
+		assert.False(t, stopTime(&all[i]).After(stopTime(&all[i-1])), "newest first")
+	}
+
+	none, err := client.RecentlyCompleted(ctx, 0)
+	require.NoError(t, err)
+	assert.NotNil(t, none)
+	assert.Empty(t, none)
+}
@@ -0,0 +1,80 @@
+package things3
+
+import (
+	"context"
+
+	"github.com/moond4rk/things3/internal/database"
+)
+
+// GetMany resolves a batch of UUIDs of unknown kind. Each found UUID maps to
+// a *Todo, *Project, *Heading, *Area or *Tag; unknown and trashed UUIDs have
+// no entry. It runs one task query, one area query and one tag scan however
+// many UUIDs are given, plus batched tag lookups for tasks and one per
+// tagged area.
+// Checklists are not loaded. Under WithSkipCorruptRows unreadable tasks have
+// no entry either, and the map is returned with a *CorruptRowsError.
+func (c *Client) GetMany(ctx context.Context, uuids []string) (map[string]any, error) {
+	found := make(map[string]any, len(uuids))
+	if len(uuids) == 0 {
+		return found, nil
+	}
+
+	var skipped skippedRows
+	rows, err := c.database.inner.QueryTasks(ctx, &database.TaskFilter{
+		Index: database.IndexDefault,
+		UUIDs: uuids,
+	})
+	if err := skipped.add(err); err != nil {
+		return nil, err
+	}
+	var todoRows, projectRows []database.TaskRow
+	for i := range rows {
+		switch rows[i].Type {
+		case "to-do":
+			todoRows = append(todoRows, rows[i])
+		case "project":
+			projectRows = append(projectRows, rows[i])
+		case "heading":
+			heading := convertTaskRowToHeading(&rows[i])
+			found[rows[i].UUID] = &heading
+		}
+	}
+	todos, err := c.database.Todos().convertRows(ctx, todoRows)
+	if err != nil {
+		return nil, err
+	}
+	for i := range todos {
+		found[todos[i].UUID] = &todos[i]
+	}
+	projects, err := c.database.Projects().convertRows(ctx, projectRows)
+	if err != nil {
+		return nil, err
+	}
+	for i := range projects {
+		found[projects[i].UUID] = &projects[i]
+	}
+
+	areas, err := c.Areas().WithUUIDs(uuids...).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range areas {
+		found[areas[i].UUID] = &areas[i]
+	}
+
+	// Tags have no UUID filter; the tag list is small, so scan it.
+	wanted := make(map[string]bool, len(uuids))
+	for _, uuid := range uuids {
+		wanted[uuid] = true
+	}
+	tags, err := c.Tags().All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range tags {
+		if wanted[tags[i].UUID] {
+			found[tags[i].UUID] = &tags[i]
+		}
+	}
+	return found, skipped.result()
+}
@@ -0,0 +1,202 @@
+package things3
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// markdownItemPattern matches a Markdown list item, with or without a task
+// box, capturing its indentation, the box mark and the text.
+var markdownItemPattern = regexp.MustCompile(`^(\s*)[-*+]\s+(?:\[([ xX])\]\s+)?(.+?)\s*$`)
+
+// markdownTodo is a todo parsed from a Markdown outline.
+type markdownTodo struct {
+	title     string
+	completed bool
+	notes     []string
+	checklist []string
+}
+
+// markdownItem is a heading or a todo of a Markdown outline; exactly one of
+// heading and todo is set.
+type markdownItem struct {
+	heading string
+	todo    *markdownTodo
+}
+
+// ProjectFromMarkdown turns a Markdown outline into a batch that creates it
+// as one project with a single json command:
+//
+//	# Project title
+//	Text before the first heading or item becomes the project notes.
+//	## Heading
+//	- [ ] Todo
+//	  - Checklist item
+//	  Indented text becomes the todo notes.
+//	- [x] Completed todo
+//
+// The first "# " line names the project; ProjectFromMarkdown returns
+// ErrMarkdownNoTitle without one. Lines starting with "## " or deeper become
+// headings, filing the todos after them. Unindented list items, with or
+// without a task box, become todos, and a checked box marks one completed.
+// Items indented under a todo become its checklist, ignoring their own boxes
+// since batch checklist items carry titles only. Other text after the notes
+// is ignored. Add project attributes such as an area with DefaultArea before
+// executing the batch.
+func (c *Client) ProjectFromMarkdown(md string) (BatchCreator, error) {
+	title, notes, items, err := parseMarkdownProject(md)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Batch().AddProject(func(p BatchProjectConfigurator) {
+		p.Title(title)
+		if notes != "" {
+			p.Notes(notes)
+		}
+		for _, item := range items {
+			if item.heading != "" {
+				p.AddHeading(item.heading)
+				continue
+			}
+			p.AddTodo(item.todo.configure)
+		}
+	}), nil
+}
+
+// configure applies the parsed todo to a batch todo.
+func (t *markdownTodo) configure(b BatchTodoConfigurator) {
+	b.Title(t.title)
+	if notes := strings.TrimSpace(strings.Join(t.notes, "\n")); notes != "" {
+		b.Notes(notes)
+	}
+	if len(t.checklist) > 0 {
+		b.ChecklistItems(t.checklist...)
+	}
+	if t.completed {
+		b.Completed(true)
+	}
+}
+
+// parseMarkdownProject splits a Markdown outline into the project title,
+// its notes and its headings and todos in document order.
+func parseMarkdownProject(md string) (title, notes string, items []markdownItem, err error) {
+	var noteLines []string
+	var current *markdownTodo
+	for line := range strings.Lines(strings.ReplaceAll(md, "\r\n", "\n")) {
+		line = strings.TrimRight(line, " \t\n")
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			if current != nil {
+				current.notes = append(current.notes, "")
+			} else if title != "" && len(items) == 0 {
+				noteLines = append(noteLines, "")
+			}
+		case strings.HasPrefix(line, "# "):
+			if title == "" {
+				title = strings.TrimSpace(line[2:])
+			}
+		case strings.HasPrefix(line, "##"):
+			current = nil
+			items = append(items, markdownItem{heading: strings.TrimSpace(strings.TrimLeft(line, "#"))})
+		default:
+			m := markdownItemPattern.FindStringSubmatch(line)
+			switch {
+			case m != nil && m[1] == "":
+				current = &markdownTodo{title: m[3], completed: m[2] == "x" || m[2] == "X"}
+				items = append(items, markdownItem{todo: current})
+			case m != nil && current != nil:
+				current.checklist = append(current.checklist, m[3])
+			case current != nil && line != trimmed:
+				current.notes = append(current.notes, trimmed)
+			case title != "" && len(items) == 0:
+				noteLines = append(noteLines, line)
+			}
+		}
+	}
+	if title == "" {
+		return "", "", nil, ErrMarkdownNoTitle
+	}
+	return title, strings.TrimSpace(strings.Join(noteLines, "\n")), items, nil
+}
+
+// MarshalMarkdown renders items nested by BuildTree as a Markdown outline in
+// the layout ProjectFromMarkdown reads: a project becomes a "# " line, a
+// heading a "## " line, and a todo a task list item, "- [ ]" when open and
+// "- [x]" when completed or canceled, with a canceled title struck through.
+// A todo's loaded checklist is indented under it the same way. Projects and
+// todos end with a "#tag" token per tag, spaces in a tag becoming hyphens,
+// and "(due YYYY-MM-DD)" when they have a deadline. Notes are not rendered.
+// For a flat list, pass BuildTree(nil, nil, todos).
+func MarshalMarkdown(nodes []*TreeNode) string {
+	var w markdownWriter
+	w.nodes(nodes)
+	if w.Len() == 0 {
+		return ""
+	}
+	return strings.TrimRight(w.String(), "\n") + "\n"
+}
+
+// markdownWriter accumulates MarshalMarkdown output, keeping exactly one
+// blank line around each "#" block line.
+type markdownWriter struct {
+	strings.Builder
+	afterBlock bool
+}
+
+// nodes writes each node and its children in order.
+func (w *markdownWriter) nodes(nodes []*TreeNode) {
+	for _, node := range nodes {
+		switch {
+		case node.Project != nil:
+			p := node.Project
+			w.block("# " + p.Title + markdownSuffix(p.Tags, p.Deadline))
+		case node.Heading != nil:
+			w.block("## " + node.Heading.Title)
+		case node.Todo != nil:
+			todo := node.Todo
+			w.item("", todo.Status, todo.Title, markdownSuffix(todo.Tags, todo.Deadline))
+			for _, item := range todo.Checklist {
+				w.item("  ", item.Status, item.Title, "")
+			}
+		}
+		w.nodes(node.Children)
+	}
+}
+
+// block writes a line set apart by blank lines.
+func (w *markdownWriter) block(line string) {
+	if w.Len() > 0 && !w.afterBlock {
+		w.WriteString("\n")
+	}
+	w.WriteString(line + "\n\n")
+	w.afterBlock = true
+}
+
+// item writes a task list item with the box for status.
+func (w *markdownWriter) item(indent string, status Status, title, suffix string) {
+	box := "[ ]"
+	switch status {
+	case StatusCompleted:
+		box = "[x]"
+	case StatusCanceled:
+		box = "[x]"
+		title = "~~" + title + "~~"
+	}
+	w.WriteString(indent + "- " + box + " " + title + suffix + "\n")
+	w.afterBlock = false
+}
+
+// markdownSuffix renders the trailing tag tokens and due date of a line.
+func markdownSuffix(tags []string, deadline *time.Time) string {
+	var b strings.Builder
+	for _, tag := range tags {
+		b.WriteString(" #" + strings.ReplaceAll(tag, " ", "-"))
+	}
+	if deadline != nil {
+		b.WriteString(" (due " + deadline.Format(time.DateOnly) + ")")
+	}
+	return b.String()
+}
@@ -0,0 +1,145 @@
+package things3
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectFromMarkdown(t *testing.T) {
+	client := newTestClient(t)
+
+	md := "# Launch\n" +
+		"Ship the new site.\n" +
+		"\n" +
+		"- [ ] Pick a date\n" +
+		"## Build\n" +
+		"- [ ] Write pages\n" +
+		"  - Home\n" +
+		"  - [x] About\n" +
+		"  Keep it short.\n" +
+		"- [x] Buy domain\n" +
+		"## Announce\n" +
+		"* Post on the blog\n"
+
+	batch, err := client.ProjectFromMarkdown(md)
+	require.NoError(t, err)
+	thingsURL, err := batch.Build()
+	require.NoError(t, err)
+
+	items := parseJSONItems(t, thingsURL)
+	require.Len(t, items, 1, "one project in one json command")
+	assert.Equal(t, JSONItemTypeProject, items[0].Type)
+	assert.Equal(t, "Launch", items[0].Attributes["title"])
+	assert.Equal(t, "Ship the new site.", items[0].Attributes["notes"])
+
+	assert.Equal(t, []any{
+		map[string]any{"type": "to-do", "attributes": map[string]any{"title": "Pick a date"}},
+		map[string]any{"type": "heading", "attributes": map[string]any{"title": "Build"}},
+		map[string]any{"type": "to-do", "attributes": map[string]any{
+			"title": "Write pages",
+			"notes": "Keep it short.",
+			"checklist-items": []any{
+				map[string]any{"type": "checklist-item", "attributes": map[string]any{"title": "Home"}},
+				map[string]any{"type": "checklist-item", "attributes": map[string]any{"title": "About"}},
+			},
+		}},
+		map[string]any{"type": "to-do", "attributes": map[string]any{"title": "Buy domain", "completed": true}},
+		map[string]any{"type": "heading", "attributes": map[string]any{"title": "Announce"}},
+		map[string]any{"type": "to-do", "attributes": map[string]any{"title": "Post on the blog"}},
+	}, items[0].Attributes["items"])
+}
+
+func TestProjectFromMarkdownNoTitle(t *testing.T) {
+	client := newTestClient(t)
+
+	_, err := client.ProjectFromMarkdown("## Heading\n- [ ] Todo\n")
+	require.ErrorIs(t, err, ErrMarkdownNoTitle)
+}
+
+// updateGolden rewrites golden files from the current output:
+// go test -run Golden -update.
+var updateGolden = flag.Bool("update", false, "rewrite golden files")
+
+// assertGolden compares got with testdata/golden/name, rewriting the file
+// instead under -update.
+func assertGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", "golden", name)
+	if *updateGolden {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(got), 0o600))
+	}
+	want, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, string(want), got)
+}
+
+func TestMarshalMarkdownGolden(t *testing.T) {
+	deadline := time.Date(2025, time.March, 31, 0, 0, 0, 0, time.Local)
+
+	t.Run("project with children", func(t *testing.T) {
+		projects := []Project{{UUID: "P", Title: "Launch", Tags: []string{"work"}, Deadline: &deadline}}
+		headings := []Heading{
+			{UUID: "H1", Title: "Build", ProjectUUID: "P"},
+			{UUID: "H2", Title: "Announce", ProjectUUID: "P"},
+		}
+		todos := []Todo{
+			{UUID: "T1", Title: "Pick a date", ProjectUUID: "P", Status: StatusCompleted},
+			{
+				UUID: "T2", Title: "Write pages", ProjectUUID: "P", HeadingUUID: "H1",
+				Tags: []string{"deep work"}, Deadline: &deadline,
+				Checklist: []ChecklistItem{
+					{Title: "Home", Status: StatusCompleted},
+					{Title: "About", Status: StatusIncomplete},
+				},
+			},
+			{UUID: "T3", Title: "Buy domain", ProjectUUID: "P", HeadingUUID: "H1", Status: StatusCanceled},
+			{UUID: "T4", Title: "Post on the blog", ProjectUUID: "P", HeadingUUID: "H2"},
+		}
+		assertGolden(t, "markdown_project.md", MarshalMarkdown(BuildTree(projects, headings, todos)))
+	})
+
+	t.Run("flat todo list", func(t *testing.T) {
+		todos := []Todo{
+			{UUID: "T1", Title: "Call the bank", Tags: []string{"Errand", "Phone"}},
+			{UUID: "T2", Title: "File taxes", Deadline: &deadline},
+			{UUID: "T3", Title: "Water plants", Status: StatusCompleted},
+		}
+		assertGolden(t, "markdown_flat.md", MarshalMarkdown(BuildTree(nil, nil, todos)))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		assert.Empty(t, MarshalMarkdown(nil))
+	})
+}
+
+func TestMarshalMarkdownRoundTrip(t *testing.T) {
+	client := newTestClient(t)
+
+	projects := []Project{{UUID: "P", Title: "Launch"}}
+	headings := []Heading{{UUID: "H", Title: "Build", ProjectUUID: "P"}}
+	todos := []Todo{
+		{UUID: "T1", Title: "Pick a date", ProjectUUID: "P"},
+		{UUID: "T2", Title: "Write pages", ProjectUUID: "P", HeadingUUID: "H", Status: StatusCompleted},
+	}
+
+	batch, err := client.ProjectFromMarkdown(MarshalMarkdown(BuildTree(projects, headings, todos)))
+	require.NoError(t, err)
+	thingsURL, err := batch.Build()
+	require.NoError(t, err)
+
+	items := parseJSONItems(t, thingsURL)
+	require.Len(t, items, 1)
+	assert.Equal(t, "Launch", items[0].Attributes["title"])
+	assert.Equal(t, []any{
+		map[string]any{"type": "to-do", "attributes": map[string]any{"title": "Pick a date"}},
+		map[string]any{"type": "heading", "attributes": map[string]any{"title": "Build"}},
+		map[string]any{"type": "to-do", "attributes": map[string]any{"title": "Write pages", "completed": true}},
+	}, items[0].Attributes["items"])
+}
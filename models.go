@@ -4,11 +4,13 @@ import "time"
 
 // Todo represents an actionable todo item in Things 3.
 type Todo struct {
-	UUID   string      `json:"uuid"`
-	Title  string      `json:"title"`
-	Status Status      `json:"status"`
-	Notes  string      `json:"notes,omitempty"`
-	Start  StartBucket `json:"start"`
+	UUID   string `json:"uuid"`
+	Title  string `json:"title"`
+	Status Status `json:"status"`
+	Notes  string `json:"notes,omitempty"`
+	// Start is typed for switch statements and encodes as "inbox",
+	// "anytime", or "someday".
+	Start StartBucket `json:"start"`
 
 	// Relationships (empty string = no relationship)
 	AreaUUID     string `json:"area_uuid,omitempty"`
@@ -18,11 +20,16 @@ type Todo struct {
 	HeadingUUID  string `json:"heading_uuid,omitempty"`
 	HeadingTitle string `json:"heading_title,omitempty"`
 
-	// Attributes
+	// Attributes. Tags is nil for an untagged todo, and Checklist is nil when
+	// the todo has no items or the query did not load them (see
+	// IncludeChecklist). Both are omitted from JSON when empty, so an encoded
+	// todo never carries a null list.
 	Tags      []string        `json:"tags,omitempty"`
 	Checklist []ChecklistItem `json:"checklist,omitempty"`
 
-	// Dates (date only, no time component)
+	// Dates (date only, no time component). The schema has no deadline time:
+	// TMTask.deadline is a packed date, and reminder times attach to the
+	// start date rather than the deadline.
 	StartDate *time.Time `json:"start_date,omitempty"`
 	Deadline  *time.Time `json:"deadline,omitempty"`
 
@@ -42,6 +49,14 @@ type Todo struct {
 	// Repeating reports whether the todo belongs to a repeating series, either a
 	// generated instance or the template that schedules its next occurrence.
 	Repeating bool `json:"repeating,omitempty"`
+	// RecurrenceRule is the schedule of the todo's repeating series, or nil
+	// when it does not repeat or the stored rule could not be decoded.
+	RecurrenceRule *RecurrenceRule `json:"recurrence_rule,omitempty"`
+
+	// deadlineSuppressed records that the user dismissed the overdue deadline
+	// from Today. It stays internal like the database column, and only feeds
+	// IsInToday.
+	deadlineSuppressed bool
 }
 
 // Project represents a container for organizing todos in Things 3.
@@ -77,6 +92,27 @@ type Project struct {
 	// Repeating reports whether the project belongs to a repeating series, either
 	// a generated instance or the template that schedules its next occurrence.
 	Repeating bool `json:"repeating,omitempty"`
+	// RecurrenceRule is the schedule of the project's repeating series, or nil
+	// when it does not repeat or the stored rule could not be decoded.
+	RecurrenceRule *RecurrenceRule `json:"recurrence_rule,omitempty"`
+}
+
+// RecurrenceRule describes how a repeating series recurs. It is decoded
+// best-effort from the rule Things stores, covering the common daily,
+// weekly, monthly and yearly schedules.
+type RecurrenceRule struct {
+	Frequency Frequency `json:"frequency"`
+	// Interval is the number of Frequency units between occurrences.
+	Interval int `json:"interval"`
+	// Weekdays lists the days a weekly rule falls on.
+	Weekdays []time.Weekday `json:"weekdays,omitempty"`
+	// DaysOfMonth lists the days a monthly rule falls on; -1 is the last day.
+	DaysOfMonth []int `json:"days_of_month,omitempty"`
+	// Until is the last date of the series, or nil when it has no end date.
+	Until *time.Time `json:"until,omitempty"`
+	// Count is the number of occurrences the series stops after, or 0 for
+	// no limit.
+	Count int `json:"count,omitempty"`
 }
 
 // Heading represents a grouping label within a project.
@@ -95,6 +131,8 @@ type Area struct {
 	UUID  string   `json:"uuid"`
 	Title string   `json:"title"`
 	Tags  []string `json:"tags,omitempty"`
+	// Visible is false for an area the user hid from the sidebar.
+	Visible bool `json:"visible"`
 }
 
 // Tag represents a label for categorizing items in Things 3.
@@ -102,6 +140,9 @@ type Tag struct {
 	UUID     string `json:"uuid"`
 	Title    string `json:"title"`
 	Shortcut string `json:"shortcut,omitempty"`
+
+	// ParentUUID is the tag this one is nested under (empty string = top level).
+	ParentUUID string `json:"parent_uuid,omitempty"`
 }
 
 // ChecklistItem represents a sub-item within a todo.
@@ -0,0 +1,65 @@
+package things3
+
+import (
+	"regexp"
+	"strings"
+)
+
+// noteLinkPattern matches absolute URLs with a scheme, including Things
+// deep links (things:///show?id=...). Markdown link syntax is covered
+// because the URL inside "(...)" stops at the closing parenthesis.
+var noteLinkPattern = regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://[^\s<>"()\[\]]+`)
+
+// noteCheckboxPattern matches a Markdown task-list line such as "- [x] Done",
+// capturing the box mark and the item text.
+var noteCheckboxPattern = regexp.MustCompile(`^\s*[-*+]\s+\[([ xX])\]\s+(.+?)\s*$`)
+
+// NoteLinks returns the URLs found in the todo notes in order of first
+// appearance, without duplicates. Returns an empty slice when there are none.
+func (t *Todo) NoteLinks() []string {
+	return extractNoteLinks(t.Notes)
+}
+
+// NoteLinks returns the URLs found in the project notes in order of first
+// appearance, without duplicates. Returns an empty slice when there are none.
+func (p *Project) NoteLinks() []string {
+	return extractNoteLinks(p.Notes)
+}
+
+// extractNoteLinks scans notes for URLs, trimming sentence punctuation that
+// commonly trails a link in prose.
+func extractNoteLinks(notes string) []string {
+	matches := noteLinkPattern.FindAllString(notes, -1)
+	links := make([]string, 0, len(matches))
+	seen := make(map[string]bool, len(matches))
+	for _, m := range matches {
+		link := strings.TrimRight(m, ".,;:!?'")
+		if seen[link] {
+			continue
+		}
+		seen[link] = true
+		links = append(links, link)
+	}
+	return links
+}
+
+// NotesChecklist parses Markdown checkboxes ("- [ ] item", "- [x] item") out
+// of the todo notes, for migrating checklists kept in notes before native
+// checklists existed. Items keep their order; a checked box yields
+// StatusCompleted. The items exist only in the notes, so UUID and the
+// timestamps are zero. Returns an empty slice when there are none.
+func (t *Todo) NotesChecklist() []ChecklistItem {
+	items := []ChecklistItem{}
+	for line := range strings.Lines(t.Notes) {
+		m := noteCheckboxPattern.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		status := StatusIncomplete
+		if m[1] != " " {
+			status = StatusCompleted
+		}
+		items = append(items, ChecklistItem{Title: m[2], Status: status})
+	}
+	return items
+}
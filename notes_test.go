@@ -0,0 +1,64 @@
+package things3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNoteLinks(t *testing.T) {
+	tests := []struct {
+		name  string
+		notes string
+		want  []string
+	}{
+		{"empty", "", []string{}},
+		{"no links", "just some text", []string{}},
+		{"bare url", "see https://example.com/a?b=1 for details", []string{"https://example.com/a?b=1"}},
+		{"markdown link", "[Docs](https://go.dev/doc/)", []string{"https://go.dev/doc/"}},
+		{"trailing punctuation", "Read https://example.com.", []string{"https://example.com"}},
+		{"things deep link", "things:///show?id=abc", []string{"things:///show?id=abc"}},
+		{
+			"dedupe keeps order",
+			"http://b.example\nhttps://a.example\nhttp://b.example",
+			[]string{"http://b.example", "https://a.example"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			todo := Todo{Notes: tt.notes}
+			assert.Equal(t, tt.want, todo.NoteLinks())
+			project := Project{Notes: tt.notes}
+			assert.Equal(t, tt.want, project.NoteLinks())
+		})
+	}
+}
+
+func TestNotesChecklist(t *testing.T) {
+	tests := []struct {
+		name  string
+		notes string
+		want  []ChecklistItem
+	}{
+		{"empty", "", []ChecklistItem{}},
+		{"no boxes", "- plain bullet\n[ ] not a list item", []ChecklistItem{}},
+		{
+			"mixed states and markers",
+			"Packing:\n- [ ] Passport\n  * [x] Tickets  \r\n+ [X] Charger\n-[ ] missing space",
+			[]ChecklistItem{
+				{Title: "Passport", Status: StatusIncomplete},
+				{Title: "Tickets", Status: StatusCompleted},
+				{Title: "Charger", Status: StatusCompleted},
+			},
+		},
+		{"empty box text", "- [ ] \n- [x] Done", []ChecklistItem{{Title: "Done", Status: StatusCompleted}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			todo := Todo{Notes: tt.notes}
+			assert.Equal(t, tt.want, todo.NotesChecklist())
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package things3
+
+import "context"
+
+// OutlineItem is one row of a project outline. Exactly one of Heading and
+// Todo is set.
+type OutlineItem struct {
+	Heading *Heading `json:"heading,omitempty"`
+	Todo    *Todo    `json:"todo,omitempty"`
+}
+
+// HeadingGroup is a heading of a project together with its open todos. The
+// group of todos outside any heading has a nil Heading.
+type HeadingGroup struct {
+	Heading *Heading `json:"heading"`
+	Todos   []Todo   `json:"todos"`
+}
+
+// ProjectOutline returns the open contents of a project as a flat list in the
+// app's display order: todos outside any heading first, then each heading
+// row followed by its todos. Archived headings are left out together with
+// their todos, which the app shows in the Logbook instead. The result is
+// never nil. Corrupt rows are handled as in HeadingGroups.
+func (c *Client) ProjectOutline(ctx context.Context, projectUUID string) ([]OutlineItem, error) {
+	var skipped skippedRows
+	groups, err := c.HeadingGroups(ctx, projectUUID)
+	if err := skipped.add(err); err != nil {
+		return nil, err
+	}
+
+	items := make([]OutlineItem, 0)
+	for i := range groups {
+		if groups[i].Heading != nil {
+			items = append(items, OutlineItem{Heading: groups[i].Heading})
+		}
+		for j := range groups[i].Todos {
+			items = append(items, OutlineItem{Todo: &groups[i].Todos[j]})
+		}
+	}
+	return items, skipped.result()
+}
+
+// ProjectAllItems returns every untrashed todo of a project whatever its
+// status, open, completed and canceled alike, including todos under
+// archived headings, ordered by list index. It suits a full project report,
+// where ProjectOutline would leave the logged todos out. The headings
+// themselves are available from Headings().InProject. The result is never
+// nil.
+func (c *Client) ProjectAllItems(ctx context.Context, projectUUID string) ([]Todo, error) {
+	return c.database.Todos().InProject(projectUUID).Status().Any().All(ctx)
+}
+
+// HeadingGroups returns the open contents of a project grouped the way the
+// app lays them out: a group with a nil Heading for the todos outside any
+// heading, present only when there are such todos, then one group per open
+// heading in order, including empty ones. Todos keep their order within
+// each group, and archived headings are left out as in ProjectOutline. The
+// result and every group's Todos are never nil. Under WithSkipCorruptRows
+// the readable headings and todos are grouped and returned with a
+// *CorruptRowsError.
+func (c *Client) HeadingGroups(ctx context.Context, projectUUID string) ([]HeadingGroup, error) {
+	var skipped skippedRows
+	headings, err := c.database.Headings().open().InProject(projectUUID).All(ctx)
+	if err := skipped.add(err); err != nil {
+		return nil, err
+	}
+	todos, err := c.database.Todos().InProject(projectUUID).Status().Incomplete().All(ctx)
+	if err := skipped.add(err); err != nil {
+		return nil, err
+	}
+
+	byHeading := make(map[string][]Todo, len(headings))
+	for i := range todos {
+		byHeading[todos[i].HeadingUUID] = append(byHeading[todos[i].HeadingUUID], todos[i])
+	}
+
+	groups := make([]HeadingGroup, 0, len(headings)+1)
+	if loose := byHeading[""]; len(loose) > 0 {
+		groups = append(groups, HeadingGroup{Todos: loose})
+	}
+	for i := range headings {
+		group := HeadingGroup{Heading: &headings[i], Todos: byHeading[headings[i].UUID]}
+		if group.Todos == nil {
+			group.Todos = make([]Todo, 0)
+		}
+		groups = append(groups, group)
+	}
+	return groups, skipped.result()
+}
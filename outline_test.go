@@ -0,0 +1,117 @@
+package things3
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// outlineUUIDs flattens an outline to its row UUIDs, prefixing headings with
+// "#" so the tests can tell the row kinds apart.
+func outlineUUIDs(items []OutlineItem) []string {
+	uuids := make([]string, len(items))
+	for i, item := range items {
+		if item.Heading != nil {
+			uuids[i] = "#" + item.Heading.UUID
+		} else {
+			uuids[i] = item.Todo.UUID
+		}
+	}
+	return uuids
+}
+
+func TestClientProjectOutline(t *testing.T) {
+	client := newTestClient(t)
+	ctx := t.Context()
+
+	items, err := client.ProjectOutline(ctx, testUUIDProjectInArea1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		testUUIDTodoInArea1Tags,
+		testUUIDTodoOverdueInToday,
+		testUUIDTodoOverdueNotToday,
+		"#6QpDLSHZMRAUSAeZ9mNvgt",
+		testUUIDTodoInHeading,
+	}, outlineUUIDs(items))
+
+	for _, item := range items {
+		assert.NotEqual(t, item.Heading == nil, item.Todo == nil, "exactly one of Heading and Todo is set")
+	}
+}
+
+func TestClientProjectOutlineSkipsArchivedHeadings(t *testing.T) {
+	client := newTestClient(t)
+
+	// "Project without Area" has an open empty heading and an archived one;
+	// only the open heading appears, after the project's open todo.
+	items, err := client.ProjectOutline(t.Context(), "TCozQqXVbB2TJkXXXQj2H9")
+	require.NoError(t, err)
+	assert.Equal(t, []string{testUUIDTodoInProject, "#AddtnlHdngTestFixture1"}, outlineUUIDs(items))
+}
+
+func TestClientProjectOutlineEmpty(t *testing.T) {
+	client := newTestClient(t)
+
+	items, err := client.ProjectOutline(t.Context(), "nonexistent-uuid")
+	require.NoError(t, err)
+	data, err := json.Marshal(items)
+	require.NoError(t, err)
+	assert.JSONEq(t, "[]", string(data))
+}
+
+func TestClientHeadingGroups(t *testing.T) {
+	client := newTestClient(t)
+	ctx := t.Context()
+
+	groups, err := client.HeadingGroups(ctx, testUUIDProjectInArea1)
+	require.NoError(t, err)
+	require.Len(t, groups, 2)
+
+	assert.Nil(t, groups[0].Heading, "todos outside any heading come first")
+	assert.Equal(t, []string{
+		testUUIDTodoInArea1Tags,
+		testUUIDTodoOverdueInToday,
+		testUUIDTodoOverdueNotToday,
+	}, extractTodoUUIDs(groups[0].Todos))
+
+	require.NotNil(t, groups[1].Heading)
+	assert.Equal(t, "6QpDLSHZMRAUSAeZ9mNvgt", groups[1].Heading.UUID)
+	assert.Equal(t, []string{testUUIDTodoInHeading}, extractTodoUUIDs(groups[1].Todos))
+}
+
+func TestClientHeadingGroupsKeepsEmptyHeadings(t *testing.T) {
+	client := newTestClient(t)
+
+	groups, err := client.HeadingGroups(t.Context(), "TCozQqXVbB2TJkXXXQj2H9")
+	require.NoError(t, err)
+	require.Len(t, groups, 2)
+	assert.Equal(t, []string{testUUIDTodoInProject}, extractTodoUUIDs(groups[0].Todos))
+	require.NotNil(t, groups[1].Heading)
+	assert.Equal(t, "AddtnlHdngTestFixture1", groups[1].Heading.UUID)
+	assert.NotNil(t, groups[1].Todos)
+	assert.Empty(t, groups[1].Todos)
+}
+
+func TestClientProjectAllItems(t *testing.T) {
+	client := newTestClient(t)
+	ctx := t.Context()
+
+	items, err := client.ProjectAllItems(ctx, testUUIDProjectInArea1)
+	require.NoError(t, err)
+	uuids := extractTodoUUIDs(items)
+	assert.Len(t, uuids, 6)
+	assert.Contains(t, uuids, "2qBNNhNuDUBEGcB2tVRH9W", "completed todo under a heading")
+	assert.Contains(t, uuids, "RqRi38gMxTFyhPh2X1vH1i", "canceled todo under a heading")
+
+	statuses := make(map[Status]bool)
+	for i := range items {
+		statuses[items[i].Status] = true
+	}
+	assert.Len(t, statuses, 3, "open, completed and canceled")
+
+	empty, err := client.ProjectAllItems(ctx, "nonexistent-uuid")
+	require.NoError(t, err)
+	assert.NotNil(t, empty)
+}
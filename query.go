@@ -2,6 +2,8 @@ package things3
 
 import (
 	"context"
+	"errors"
+	"slices"
 	"time"
 
 	"github.com/moond4rk/things3/internal/database"
@@ -21,6 +23,193 @@ type taskQuery struct {
 	database         *db
 	filter           database.TaskFilter
 	includeChecklist bool
+	// cursorErr holds a malformed AfterCursor token until the query runs.
+	cursorErr error
+	// err holds the first invalid builder argument until the query runs.
+	err error
+}
+
+// check reports an invalid builder argument recorded while the query was
+// built, then a cursor the query cannot honor.
+func (q *taskQuery) check() error {
+	if q.err != nil {
+		return q.err
+	}
+	return q.checkCursor()
+}
+
+// fail records err for the query to report when it runs, keeping the first.
+func (q *taskQuery) fail(err error) {
+	if q.err == nil {
+		q.err = err
+	}
+}
+
+// orderBy appends a sort term after the earlier ones. An unknown column adds
+// no term and is kept as ErrInvalidTaskColumn for the query to report.
+func (q *taskQuery) orderBy(column TaskColumn, dir SortDir) {
+	if err := database.CheckColumn(ErrInvalidTaskColumn, column, taskColumns); err != nil {
+		q.fail(err)
+		return
+	}
+	q.filter.OrderBy = slices.Concat(q.filter.OrderBy, []database.TaskOrder{{Column: string(column), Desc: dir == SortDesc}})
+}
+
+// firstOK adapts a First result for FirstOK, turning a not-found error into
+// ok == false.
+func firstOK[T any](v *T, err error) (*T, bool, error) {
+	switch {
+	case v != nil:
+		// A match found past corrupt rows keeps its *CorruptRowsError.
+		return v, true, err
+	case errors.Is(err, ErrTodoNotFound), errors.Is(err, ErrProjectNotFound), errors.Is(err, ErrHeadingNotFound),
+		errors.Is(err, ErrAreaNotFound), errors.Is(err, ErrTagNotFound):
+		return nil, false, nil
+	default:
+		return nil, false, err
+	}
+}
+
+// queryTasks runs the task query. skipped carries the *CorruptRowsError for
+// rows left out under WithSkipCorruptRows, so callers can still convert the
+// rest; err is any other failure.
+func (q *taskQuery) queryTasks(ctx context.Context) (rows []database.TaskRow, skipped, err error) {
+	if err := q.check(); err != nil {
+		return nil, nil, err
+	}
+	rows, err = q.database.inner.QueryTasks(ctx, &q.filter)
+	var corrupt *database.CorruptRowsError
+	if errors.As(err, &corrupt) {
+		return rows, err, nil
+	}
+	return rows, nil, err
+}
+
+// firstRow reads the first readable row of the query. It fetches a single
+// row and, only when that row is corrupt under WithSkipCorruptRows, moves on
+// one row at a time until one reads. skipped reports the rows passed over;
+// row is nil when there is no readable match.
+func (q *taskQuery) firstRow(ctx context.Context) (row *database.TaskRow, skipped, err error) {
+	c := *q
+	c.filter.Limit = new(1)
+	offset := 0
+	if q.filter.Offset != nil {
+		offset = *q.filter.Offset
+	}
+	var corrupt skippedRows
+	for {
+		rows, passed, err := c.queryTasks(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(rows) > 0 {
+			return &rows[0], corrupt.result(), nil
+		}
+		if passed == nil {
+			return nil, corrupt.result(), nil
+		}
+		_ = corrupt.add(passed)
+		offset++
+		c.filter.Offset = new(offset)
+	}
+}
+
+// eachChunkSize is how many rows Each reads per query.
+var eachChunkSize = 500
+
+// eachChunk runs the query eachChunkSize rows at a time and calls fn with
+// each chunk once its result set is closed, so fn may query the database
+// even when the pool has a single connection. Ties in the ordering are
+// broken by UUID to keep chunk boundaries stable, the query's own Limit and
+// Offset still apply, and rows skipped under WithSkipCorruptRows are
+// reported after the last chunk.
+func (q *taskQuery) eachChunk(ctx context.Context, fn func([]database.TaskRow) error) error {
+	c := *q
+	c.filter.Keyset = true
+	offset := 0
+	if q.filter.Offset != nil {
+		offset = *q.filter.Offset
+	}
+	remaining := -1
+	if q.filter.Limit != nil && *q.filter.Limit > 0 {
+		remaining = *q.filter.Limit
+	}
+
+	var skipped skippedRows
+	for remaining != 0 {
+		size := eachChunkSize
+		if remaining > 0 && remaining < size {
+			size = remaining
+		}
+		c.filter.Limit, c.filter.Offset = new(size), new(offset)
+		rows, passed, err := c.queryTasks(ctx)
+		if err != nil {
+			return err
+		}
+		_ = skipped.add(passed)
+		read := len(rows)
+		var corrupt *CorruptRowsError
+		if errors.As(passed, &corrupt) {
+			read += corrupt.Skipped
+		}
+		if len(rows) > 0 {
+			if err := fn(rows); err != nil {
+				return err
+			}
+		}
+		if read < size {
+			break
+		}
+		offset += read
+		if remaining > 0 {
+			remaining -= read
+		}
+	}
+	return skipped.result()
+}
+
+// tagsOf loads the tags of the tagged rows, keyed by task UUID.
+func (q *taskQuery) tagsOf(ctx context.Context, rows []database.TaskRow) (map[string][]string, error) {
+	var tagged []string
+	for i := range rows {
+		if rows[i].HasTags {
+			tagged = append(tagged, rows[i].UUID)
+		}
+	}
+	if len(tagged) == 0 {
+		return nil, nil
+	}
+	return q.database.inner.TagsOfTasks(ctx, tagged)
+}
+
+// skippedRows collects the *CorruptRowsError of the queries behind a
+// composed read, so it can return what it read with one combined error, as
+// All does.
+type skippedRows struct {
+	err *CorruptRowsError
+}
+
+// add records err when it is a *CorruptRowsError and returns any other
+// error for the caller to fail on.
+func (s *skippedRows) add(err error) error {
+	var corrupt *CorruptRowsError
+	if !errors.As(err, &corrupt) {
+		return err
+	}
+	if s.err == nil {
+		s.err = &CorruptRowsError{First: corrupt.First}
+	}
+	s.err.Skipped += corrupt.Skipped
+	return nil
+}
+
+// result returns the combined *CorruptRowsError, or nil when no row was
+// skipped.
+func (s *skippedRows) result() error {
+	if s.err == nil {
+		return nil
+	}
+	return s.err
 }
 
 // =============================================================================
@@ -65,11 +254,31 @@ func (q *todoQuery) WithUUID(uuid string) TodoQueryBuilder {
 	return q.withFilter(func(f *database.TaskFilter) { f.UUID = &uuid })
 }
 
+// WithUUIDs filters todos to those with any of the given UUIDs, loading
+// several in one query. It replaces an earlier call, and no UUIDs adds no
+// filter. Results keep the index order, not the argument order.
+func (q *todoQuery) WithUUIDs(uuids ...string) TodoQueryBuilder {
+	return q.withFilter(func(f *database.TaskFilter) {
+		f.UUIDs = nil
+		if len(uuids) > 0 {
+			f.UUIDs = slices.Clone(uuids)
+		}
+	})
+}
+
 // WithUUIDPrefix filters todos by UUID prefix (LIKE match).
 func (q *todoQuery) WithUUIDPrefix(prefix string) TodoQueryBuilder {
 	return q.withFilter(func(f *database.TaskFilter) { f.UUIDPrefix = &prefix })
 }
 
+// ExcludeUUIDs drops the todos with the given UUIDs from the results.
+// Repeated calls accumulate.
+func (q *todoQuery) ExcludeUUIDs(uuids ...string) TodoQueryBuilder {
+	return q.withFilter(func(f *database.TaskFilter) {
+		f.ExcludeUUIDs = slices.Concat(f.ExcludeUUIDs, uuids)
+	})
+}
+
 // WithTitle filters todos by title (keyword match).
 func (q *todoQuery) WithTitle(title string) TodoQueryBuilder {
 	return q.withFilter(func(f *database.TaskFilter) { f.Title = &title })
@@ -85,6 +294,21 @@ func (q *todoQuery) Start() StartFilter[TodoQueryBuilder] {
 	return &startFilter[TodoQueryBuilder]{with: q.withFilter}
 }
 
+// InheritProjectStart makes Start filter on, and Todo.Start report, the
+// bucket the app shows a todo in: a todo in a Someday project, directly or
+// under one of its headings, counts as Someday whatever its own bucket.
+func (q *todoQuery) InheritProjectStart(inherit bool) TodoQueryBuilder {
+	return q.withFilter(func(f *database.TaskFilter) { f.InheritProjectStart = inherit })
+}
+
+// IncludeContextTrashed controls whether untrashed todos inside a trashed
+// project or heading are returned. By default they are not, matching the app,
+// which hides them along with their container; pass true to find such
+// "ghost" todos, for example to audit what emptying the trash will delete.
+func (q *todoQuery) IncludeContextTrashed(include bool) TodoQueryBuilder {
+	return q.withFilter(func(f *database.TaskFilter) { f.IncludeContextTrashed = include })
+}
+
 // Trashed filters todos by trash status.
 func (q *todoQuery) Trashed(trashed bool) TodoQueryBuilder {
 	return q.withFilter(func(f *database.TaskFilter) { f.Trashed = &trashed })
@@ -130,6 +354,74 @@ func (q *todoQuery) HasTag(has bool) TodoQueryBuilder {
 	return q.withFilter(func(f *database.TaskFilter) { f.HasTags = &has })
 }
 
+// IncludeRecurring controls whether repeating templates, the hidden rows that
+// schedule each next occurrence of a repeating todo, are returned alongside
+// regular todos. They are left out by default, as in the app's lists. A
+// template carries its schedule in RecurrenceRule.
+func (q *todoQuery) IncludeRecurring(include bool) TodoQueryBuilder {
+	return q.withFilter(func(f *database.TaskFilter) { f.IncludeRecurring = include })
+}
+
+// InAllTags filters todos tagged with every one of the titles. Like
+// InAnyTags, it replaces an earlier call and matches nothing with no titles.
+// Only tags on a todo itself count, as with InTag, and combining this with
+// HasTag(false) matches nothing.
+func (q *todoQuery) InAllTags(titles ...string) TodoQueryBuilder {
+	return q.withFilter(func(f *database.TaskFilter) { f.AllTags = append([]string{}, titles...) })
+}
+
+// InAnyTags filters todos tagged with at least one of the titles. Like
+// InAllTags, it replaces an earlier call and matches nothing with no titles,
+// and combining it with HasTag(false) matches nothing.
+func (q *todoQuery) InAnyTags(titles ...string) TodoQueryBuilder {
+	return q.withFilter(func(f *database.TaskFilter) { f.AnyTags = append([]string{}, titles...) })
+}
+
+// NotInTag filters out todos tagged with the title, keeping untagged ones.
+// Repeated calls exclude each title; an empty title adds no filter. Like
+// InTag it looks only at a todo's own tags.
+func (q *todoQuery) NotInTag(title string) TodoQueryBuilder {
+	if title == "" {
+		return q
+	}
+	return q.withFilter(func(f *database.TaskFilter) { f.ExcludeTags = slices.Concat(f.ExcludeTags, []string{title}) })
+}
+
+// WhereRaw adds a condition the builder does not model, written against the
+// query's table aliases: TASK, AREA, PROJECT, HEADING, PROJECT_OF_HEADING and
+// TAG. Each ? in expr is replaced by the matching arg as an escaped SQL
+// literal, never spliced in as text, so args are injection-safe; a time.Time
+// becomes Unix seconds like the creationDate and userModificationDate
+// columns. expr itself is trusted: a typo or an unknown column makes the
+// query fail when it runs, and the aliases may change between releases.
+// Repeated calls add conditions. If the number of ? placeholders and args
+// differ, no condition is added and the query returns ErrRawArgCount.
+//
+// Example:
+//
+//	client.Todos().WhereRaw("TASK.title LIKE ? AND TASK.status = ?", "%report%", 0)
+func (q *todoQuery) WhereRaw(expr string, args ...any) TodoQueryBuilder {
+	bound, err := database.BindSQL(expr, args...)
+	c := q.clone()
+	if err != nil {
+		c.inner.fail(err)
+		return c
+	}
+	c.inner.filter.Raw = slices.Concat(c.inner.filter.Raw, []string{bound})
+	return c
+}
+
+// Orphaned filters todos that have no area, no project, and no heading.
+// Unlike HasProject(false), which still allows an area, this matches only
+// loose captures with no context at all.
+func (q *todoQuery) Orphaned() TodoQueryBuilder {
+	return q.withFilter(func(f *database.TaskFilter) {
+		f.HasArea = new(false)
+		f.HasProject = new(false)
+		f.HasHeading = new(false)
+	})
+}
+
 // StartDate returns a DateFilter for start date filtering.
 func (q *todoQuery) StartDate() DateFilter[TodoQueryBuilder] {
 	return &dateFilter[TodoQueryBuilder]{with: q.withFilter, field: dateFieldStartDate}
@@ -146,12 +438,24 @@ func (q *todoQuery) Deadline() DateFilter[TodoQueryBuilder] {
 }
 
 // deadlineSuppressed filters todos by whether the deadline has been suppressed.
-// It is unexported: deadline suppression is a database internal, and Today is
-// its only consumer.
+// It is unexported: deadline suppression is a database internal, surfaced only
+// through Today and SuppressedDeadlines.
 func (q *todoQuery) deadlineSuppressed(suppressed bool) TodoQueryBuilder {
 	return q.withFilter(func(f *database.TaskFilter) { f.DeadlineSuppressed = &suppressed })
 }
 
+// todayView restricts the query to the Things Today view and orders it as the
+// app does. It is unexported because Client.Today is its public surface.
+func (q *todoQuery) todayView() TodoQueryBuilder {
+	return q.withFilter(func(f *database.TaskFilter) { f.TodayView = true })
+}
+
+// checklistComplete restricts the query to todos whose checklist items are
+// all closed. Client.ChecklistComplete is its public surface.
+func (q *todoQuery) checklistComplete() TodoQueryBuilder {
+	return q.withFilter(func(f *database.TaskFilter) { f.ChecklistComplete = true })
+}
+
 // repeatingTemplates restricts the query to repeating templates (rows carrying a
 // recurrence rule), whose start-date filter targets the next occurrence. It is
 // unexported: repeating templates are a database internal, and Upcoming is its
@@ -160,26 +464,137 @@ func (q *todoQuery) repeatingTemplates() TodoQueryBuilder {
 	return q.withFilter(func(f *database.TaskFilter) { f.RepeatingTemplates = new(true) })
 }
 
+// stoppedBetween restricts the query to todos whose stop date falls on or
+// after from and before until, regardless of status. Only completed and
+// canceled todos carry a stop date, so this selects a logbook window.
+func (q *todoQuery) stoppedBetween(from, until time.Time) TodoQueryBuilder {
+	return q.withFilter(func(f *database.TaskFilter) {
+		f.Status = nil
+		f.StopDateFilter = &database.DateFilterValue{Operator: ">=", Date: &from, Until: &until}
+	})
+}
+
+// deadlineBetween restricts the query to todos whose deadline falls on or
+// after from and before until.
+func (q *todoQuery) deadlineBetween(from, until time.Time) TodoQueryBuilder {
+	return q.withFilter(func(f *database.TaskFilter) {
+		f.DeadlineFilter = &database.DateFilterValue{Operator: ">=", Date: &from, Until: &until}
+	})
+}
+
+// ModifiedDate returns a DateFilter on the day of the last modification.
+func (q *todoQuery) ModifiedDate() DateFilter[TodoQueryBuilder] {
+	return &dateFilter[TodoQueryBuilder]{with: q.withFilter, field: dateFieldModifiedDate}
+}
+
 // CreatedAfter filters todos created after the specified time.
 func (q *todoQuery) CreatedAfter(t time.Time) TodoQueryBuilder {
 	return q.withFilter(func(f *database.TaskFilter) { f.CreatedAfter = &t })
 }
 
-// Search filters todos by a search query.
+// CreatedBefore filters todos created at or before the specified time, the
+// complement of CreatedAfter, for finding stale items. A zero time adds no
+// filter.
+func (q *todoQuery) CreatedBefore(t time.Time) TodoQueryBuilder {
+	return q.withFilter(func(f *database.TaskFilter) { f.CreatedBefore = &t })
+}
+
+// ModifiedAfter filters todos last modified after the specified time, for
+// syncing changes since a previous run. Like CreatedAfter it compares whole
+// seconds, so a change within the same second as t is left out; pass a time
+// a second before the last sync to be safe.
+func (q *todoQuery) ModifiedAfter(t time.Time) TodoQueryBuilder {
+	return q.withFilter(func(f *database.TaskFilter) { f.ModifiedAfter = &t })
+}
+
+// Search filters todos by a search query. It adds no status filter, so
+// completed and canceled matches are included unless Status narrows them.
 func (q *todoQuery) Search(query string) TodoQueryBuilder {
-	return q.withFilter(func(f *database.TaskFilter) { f.SearchQuery = &query })
+	c := q.clone()
+	c.inner.setSearch(query, SearchContains, nil)
+	return c
+}
+
+// SearchIn is Search with a match mode and, optionally, the fields to match.
+// Without fields it searches the client's search columns (see
+// WithSearchColumns). A field outside the SearchColumn constants makes the
+// query return ErrInvalidSearchColumn.
+func (q *todoQuery) SearchIn(query string, mode SearchMode, fields ...SearchColumn) TodoQueryBuilder {
+	c := q.clone()
+	c.inner.setSearch(query, mode, fields)
+	return c
 }
 
-// OrderByTodayIndex orders results by today index instead of default index.
+// OrderByIndex ends the ordering with the manual order the user arranged in
+// the app, which is also the default. Ordering methods stack: the index
+// breaks ties left by any OrderBy, OrderByDeadline or OrderByStartDate
+// terms, which OrderByIndex keeps, and replaces an earlier OrderByTodayIndex.
+func (q *todoQuery) OrderByIndex() TodoQueryBuilder {
+	return q.withFilter(func(f *database.TaskFilter) { f.Index = database.IndexDefault })
+}
+
+// OrderByTodayIndex ends the ordering with the Today index instead of the
+// default index. Like OrderByIndex it keeps earlier sort terms.
 func (q *todoQuery) OrderByTodayIndex() TodoQueryBuilder {
 	return q.withFilter(func(f *database.TaskFilter) { f.Index = database.IndexToday })
 }
 
-// Limit restricts the maximum number of results returned.
+// orderByStopDate orders todos by when they were closed. It is unexported
+// because RecentlyCompleted is its public surface.
+func (q *todoQuery) orderByStopDate(desc bool) TodoQueryBuilder {
+	return q.OrderBy(TaskColumnStopDate, sortDir(desc))
+}
+
+// OrderByDeadline adds a deadline sort term, ascending unless desc is set,
+// after any earlier ones; it is OrderBy(TaskColumnDeadline, ...). Todos
+// without a deadline sort last in either direction.
+func (q *todoQuery) OrderByDeadline(desc bool) TodoQueryBuilder {
+	return q.OrderBy(TaskColumnDeadline, sortDir(desc))
+}
+
+// OrderByStartDate adds a start date sort term, ascending unless desc is
+// set, after any earlier ones; it is OrderBy(TaskColumnStartDate, ...).
+// Todos without a start date sort last in either direction.
+func (q *todoQuery) OrderByStartDate(desc bool) TodoQueryBuilder {
+	return q.OrderBy(TaskColumnStartDate, sortDir(desc))
+}
+
+// OrderBy adds a sort term after any earlier ones, so repeated calls and
+// the OrderByDeadline and OrderByStartDate shorthands stack into one
+// multi-column ordering. A column outside the TaskColumn
+// constants makes the query fail with ErrInvalidTaskColumn when it runs.
+// Todos without a start date, deadline or stop date sort last on that
+// column in either direction, and the index chosen by OrderByIndex or
+// OrderByTodayIndex breaks any remaining ties.
+func (q *todoQuery) OrderBy(column TaskColumn, dir SortDir) TodoQueryBuilder {
+	c := q.clone()
+	c.inner.orderBy(column, dir)
+	return c
+}
+
+// Limit restricts the maximum number of results returned; 0 means no limit.
+// Count ignores it.
 func (q *todoQuery) Limit(n int) TodoQueryBuilder {
 	return q.withFilter(func(f *database.TaskFilter) { f.Limit = &n })
 }
 
+// Offset skips the first n results, for paging together with Limit. Count
+// ignores it; First returns the first result after the skipped ones.
+func (q *todoQuery) Offset(n int) TodoQueryBuilder {
+	return q.withFilter(func(f *database.TaskFilter) { f.Offset = &n })
+}
+
+// AfterCursor resumes the query after the last todo of the page that
+// returned cursor as its NextCursor; an empty cursor starts from the top.
+// Unlike Offset it keeps its place when todos are added or removed ahead of
+// it. The cursor only works under the index ordering it was made with, and
+// the query fails with ErrInvalidCursor or ErrCursorOrder otherwise.
+func (q *todoQuery) AfterCursor(cursor string) TodoQueryBuilder {
+	c := q.clone()
+	c.inner.afterCursor(cursor)
+	return c
+}
+
 // IncludeChecklist opts in to loading checklist items for each todo.
 func (q *todoQuery) IncludeChecklist() TodoQueryBuilder {
 	c := q.clone()
@@ -190,63 +605,201 @@ func (q *todoQuery) IncludeChecklist() TodoQueryBuilder {
 // All executes the query and returns all matching todos.
 // The result is never nil; an empty result encodes as a JSON array.
 func (q *todoQuery) All(ctx context.Context) ([]Todo, error) {
-	rows, err := q.inner.database.inner.QueryTasks(ctx, &q.inner.filter)
+	rows, skipped, err := q.inner.queryTasks(ctx)
 	if err != nil {
 		return nil, err
 	}
+	todos, err := q.convertRows(ctx, rows)
+	if err != nil {
+		return nil, err
+	}
+	return todos, skipped
+}
+
+// Page executes the query for at most size todos, ordered as by
+// OrderByIndex or OrderByTodayIndex with ties broken by UUID, and returns
+// them with the cursor for the next page. Pass that cursor to AfterCursor
+// on the same query to continue. Any OrderBy, OrderByDeadline or
+// OrderByStartDate term, and the Today view, fail with ErrCursorOrder. Limit
+// is replaced by size, and Offset skips rows on the first page only, since a
+// cursor already marks where later pages start. Under WithSkipCorruptRows a
+// page still fills to size past unreadable rows and is returned, cursor
+// included, with a *CorruptRowsError.
+//
+// Example:
+//
+//	q := client.Todos().Status().Incomplete()
+//	page, err := q.Page(ctx, 50)
+//	for err == nil && page.NextCursor != "" {
+//		page, err = q.AfterCursor(page.NextCursor).Page(ctx, 50)
+//	}
+func (q *todoQuery) Page(ctx context.Context, size int) (PageResult[Todo], error) {
+	rows, next, skipped, err := q.inner.page(ctx, size)
+	if err != nil {
+		return PageResult[Todo]{}, err
+	}
+	todos, err := q.convertRows(ctx, rows)
+	if err != nil {
+		return PageResult[Todo]{}, err
+	}
+	return PageResult[Todo]{Items: todos, NextCursor: next}, skipped
+}
 
-	todos := make([]Todo, 0, len(rows))
-	for i := range rows {
-		todo := convertTaskRowToTodo(&rows[i])
+// convertRows converts task rows into todos, loading the tags of the whole
+// result, and its checklists when IncludeChecklist is set, in batched
+// queries.
+func (q *todoQuery) convertRows(ctx context.Context, rows []database.TaskRow) ([]Todo, error) {
+	tags, err := q.inner.tagsOf(ctx, rows)
+	if err != nil {
+		return nil, err
+	}
 
-		// Load tags if present
-		if rows[i].HasTags {
-			tags, err := q.inner.database.inner.TagsOfTask(ctx, rows[i].UUID)
-			if err != nil {
-				return nil, err
+	// Load checklists if requested, in one query for the whole result
+	var checklists map[string][]database.ChecklistItemRow
+	if q.inner.includeChecklist {
+		var withChecklist []string
+		for i := range rows {
+			if rows[i].HasChecklist {
+				withChecklist = append(withChecklist, rows[i].UUID)
 			}
-			todo.Tags = tags
 		}
+		checklists, err = q.inner.database.inner.QueryChecklistItemsOfTasks(ctx, withChecklist)
+		if err != nil {
+			return nil, err
+		}
+	}
 
-		// Load checklist if requested
-		if q.inner.includeChecklist && rows[i].HasChecklist {
-			clRows, err := q.inner.database.inner.QueryChecklistItems(ctx, rows[i].UUID)
-			if err != nil {
-				return nil, err
-			}
+	todos := make([]Todo, 0, len(rows))
+	for i := range rows {
+		todo := convertTaskRowToTodo(&rows[i])
+		todo.Tags = tags[rows[i].UUID]
+		if clRows, ok := checklists[rows[i].UUID]; ok {
 			todo.Checklist = convertChecklistItemRows(clRows)
 		}
-
 		todos = append(todos, todo)
 	}
-
 	return todos, nil
 }
 
+// Each executes the query and calls fn with each matching todo in order,
+// ties broken by UUID, so an export of the whole database never holds every
+// todo in memory. Rows are read in chunks, each loaded with its tags, and
+// checklists with IncludeChecklist, in batched queries once its result set
+// is closed, so fn may query the database even with WithMaxOpenConns(1).
+// Each stops at the first error fn returns and returns it unchanged; under
+// WithSkipCorruptRows a *CorruptRowsError is returned after the last todo.
+func (q *todoQuery) Each(ctx context.Context, fn func(Todo) error) error {
+	return q.inner.eachChunk(ctx, func(rows []database.TaskRow) error {
+		todos, err := q.convertRows(ctx, rows)
+		if err != nil {
+			return err
+		}
+		for i := range todos {
+			if err := fn(todos[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 // First executes the query and returns the first matching todo.
 // Unlike All, First always loads the checklist and fetches at most one row.
 // Both adjustments apply to a private copy, leaving the receiver unchanged.
+// Under WithSkipCorruptRows, First passes over unreadable rows and returns
+// the first readable todo with a *CorruptRowsError counting them, or that
+// error alone when no match is readable.
 func (q *todoQuery) First(ctx context.Context) (*Todo, error) {
-	one := 1
+	row, skipped, err := q.inner.firstRow(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if row == nil {
+		if skipped != nil {
+			return nil, skipped
+		}
+		return nil, ErrTodoNotFound
+	}
+
 	c := q.clone()
 	c.inner.includeChecklist = true
-	c.inner.filter.Limit = &one
-
-	todos, err := c.All(ctx)
+	todos, err := c.convertRows(ctx, []database.TaskRow{*row})
 	if err != nil {
 		return nil, err
 	}
-	if len(todos) == 0 {
-		return nil, ErrTodoNotFound
+	return &todos[0], skipped
+}
+
+// FirstOK executes the query like First but reports no match as
+// (nil, false, nil) instead of ErrTodoNotFound, keeping the error for real failures.
+func (q *todoQuery) FirstOK(ctx context.Context) (*Todo, bool, error) {
+	return firstOK(q.First(ctx))
+}
+
+// Around executes the query and returns the todos immediately before and
+// after the one with the given UUID in the query's order. prev is nil at the
+// start of the list and next is nil at the end. It returns ErrTodoNotFound
+// when the UUID is not among the results. Under WithSkipCorruptRows the
+// neighbors are taken among the readable todos, and a *CorruptRowsError is
+// returned with them.
+func (q *todoQuery) Around(ctx context.Context, uuid string) (prev, next *Todo, err error) {
+	var skipped skippedRows
+	todos, err := q.All(ctx)
+	if err := skipped.add(err); err != nil {
+		return nil, nil, err
+	}
+	i := slices.IndexFunc(todos, func(t Todo) bool { return t.UUID == uuid })
+	if i < 0 {
+		return nil, nil, ErrTodoNotFound
 	}
-	return &todos[0], nil
+	if i > 0 {
+		prev = &todos[i-1]
+	}
+	if i < len(todos)-1 {
+		next = &todos[i+1]
+	}
+	return prev, next, skipped.result()
 }
 
 // Count executes the query and returns the count of matching todos.
 func (q *todoQuery) Count(ctx context.Context) (int, error) {
+	if err := q.inner.check(); err != nil {
+		return 0, err
+	}
 	return q.inner.database.inner.CountTasks(ctx, &q.inner.filter)
 }
 
+// countByProject executes the query and returns the count of matching todos
+// per project UUID, including todos filed under the project's headings.
+func (q *todoQuery) countByProject(ctx context.Context) (map[string]int, error) {
+	return q.inner.database.inner.CountTasksBy(ctx, &q.inner.filter, database.GroupByProject)
+}
+
+// CountBy executes the query and returns the count of matching todos for
+// each value of col, in one grouped query. Todos without a value, such as
+// those in no area for GroupByArea, are left out, and a todo with several
+// tags counts once under each for GroupByTag, so only the status and type
+// counts always sum to Count. It returns ErrInvalidGroupColumn for a column
+// outside the GroupBy constants.
+//
+// Example:
+//
+//	perArea, err := client.Todos().Status().Incomplete().CountBy(ctx, things3.GroupByArea)
+func (q *todoQuery) CountBy(ctx context.Context, col GroupColumn) (map[string]int, error) {
+	if err := q.inner.check(); err != nil {
+		return nil, err
+	}
+	return q.inner.database.inner.CountTasksBy(ctx, &q.inner.filter, string(col))
+}
+
+// SQL returns the statement All would run to select the todos, without
+// touching the database. Tags and checklists are loaded by follow-up queries
+// that are not included. Values are inlined, so the statement can be pasted
+// into a SQLite shell as is.
+func (q *todoQuery) SQL() string {
+	return q.inner.database.inner.TasksSQL(&q.inner.filter)
+}
+
 // =============================================================================
 // ProjectQuery Builder
 // =============================================================================
@@ -289,11 +842,31 @@ func (q *projectQuery) WithUUID(uuid string) ProjectQueryBuilder {
 	return q.withFilter(func(f *database.TaskFilter) { f.UUID = &uuid })
 }
 
+// WithUUIDs filters projects to those with any of the given UUIDs, loading
+// several in one query. It replaces an earlier call, and no UUIDs adds no
+// filter. Results keep the index order, not the argument order.
+func (q *projectQuery) WithUUIDs(uuids ...string) ProjectQueryBuilder {
+	return q.withFilter(func(f *database.TaskFilter) {
+		f.UUIDs = nil
+		if len(uuids) > 0 {
+			f.UUIDs = slices.Clone(uuids)
+		}
+	})
+}
+
 // WithUUIDPrefix filters projects by UUID prefix (LIKE match).
 func (q *projectQuery) WithUUIDPrefix(prefix string) ProjectQueryBuilder {
 	return q.withFilter(func(f *database.TaskFilter) { f.UUIDPrefix = &prefix })
 }
 
+// ExcludeUUIDs drops the projects with the given UUIDs from the results.
+// Repeated calls accumulate.
+func (q *projectQuery) ExcludeUUIDs(uuids ...string) ProjectQueryBuilder {
+	return q.withFilter(func(f *database.TaskFilter) {
+		f.ExcludeUUIDs = slices.Concat(f.ExcludeUUIDs, uuids)
+	})
+}
+
 // WithTitle filters projects by title (keyword match).
 func (q *projectQuery) WithTitle(title string) ProjectQueryBuilder {
 	return q.withFilter(func(f *database.TaskFilter) { f.Title = &title })
@@ -334,6 +907,54 @@ func (q *projectQuery) HasTag(has bool) ProjectQueryBuilder {
 	return q.withFilter(func(f *database.TaskFilter) { f.HasTags = &has })
 }
 
+// IncludeRecurring controls whether repeating templates, the hidden rows that
+// schedule each next occurrence of a repeating project, are returned alongside
+// regular projects. They are left out by default, as in the app's lists. A
+// template carries its schedule in RecurrenceRule.
+func (q *projectQuery) IncludeRecurring(include bool) ProjectQueryBuilder {
+	return q.withFilter(func(f *database.TaskFilter) { f.IncludeRecurring = include })
+}
+
+// InAllTags filters projects tagged with every one of the titles. Like
+// InAnyTags, it replaces an earlier call and matches nothing with no titles.
+// Only tags on a project itself count, as with InTag, and combining this with
+// HasTag(false) matches nothing.
+func (q *projectQuery) InAllTags(titles ...string) ProjectQueryBuilder {
+	return q.withFilter(func(f *database.TaskFilter) { f.AllTags = append([]string{}, titles...) })
+}
+
+// InAnyTags filters projects tagged with at least one of the titles. Like
+// InAllTags, it replaces an earlier call and matches nothing with no titles,
+// and combining it with HasTag(false) matches nothing.
+func (q *projectQuery) InAnyTags(titles ...string) ProjectQueryBuilder {
+	return q.withFilter(func(f *database.TaskFilter) { f.AnyTags = append([]string{}, titles...) })
+}
+
+// NotInTag filters out projects tagged with the title, keeping untagged ones.
+// Repeated calls exclude each title; an empty title adds no filter. Like
+// InTag it looks only at a project's own tags.
+func (q *projectQuery) NotInTag(title string) ProjectQueryBuilder {
+	if title == "" {
+		return q
+	}
+	return q.withFilter(func(f *database.TaskFilter) { f.ExcludeTags = slices.Concat(f.ExcludeTags, []string{title}) })
+}
+
+// WhereRaw adds a raw SQL condition with each ? bound to an escaped literal
+// from args. Like the todo WhereRaw, expr is trusted and must use the query's
+// table aliases, and a placeholder count that differs from args makes the
+// query return ErrRawArgCount.
+func (q *projectQuery) WhereRaw(expr string, args ...any) ProjectQueryBuilder {
+	bound, err := database.BindSQL(expr, args...)
+	c := q.clone()
+	if err != nil {
+		c.inner.fail(err)
+		return c
+	}
+	c.inner.filter.Raw = slices.Concat(c.inner.filter.Raw, []string{bound})
+	return c
+}
+
 // StartDate returns a DateFilter for start date filtering.
 func (q *projectQuery) StartDate() DateFilter[ProjectQueryBuilder] {
 	return &dateFilter[ProjectQueryBuilder]{with: q.withFilter, field: dateFieldStartDate}
@@ -349,25 +970,168 @@ func (q *projectQuery) Deadline() DateFilter[ProjectQueryBuilder] {
 	return &dateFilter[ProjectQueryBuilder]{with: q.withFilter, field: dateFieldDeadline}
 }
 
+// ModifiedDate returns a DateFilter on the day of the last modification.
+func (q *projectQuery) ModifiedDate() DateFilter[ProjectQueryBuilder] {
+	return &dateFilter[ProjectQueryBuilder]{with: q.withFilter, field: dateFieldModifiedDate}
+}
+
 // CreatedAfter filters projects created after the specified time.
 func (q *projectQuery) CreatedAfter(t time.Time) ProjectQueryBuilder {
 	return q.withFilter(func(f *database.TaskFilter) { f.CreatedAfter = &t })
 }
 
-// Search filters projects by a search query.
+// CreatedBefore filters projects created at or before the specified time, the
+// complement of CreatedAfter, for finding stale items. A zero time adds no
+// filter.
+func (q *projectQuery) CreatedBefore(t time.Time) ProjectQueryBuilder {
+	return q.withFilter(func(f *database.TaskFilter) { f.CreatedBefore = &t })
+}
+
+// ModifiedAfter filters projects last modified after the specified time, for
+// syncing changes since a previous run. Like CreatedAfter it compares whole
+// seconds, so a change within the same second as t is left out; pass a time
+// a second before the last sync to be safe.
+func (q *projectQuery) ModifiedAfter(t time.Time) ProjectQueryBuilder {
+	return q.withFilter(func(f *database.TaskFilter) { f.ModifiedAfter = &t })
+}
+
+// Search filters projects by a search query. Like the todo Search, it
+// matches every status unless Status narrows it.
 func (q *projectQuery) Search(query string) ProjectQueryBuilder {
-	return q.withFilter(func(f *database.TaskFilter) { f.SearchQuery = &query })
+	c := q.clone()
+	c.inner.setSearch(query, SearchContains, nil)
+	return c
+}
+
+// SearchIn is the project counterpart of the todo SearchIn.
+func (q *projectQuery) SearchIn(query string, mode SearchMode, fields ...SearchColumn) ProjectQueryBuilder {
+	c := q.clone()
+	c.inner.setSearch(query, mode, fields)
+	return c
+}
+
+// setSearch sets the search filter shared by Search and SearchIn. An unknown
+// field is kept as ErrInvalidSearchColumn for the query to report.
+func (q *taskQuery) setSearch(query string, mode SearchMode, fields []SearchColumn) {
+	f := &q.filter
+	f.SearchQuery = &query
+	f.SearchMode = mode
+	f.SearchFields = nil
+	for _, field := range fields {
+		if err := database.CheckColumn(ErrInvalidSearchColumn, field, searchColumns); err != nil {
+			q.fail(err)
+			continue
+		}
+		f.SearchFields = append(f.SearchFields, string(field))
+	}
+}
+
+// OrderByIndex ends the ordering with the manual order the user arranged in
+// the app, which is also the default. Ordering methods stack: the index
+// breaks ties left by any OrderBy, OrderByDeadline or OrderByStartDate
+// terms, which OrderByIndex keeps, and replaces an earlier OrderByTodayIndex.
+func (q *projectQuery) OrderByIndex() ProjectQueryBuilder {
+	return q.withFilter(func(f *database.TaskFilter) { f.Index = database.IndexDefault })
+}
+
+// OrderByTodayIndex ends the ordering with the Today index instead of the
+// default index. Like OrderByIndex it keeps earlier sort terms.
+func (q *projectQuery) OrderByTodayIndex() ProjectQueryBuilder {
+	return q.withFilter(func(f *database.TaskFilter) { f.Index = database.IndexToday })
+}
+
+// deadlineSuppressed filters projects by whether the deadline has been
+// suppressed. Like its todo counterpart it only serves Today.
+func (q *projectQuery) deadlineSuppressed(suppressed bool) ProjectQueryBuilder {
+	return q.withFilter(func(f *database.TaskFilter) { f.DeadlineSuppressed = &suppressed })
 }
 
-// Limit restricts the maximum number of results returned.
+// orderByStopDate orders projects by when they were closed. It is unexported
+// because only LoggedProjects needs it.
+func (q *projectQuery) orderByStopDate(desc bool) ProjectQueryBuilder {
+	return q.OrderBy(TaskColumnStopDate, sortDir(desc))
+}
+
+// OrderByDeadline adds a deadline sort term, ascending unless desc is set,
+// after any earlier ones; it is OrderBy(TaskColumnDeadline, ...). Projects
+// without a deadline sort last in either direction.
+func (q *projectQuery) OrderByDeadline(desc bool) ProjectQueryBuilder {
+	return q.OrderBy(TaskColumnDeadline, sortDir(desc))
+}
+
+// OrderByStartDate adds a start date sort term, ascending unless desc is
+// set, after any earlier ones; it is OrderBy(TaskColumnStartDate, ...).
+// Projects without a start date sort last in either direction.
+func (q *projectQuery) OrderByStartDate(desc bool) ProjectQueryBuilder {
+	return q.OrderBy(TaskColumnStartDate, sortDir(desc))
+}
+
+// OrderBy adds a sort term after any earlier ones, so repeated calls and
+// the OrderByDeadline and OrderByStartDate shorthands stack into one
+// multi-column ordering. A column outside the TaskColumn
+// constants makes the query fail with ErrInvalidTaskColumn when it runs.
+// Projects without a start date, deadline or stop date sort last on that
+// column in either direction, and the index chosen by OrderByIndex or
+// OrderByTodayIndex breaks any remaining ties.
+func (q *projectQuery) OrderBy(column TaskColumn, dir SortDir) ProjectQueryBuilder {
+	c := q.clone()
+	c.inner.orderBy(column, dir)
+	return c
+}
+
+// Limit restricts the maximum number of results returned; 0 means no limit.
+// Count ignores it.
 func (q *projectQuery) Limit(n int) ProjectQueryBuilder {
 	return q.withFilter(func(f *database.TaskFilter) { f.Limit = &n })
 }
 
+// Offset skips the first n results, for paging together with Limit. Count
+// ignores it; First returns the first result after the skipped ones.
+func (q *projectQuery) Offset(n int) ProjectQueryBuilder {
+	return q.withFilter(func(f *database.TaskFilter) { f.Offset = &n })
+}
+
+// AfterCursor resumes the query after the last project of the page that
+// returned cursor; see the todo AfterCursor.
+func (q *projectQuery) AfterCursor(cursor string) ProjectQueryBuilder {
+	c := q.clone()
+	c.inner.afterCursor(cursor)
+	return c
+}
+
 // All executes the query and returns all matching projects.
 // The result is never nil; an empty result encodes as a JSON array.
 func (q *projectQuery) All(ctx context.Context) ([]Project, error) {
-	rows, err := q.inner.database.inner.QueryTasks(ctx, &q.inner.filter)
+	rows, skipped, err := q.inner.queryTasks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	projects, err := q.convertRows(ctx, rows)
+	if err != nil {
+		return nil, err
+	}
+	return projects, skipped
+}
+
+// Page executes the query for at most size projects and returns them with
+// the cursor for the next page; see the todo Page. As there, any OrderBy,
+// OrderByDeadline or OrderByStartDate term fails with ErrCursorOrder.
+func (q *projectQuery) Page(ctx context.Context, size int) (PageResult[Project], error) {
+	rows, next, skipped, err := q.inner.page(ctx, size)
+	if err != nil {
+		return PageResult[Project]{}, err
+	}
+	projects, err := q.convertRows(ctx, rows)
+	if err != nil {
+		return PageResult[Project]{}, err
+	}
+	return PageResult[Project]{Items: projects, NextCursor: next}, skipped
+}
+
+// convertRows converts task rows into projects, loading the tags of the
+// whole result in batched queries.
+func (q *projectQuery) convertRows(ctx context.Context, rows []database.TaskRow) ([]Project, error) {
+	tags, err := q.inner.tagsOf(ctx, rows)
 	if err != nil {
 		return nil, err
 	}
@@ -375,44 +1139,83 @@ func (q *projectQuery) All(ctx context.Context) ([]Project, error) {
 	projects := make([]Project, 0, len(rows))
 	for i := range rows {
 		project := convertTaskRowToProject(&rows[i])
-
-		// Load tags if present
-		if rows[i].HasTags {
-			tags, err := q.inner.database.inner.TagsOfTask(ctx, rows[i].UUID)
-			if err != nil {
-				return nil, err
-			}
-			project.Tags = tags
-		}
-
+		project.Tags = tags[rows[i].UUID]
 		projects = append(projects, project)
 	}
-
 	return projects, nil
 }
 
+// Each executes the query and calls fn with each matching project in order,
+// reading rows in chunks with their tags; see todoQuery.Each. A project's
+// todos are not loaded, so walking them means a Todos().InProject query per
+// project inside fn.
+func (q *projectQuery) Each(ctx context.Context, fn func(Project) error) error {
+	return q.inner.eachChunk(ctx, func(rows []database.TaskRow) error {
+		projects, err := q.convertRows(ctx, rows)
+		if err != nil {
+			return err
+		}
+		for i := range projects {
+			if err := fn(projects[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 // First executes the query and returns the first matching project.
 // It fetches at most one row via a private copy, leaving the receiver unchanged.
+// Corrupt rows are passed over as in the todo First.
 func (q *projectQuery) First(ctx context.Context) (*Project, error) {
-	one := 1
-	c := q.clone()
-	c.inner.filter.Limit = &one
-
-	projects, err := c.All(ctx)
+	row, skipped, err := q.inner.firstRow(ctx)
 	if err != nil {
 		return nil, err
 	}
-	if len(projects) == 0 {
+	if row == nil {
+		if skipped != nil {
+			return nil, skipped
+		}
 		return nil, ErrProjectNotFound
 	}
-	return &projects[0], nil
+
+	projects, err := q.convertRows(ctx, []database.TaskRow{*row})
+	if err != nil {
+		return nil, err
+	}
+	return &projects[0], skipped
+}
+
+// FirstOK executes the query like First but reports no match as
+// (nil, false, nil) instead of ErrProjectNotFound, keeping the error for real failures.
+func (q *projectQuery) FirstOK(ctx context.Context) (*Project, bool, error) {
+	return firstOK(q.First(ctx))
 }
 
 // Count executes the query and returns the count of matching projects.
 func (q *projectQuery) Count(ctx context.Context) (int, error) {
+	if err := q.inner.check(); err != nil {
+		return 0, err
+	}
 	return q.inner.database.inner.CountTasks(ctx, &q.inner.filter)
 }
 
+// CountBy executes the query and returns the count of matching projects for
+// each value of col. Like the todo CountBy, projects without a value are left
+// out and a tagged project counts once per tag.
+func (q *projectQuery) CountBy(ctx context.Context, col GroupColumn) (map[string]int, error) {
+	if err := q.inner.check(); err != nil {
+		return nil, err
+	}
+	return q.inner.database.inner.CountTasksBy(ctx, &q.inner.filter, string(col))
+}
+
+// SQL returns the statement All would run to select the projects, without
+// touching the database. The follow-up tag query is not included.
+func (q *projectQuery) SQL() string {
+	return q.inner.database.inner.TasksSQL(&q.inner.filter)
+}
+
 // =============================================================================
 // HeadingQuery Builder
 // =============================================================================
@@ -463,17 +1266,34 @@ func (q *headingQuery) InProject(uuid string) HeadingQueryBuilder {
 	return c
 }
 
-// Limit restricts the maximum number of results returned.
+// open restricts the query to headings that have not been archived. It is
+// unexported because Heading carries no status; ProjectOutline is its only
+// consumer.
+func (q *headingQuery) open() HeadingQueryBuilder {
+	c := q.clone()
+	status := int(StatusIncomplete)
+	c.inner.filter.Status = &status
+	return c
+}
+
+// Limit restricts the maximum number of results returned; 0 means no limit.
 func (q *headingQuery) Limit(n int) HeadingQueryBuilder {
 	c := q.clone()
 	c.inner.filter.Limit = &n
 	return c
 }
 
+// Offset skips the first n results, for paging together with Limit.
+func (q *headingQuery) Offset(n int) HeadingQueryBuilder {
+	c := q.clone()
+	c.inner.filter.Offset = &n
+	return c
+}
+
 // All executes the query and returns all matching headings.
 // The result is never nil; an empty result encodes as a JSON array.
 func (q *headingQuery) All(ctx context.Context) ([]Heading, error) {
-	rows, err := q.inner.database.inner.QueryTasks(ctx, &q.inner.filter)
+	rows, skipped, err := q.inner.queryTasks(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -483,24 +1303,32 @@ func (q *headingQuery) All(ctx context.Context) ([]Heading, error) {
 		headings[i] = convertTaskRowToHeading(&rows[i])
 	}
 
-	return headings, nil
+	return headings, skipped
 }
 
 // First executes the query and returns the first matching heading.
 // It fetches at most one row via a private copy, leaving the receiver unchanged.
+// Corrupt rows are passed over as in the todo First.
 func (q *headingQuery) First(ctx context.Context) (*Heading, error) {
-	one := 1
-	c := q.clone()
-	c.inner.filter.Limit = &one
-
-	headings, err := c.All(ctx)
+	row, skipped, err := q.inner.firstRow(ctx)
 	if err != nil {
 		return nil, err
 	}
-	if len(headings) == 0 {
+	if row == nil {
+		if skipped != nil {
+			return nil, skipped
+		}
 		return nil, ErrHeadingNotFound
 	}
-	return &headings[0], nil
+
+	heading := convertTaskRowToHeading(row)
+	return &heading, skipped
+}
+
+// FirstOK executes the query like First but reports no match as
+// (nil, false, nil) instead of ErrHeadingNotFound, keeping the error for real failures.
+func (q *headingQuery) FirstOK(ctx context.Context) (*Heading, bool, error) {
+	return firstOK(q.First(ctx))
 }
 
 // Count executes the query and returns the count of matching headings.
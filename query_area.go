@@ -34,6 +34,16 @@ func (q *areaQuery) WithUUID(uuid string) AreaQueryBuilder {
 	return c
 }
 
+// WithUUIDs filters areas to those with any of the given UUIDs, loading
+// several in one query. Like WithUUID it replaces an earlier call; with no
+// UUIDs it matches no areas. Results keep the area list order, not the
+// argument order.
+func (q *areaQuery) WithUUIDs(uuids ...string) AreaQueryBuilder {
+	c := q.clone()
+	c.filter.UUIDs = append(make([]string, 0, len(uuids)), uuids...)
+	return c
+}
+
 // WithTitle filters areas by title.
 func (q *areaQuery) WithTitle(title string) AreaQueryBuilder {
 	c := q.clone()
@@ -64,6 +74,20 @@ func (q *areaQuery) HasTag(has bool) AreaQueryBuilder {
 	return c
 }
 
+// Limit restricts the maximum number of results returned; 0 means no limit.
+func (q *areaQuery) Limit(n int) AreaQueryBuilder {
+	c := q.clone()
+	c.filter.Limit = &n
+	return c
+}
+
+// Offset skips the first n results, for paging together with Limit.
+func (q *areaQuery) Offset(n int) AreaQueryBuilder {
+	c := q.clone()
+	c.filter.Offset = &n
+	return c
+}
+
 // All executes the query and returns all matching areas.
 // The result is never nil; an empty result encodes as a JSON array.
 func (q *areaQuery) All(ctx context.Context) ([]Area, error) {
@@ -103,7 +127,21 @@ func (q *areaQuery) First(ctx context.Context) (*Area, error) {
 	return &areas[0], nil
 }
 
+// FirstOK executes the query like First but reports no match as
+// (nil, false, nil) instead of ErrAreaNotFound, keeping the error for real failures.
+func (q *areaQuery) FirstOK(ctx context.Context) (*Area, bool, error) {
+	return firstOK(q.First(ctx))
+}
+
 // Count executes the query and returns the count of matching areas.
 func (q *areaQuery) Count(ctx context.Context) (int, error) {
 	return q.database.inner.CountAreas(ctx, q.filter)
 }
+
+// SQL returns the statement All would run to select the areas, without
+// touching the database. Area tags are loaded by follow-up queries that are
+// not included. Values are inlined, so the statement can be pasted into a
+// SQLite shell as is.
+func (q *areaQuery) SQL() string {
+	return q.database.inner.AreasSQL(q.filter)
+}
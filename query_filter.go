@@ -17,6 +17,7 @@ const (
 	dateFieldStartDate dateField = iota
 	dateFieldStopDate
 	dateFieldDeadline
+	dateFieldModifiedDate
 )
 
 // withTaskFilter clones the parent builder, applies a mutation to the clone's
@@ -134,6 +135,13 @@ func (f *dateFilter[T]) OnOrAfter(date time.Time) T {
 	return f.set(&database.DateFilterValue{Operator: ">=", Date: &date})
 }
 
+// Between filters for dates from start through end, inclusive on both ends.
+// Only the calendar date of each bound counts, and an end before start
+// matches nothing.
+func (f *dateFilter[T]) Between(start, end time.Time) T {
+	return f.set(&database.DateFilterValue{Operator: ">=", Date: &start, Through: &end})
+}
+
 // set forks the parent with the filter value applied to the appropriate field.
 func (f *dateFilter[T]) set(v *database.DateFilterValue) T {
 	field := f.field
@@ -145,6 +153,8 @@ func (f *dateFilter[T]) set(v *database.DateFilterValue) T {
 			tf.StopDateFilter = v
 		case dateFieldDeadline:
 			tf.DeadlineFilter = v
+		case dateFieldModifiedDate:
+			tf.ModifiedDateFilter = v
 		}
 	})
 }
@@ -49,6 +49,36 @@ func (q *tagQuery) WithParent(parentUUID string) TagQueryBuilder {
 	return c
 }
 
+// usedInArea limits the query to tags applied to tasks in the area. It is
+// unexported because Client.TagsUsedInArea is its public surface.
+func (q *tagQuery) usedInArea(areaUUID string) *tagQuery {
+	c := q.clone()
+	c.filter.UsedInArea = &areaUUID
+	return c
+}
+
+// unused limits the query to tags applied to no task and no area. It is
+// unexported because Client.UnusedTags is its public surface.
+func (q *tagQuery) unused() *tagQuery {
+	c := q.clone()
+	c.filter.Unused = true
+	return c
+}
+
+// Limit restricts the maximum number of results returned; 0 means no limit.
+func (q *tagQuery) Limit(n int) TagQueryBuilder {
+	c := q.clone()
+	c.filter.Limit = &n
+	return c
+}
+
+// Offset skips the first n results, for paging together with Limit.
+func (q *tagQuery) Offset(n int) TagQueryBuilder {
+	c := q.clone()
+	c.filter.Offset = &n
+	return c
+}
+
 // All executes the query and returns all matching tags.
 // The result is never nil; an empty result encodes as a JSON array.
 func (q *tagQuery) All(ctx context.Context) ([]Tag, error) {
@@ -76,3 +106,43 @@ func (q *tagQuery) First(ctx context.Context) (*Tag, error) {
 	}
 	return &tags[0], nil
 }
+
+// FirstOK executes the query like First but reports no match as
+// (nil, false, nil) instead of ErrTagNotFound, keeping the error for real failures.
+func (q *tagQuery) FirstOK(ctx context.Context) (*Tag, bool, error) {
+	return firstOK(q.First(ctx))
+}
+
+// Count executes the query and returns the count of matching tags.
+func (q *tagQuery) Count(ctx context.Context) (int, error) {
+	return q.database.inner.CountTags(ctx, q.filter)
+}
+
+// SQL returns the statement All would run, without touching the database.
+// Values are inlined, so the statement can be pasted into a SQLite shell as
+// is.
+func (q *tagQuery) SQL() string {
+	return q.database.inner.TagsSQL(q.filter)
+}
+
+// Children executes the query and returns the tags nested directly under the
+// matching tags, in sidebar order. Call it again on a child to walk deeper.
+// The result is never nil.
+//
+// Example:
+//
+//	children, err := client.Tags().WithTitle("Work").Children(ctx)
+func (q *tagQuery) Children(ctx context.Context) ([]Tag, error) {
+	parents, err := q.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	uuids := make([]string, len(parents))
+	for i, parent := range parents {
+		uuids[i] = parent.UUID
+	}
+
+	children := q.database.Tags()
+	children.filter.ParentUUIDs = uuids
+	return children.All(ctx)
+}
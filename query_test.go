@@ -1,7 +1,11 @@
 package things3
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
@@ -166,6 +170,151 @@ func TestTodoQueryInTag(t *testing.T) {
 	}
 }
 
+func TestTodoQueryInAllAnyTags(t *testing.T) {
+	db := newTestDB(t)
+	ctx := t.Context()
+
+	tests := []struct {
+		name  string
+		query TodoQueryBuilder
+		want  []string
+	}{
+		{"all of two tags on one todo", db.Todos().InAllTags("Home", "Errand"), []string{testUUIDTodoInArea1Tags}},
+		{"all repeated title counts once", db.Todos().InAllTags("Home", "Home"), []string{testUUIDTodoInArea1Tags}},
+		{"all replaces earlier call", db.Todos().InAllTags("Office").InAllTags("Home", "Errand"), []string{testUUIDTodoInArea1Tags}},
+		{"all of tags on different todos", db.Todos().InAllTags("Home", "Office"), []string{}},
+		{"all with no titles matches nothing", db.Todos().InAllTags(), []string{}},
+		{
+			"any of two tags",
+			db.Todos().InAnyTags("Office", "Important"),
+			[]string{testUUIDTodoInToday, testUUIDTodoInProject},
+		},
+		{"any replaces earlier call", db.Todos().InAnyTags("Office").InAnyTags("Errand"), []string{testUUIDTodoInArea1Tags}},
+		{"any with no titles matches nothing", db.Todos().InAnyTags(), []string{}},
+		{"any with HasTag(false) matches nothing", db.Todos().InAnyTags("Office").HasTag(false), []string{}},
+		{"all and any compose", db.Todos().InAllTags("Home").InAnyTags("Errand", "Office"), []string{testUUIDTodoInArea1Tags}},
+		{"not in tag with any", db.Todos().InAnyTags("Office", "Important").NotInTag("Office"), []string{testUUIDTodoInProject}},
+		{"not in tag drops one of two tags", db.Todos().InTag("Home").NotInTag("Errand"), []string{}},
+		{"not in tag calls accumulate", db.Todos().InAnyTags("Office", "Important").NotInTag("Office").NotInTag("Important"), []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			todos, err := tt.query.All(ctx)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.want, extractTodoUUIDs(todos))
+		})
+	}
+}
+
+func TestTodoQueryNotInTag(t *testing.T) {
+	db := newTestDB(t)
+	ctx := t.Context()
+
+	all, err := db.Todos().Status().Incomplete().All(ctx)
+	require.NoError(t, err)
+	require.Contains(t, extractTodoUUIDs(all), testUUIDTodoInToday)
+
+	todos, err := db.Todos().Status().Incomplete().NotInTag("Office").All(ctx)
+	require.NoError(t, err)
+	uuids := extractTodoUUIDs(todos)
+	assert.NotContains(t, uuids, testUUIDTodoInToday, "the Office todo is excluded")
+	assert.Contains(t, uuids, testUUIDTodoInArea1Tags, "todos with other tags stay")
+	assert.Contains(t, uuids, testUUIDTodoInbox, "untagged todos stay")
+	assert.Len(t, todos, len(all)-1)
+
+	unfiltered, err := db.Todos().Status().Incomplete().NotInTag("").Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, len(all), unfiltered, "an empty title adds no filter")
+}
+
+func TestTodoQueryWhereRaw(t *testing.T) {
+	db := newTestDB(t)
+	ctx := t.Context()
+
+	t.Run("parameterized like", func(t *testing.T) {
+		todos, err := db.Todos().WhereRaw("TASK.title LIKE ?", "%in Today").Status().Any().All(ctx)
+		require.NoError(t, err)
+		require.NotEmpty(t, todos)
+		assert.Contains(t, extractTodoUUIDs(todos), testUUIDTodoInToday)
+		for _, todo := range todos {
+			assert.True(t, strings.HasSuffix(todo.Title, "in Today"), todo.Title)
+		}
+	})
+
+	t.Run("numeric comparison", func(t *testing.T) {
+		raw, err := db.Todos().WhereRaw("TASK.status = ?", int(StatusCompleted)).Count(ctx)
+		require.NoError(t, err)
+		built, err := db.Todos().Status().Completed().Count(ctx)
+		require.NoError(t, err)
+		assert.Positive(t, raw)
+		assert.Equal(t, built, raw)
+	})
+
+	t.Run("conditions accumulate", func(t *testing.T) {
+		todos, err := db.Todos().
+			WhereRaw("TASK.title LIKE ?", "%in Today").
+			WhereRaw("TASK.status = ?", 0).
+			All(ctx)
+		require.NoError(t, err)
+		assert.Contains(t, extractTodoUUIDs(todos), testUUIDTodoInToday)
+		for _, todo := range todos {
+			assert.True(t, strings.HasSuffix(todo.Title, "in Today"), todo.Title)
+			assert.Equal(t, StatusIncomplete, todo.Status, todo.Title)
+		}
+	})
+
+	t.Run("args cannot inject", func(t *testing.T) {
+		todos, err := db.Todos().WhereRaw("TASK.title = ?", "x' OR '1'='1").All(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, todos)
+	})
+
+	t.Run("placeholder mismatch is returned", func(t *testing.T) {
+		query := db.Todos().WhereRaw("TASK.title = ?")
+		_, err := query.All(ctx)
+		require.ErrorIs(t, err, ErrRawArgCount)
+		_, err = query.Count(ctx)
+		require.ErrorIs(t, err, ErrRawArgCount)
+		_, err = query.First(ctx)
+		require.ErrorIs(t, err, ErrRawArgCount)
+		err = query.Each(ctx, func(Todo) error { return nil })
+		require.ErrorIs(t, err, ErrRawArgCount)
+
+		_, err = db.Projects().WhereRaw("TASK.title = ?", 1, 2).All(ctx)
+		require.ErrorIs(t, err, ErrRawArgCount)
+	})
+}
+
+func TestTodoQueryIncludeRecurring(t *testing.T) {
+	db := newTestDB(t)
+	ctx := t.Context()
+	const templateUUID = "N1PJHsbjct4mb1bhcs7aHa"
+
+	plain, err := db.Todos().All(ctx)
+	require.NoError(t, err)
+	assert.NotContains(t, extractTodoUUIDs(plain), templateUUID, "templates are excluded by default")
+
+	todos, err := db.Todos().IncludeRecurring(true).All(ctx)
+	require.NoError(t, err)
+	assert.Len(t, todos, len(plain)+1)
+
+	byUUID := make(map[string]Todo, len(todos))
+	for _, todo := range todos {
+		byUUID[todo.UUID] = todo
+	}
+	template, ok := byUUID[templateUUID]
+	require.True(t, ok, "IncludeRecurring(true) returns the template")
+	want := &RecurrenceRule{Frequency: FrequencyWeekly, Interval: 1, Weekdays: []time.Weekday{time.Sunday}}
+	assert.Equal(t, want, template.RecurrenceRule)
+	assert.Equal(t, want, byUUID[testUUIDTodoRepeating].RecurrenceRule, "an instance reports its template's rule")
+	assert.Nil(t, byUUID[testUUIDTodoInbox].RecurrenceRule)
+
+	reset, err := db.Todos().IncludeRecurring(true).IncludeRecurring(false).All(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, extractTodoUUIDs(plain), extractTodoUUIDs(reset))
+}
+
 func TestTodoQueryWithDeadline(t *testing.T) {
 	db := newTestDB(t)
 	ctx := t.Context()
@@ -250,6 +399,87 @@ func TestTodoQuerySearch(t *testing.T) {
 	assert.Empty(t, todos)
 }
 
+func TestTodoQuerySearchIn(t *testing.T) {
+	db := newTestDB(t)
+	ctx := t.Context()
+
+	todos, err := db.Todos().SearchIn("to-do in", SearchPrefix, SearchColumnTitle).All(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, todos)
+	for i := range todos {
+		assert.True(t, strings.HasPrefix(todos[i].Title, "To-Do in"), todos[i].Title)
+	}
+
+	// A mid-title fragment is not a prefix.
+	todos, err = db.Todos().SearchIn("in Inbox", SearchPrefix, SearchColumnTitle).All(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, todos)
+
+	todos, err = db.Todos().SearchIn("To-Do in Inbox", SearchExact, SearchColumnTitle).All(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{testUUIDTodoInbox}, extractTodoUUIDs(todos))
+
+	// Wildcards match literally even in exact mode.
+	todos, err = db.Todos().SearchIn("To-Do in %", SearchExact, SearchColumnTitle).All(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, todos)
+
+	// Notes are searched by default but not when scoped to titles.
+	todos, err = db.Todos().SearchIn("notes", SearchContains).All(ctx)
+	require.NoError(t, err)
+	assert.NotEmpty(t, todos)
+	todos, err = db.Todos().SearchIn("notes", SearchContains, SearchColumnTitle).All(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, todos)
+
+	_, err = db.Todos().SearchIn("To-Do", SearchContains, SearchColumnTitle, SearchColumn("bogus")).All(ctx)
+	require.ErrorIs(t, err, ErrInvalidSearchColumn)
+	require.ErrorIs(t, err, ErrInvalidColumn)
+	_, err = db.Projects().SearchIn("Project", SearchContains, SearchColumn("bogus")).Count(ctx)
+	require.ErrorIs(t, err, ErrInvalidSearchColumn)
+}
+
+func TestTodoQuerySearchLikeWildcards(t *testing.T) {
+	dbPath := copyWritableFixture(t)
+	execFixtureSQL(t, dbPath, "UPDATE TMTask SET title = '50% off' WHERE uuid = ?", testUUIDTodoInbox)
+	execFixtureSQL(t, dbPath, "UPDATE TMTask SET title = '500 items' WHERE uuid = ?", testUUIDTodoInToday)
+	client, err := NewClient(WithDatabasePath(dbPath))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	ctx := t.Context()
+
+	// "50%" matches only the literal percent sign, not "500 items".
+	todos, err := client.Todos().Search("50%").All(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{testUUIDTodoInbox}, extractTodoUUIDs(todos))
+
+	todos, err = client.Todos().SearchIn("50_", SearchContains, SearchColumnTitle).All(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, todos)
+
+	// SearchRawLike keeps the wildcards active.
+	todos, err = client.Todos().SearchIn("50%", SearchRawLike, SearchColumnTitle).All(ctx)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{testUUIDTodoInbox, testUUIDTodoInToday}, extractTodoUUIDs(todos))
+
+	todos, err = client.Todos().SearchIn(`50\%%`, SearchRawLike, SearchColumnTitle).All(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{testUUIDTodoInbox}, extractTodoUUIDs(todos))
+}
+
+func TestTodoQuerySearchMatchesEveryStatus(t *testing.T) {
+	db := newTestDB(t)
+
+	todos, err := db.Todos().Search("To-Do in Today").All(t.Context())
+	require.NoError(t, err)
+	statuses := make(map[Status]bool)
+	for i := range todos {
+		statuses[todos[i].Status] = true
+	}
+	assert.Equal(t, map[Status]bool{StatusIncomplete: true, StatusCompleted: true, StatusCanceled: true}, statuses,
+		"without Status, Search finds closed todos too")
+}
+
 func TestTodoQueryCreatedAfter(t *testing.T) {
 	db := newTestDB(t)
 	ctx := t.Context()
@@ -276,6 +506,65 @@ func TestTodoQueryCreatedAfter(t *testing.T) {
 	}
 }
 
+func TestTodoQueryCreatedBefore(t *testing.T) {
+	db := newTestDB(t)
+	ctx := t.Context()
+
+	// "To-Do in Inbox" was created at 2021-03-28 19:10:29.448 UTC; the filter
+	// compares whole seconds and includes the boundary.
+	created := time.Date(2021, time.March, 28, 19, 10, 29, 0, time.UTC)
+	at, err := db.Todos().CreatedBefore(created).Status().Any().All(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, extractTodoUUIDs(at), testUUIDTodoInbox)
+	for _, todo := range at {
+		assert.False(t, todo.CreatedAt.After(created.Add(time.Second)),
+			"CreatedAt %v should be at or before %v", todo.CreatedAt, created)
+	}
+
+	before, err := db.Todos().CreatedBefore(created.Add(-time.Second)).Status().Any().All(ctx)
+	require.NoError(t, err)
+	assert.NotContains(t, extractTodoUUIDs(before), testUUIDTodoInbox)
+
+	all, err := db.Todos().Status().Any().Count(ctx)
+	require.NoError(t, err)
+	after, err := db.Todos().CreatedAfter(created).Status().Any().Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, all, len(at)+after, "CreatedBefore and CreatedAfter partition the todos")
+
+	unfiltered, err := db.Todos().CreatedBefore(time.Time{}).Status().Any().Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, all, unfiltered, "a zero time adds no filter")
+}
+
+func TestTodoQueryModifiedAfter(t *testing.T) {
+	db := newTestDB(t)
+	ctx := t.Context()
+
+	boundary := time.Date(2022, time.January, 1, 0, 0, 0, 0, time.Local)
+	todos, err := db.Todos().ModifiedAfter(boundary).Status().Any().All(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, todos)
+	for _, todo := range todos {
+		assert.True(t, todo.ModifiedAt.After(boundary),
+			"ModifiedAt %v should be after %v", todo.ModifiedAt, boundary)
+	}
+	uuids := extractTodoUUIDs(todos)
+	assert.Contains(t, uuids, testUUIDTodoInArea1Tags, "modified in 2023")
+	assert.NotContains(t, uuids, testUUIDTodoInProject, "last modified in 2021")
+
+	all, err := db.Todos().Status().Any().Count(ctx)
+	require.NoError(t, err)
+	older, err := db.Todos().ModifiedDate().Before(boundary).Status().Any().Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, all, older+len(todos), "ModifiedDate().Before and ModifiedAfter partition the todos")
+
+	inProject, err := db.Todos().WithUUID(testUUIDTodoInProject).First(ctx)
+	require.NoError(t, err)
+	onDay, err := db.Todos().ModifiedDate().On(inProject.ModifiedAt).Status().Any().All(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, extractTodoUUIDs(onDay), testUUIDTodoInProject)
+}
+
 func TestTodoQueryCount(t *testing.T) {
 	db := newTestDB(t)
 	ctx := t.Context()
@@ -287,6 +576,228 @@ func TestTodoQueryCount(t *testing.T) {
 	assert.Equal(t, testTodosIncomplete, count)
 }
 
+func TestTodoQueryCountBy(t *testing.T) {
+	db := newTestDB(t)
+	ctx := t.Context()
+
+	sum := func(counts map[string]int) int {
+		n := 0
+		for _, c := range counts {
+			n += c
+		}
+		return n
+	}
+
+	all := db.Todos().Status().Any()
+	total, err := all.Count(ctx)
+	require.NoError(t, err)
+
+	byStatus, err := all.CountBy(ctx, GroupByStatus)
+	require.NoError(t, err)
+	assert.Equal(t, total, sum(byStatus), "per-status counts sum to Count")
+	incomplete, err := db.Todos().Status().Incomplete().Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, incomplete, byStatus[StatusIncomplete.String()])
+	assert.Positive(t, byStatus[StatusCompleted.String()])
+
+	byType, err := all.CountBy(ctx, GroupByType)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"to-do": total}, byType)
+
+	open := db.Todos().Status().Incomplete()
+	byArea, err := open.CountBy(ctx, GroupByArea)
+	require.NoError(t, err)
+	inArea1, err := open.InArea(testUUIDArea1).Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, inArea1, byArea[testUUIDArea1])
+	assert.NotContains(t, byArea, "", "todos without an area are left out")
+
+	byTag, err := open.CountBy(ctx, GroupByTag)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"Home": 1, "Errand": 1, "Office": 1, "Pending": 1, "Important": 1}, byTag)
+
+	byProject, err := db.Projects().Status().Any().CountBy(ctx, GroupByStatus)
+	require.NoError(t, err)
+	projects, err := db.Projects().Status().Any().Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, projects, sum(byProject))
+
+	_, err = all.CountBy(ctx, "priority")
+	require.ErrorIs(t, err, ErrInvalidGroupColumn)
+	require.ErrorIs(t, err, ErrInvalidColumn)
+}
+
+func TestTodoQuerySQL(t *testing.T) {
+	db := newTestDB(t)
+	ctx := t.Context()
+
+	query := db.Todos().InArea(testUUIDArea1).Status().Incomplete()
+	stmt := query.SQL()
+	assert.Contains(t, stmt, "TASK.status = 0")
+	assert.Contains(t, stmt, testUUIDArea1)
+
+	// The preview is the statement All runs, so running it by hand agrees.
+	rows, err := db.inner.SQLDB().QueryContext(ctx, stmt)
+	require.NoError(t, err)
+	defer rows.Close()
+	n := 0
+	for rows.Next() {
+		n++
+	}
+	require.NoError(t, rows.Err())
+	require.NotZero(t, n)
+
+	todos, err := query.All(ctx)
+	require.NoError(t, err)
+	assert.Len(t, todos, n)
+}
+
+func TestTodoQueryExcludeUUIDs(t *testing.T) {
+	db := newTestDB(t)
+	ctx := t.Context()
+
+	base := db.Todos().InProject(testUUIDProjectInArea1).Status().Any()
+	all, err := base.All(ctx)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, len(all), 2)
+
+	rest, err := base.ExcludeUUIDs(all[0].UUID).ExcludeUUIDs(all[1].UUID).All(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, extractTodoUUIDs(all[2:]), extractTodoUUIDs(rest))
+}
+
+func TestTodoQueryWithUUIDs(t *testing.T) {
+	db := newTestDB(t)
+	ctx := t.Context()
+
+	want := []string{testUUIDTodoInToday, testUUIDTodoInProject, testUUIDTodoInArea1Tags}
+	todos, err := db.Todos().WithUUIDs(want...).All(ctx)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, want, extractTodoUUIDs(todos))
+
+	// A later call replaces the set, and an empty call drops the filter.
+	todos, err = db.Todos().WithUUIDs(want...).WithUUIDs(testUUIDTodoInbox).All(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{testUUIDTodoInbox}, extractTodoUUIDs(todos))
+
+	all, err := db.Todos().All(ctx)
+	require.NoError(t, err)
+	unfiltered, err := db.Todos().WithUUIDs(want...).WithUUIDs().All(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, all, unfiltered)
+	todos, err = db.Todos().WithUUIDs().All(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, all, todos)
+
+	allProjects, err := db.Projects().All(ctx)
+	require.NoError(t, err)
+	projects, err := db.Projects().WithUUIDs().All(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, allProjects, projects)
+}
+
+func TestTodoQueryEach(t *testing.T) {
+	db := newTestDB(t)
+	ctx := t.Context()
+
+	query := db.Todos().Status().Any().IncludeChecklist()
+	keyset := query.(*todoQuery).clone()
+	keyset.inner.filter.Keyset = true // Each breaks index ties by UUID
+	want, err := keyset.All(ctx)
+	require.NoError(t, err)
+	count, err := query.Count(ctx)
+	require.NoError(t, err)
+
+	var got []Todo
+	require.NoError(t, query.Each(ctx, func(todo Todo) error {
+		got = append(got, todo)
+		return nil
+	}))
+	assert.Len(t, got, count)
+	assert.Equal(t, want, got, "tags and checklists load as in All")
+
+	errStop := errors.New("stop")
+	seen := 0
+	err = query.Each(ctx, func(Todo) error {
+		seen++
+		if seen == 2 {
+			return errStop
+		}
+		return nil
+	})
+	require.ErrorIs(t, err, errStop)
+	assert.Equal(t, 2, seen)
+
+	// Limit(0) means no limit, as in All.
+	seen = 0
+	require.NoError(t, query.Limit(0).Each(ctx, func(Todo) error {
+		seen++
+		return nil
+	}))
+	assert.Equal(t, count, seen)
+}
+
+func TestTodoQueryEachChunks(t *testing.T) {
+	db := newTestDB(t)
+	ctx := t.Context()
+	saved := eachChunkSize
+	eachChunkSize = 2
+	t.Cleanup(func() { eachChunkSize = saved })
+
+	for name, query := range map[string]TodoQueryBuilder{
+		"whole result":       db.Todos().Status().Any().IncludeChecklist(),
+		"limit and offset":   db.Todos().Status().Any().Offset(1).Limit(3),
+		"limit past the end": db.Todos().Status().Incomplete().Limit(1000),
+		"zero limit":         db.Todos().Status().Any().Limit(0),
+	} {
+		t.Run(name, func(t *testing.T) {
+			// Each breaks index ties by UUID; compare with All doing the same.
+			keyset := query.(*todoQuery).clone()
+			keyset.inner.filter.Keyset = true
+			want, err := keyset.All(ctx)
+			require.NoError(t, err)
+			var got []Todo
+			require.NoError(t, query.Each(ctx, func(todo Todo) error {
+				got = append(got, todo)
+				return nil
+			}))
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestTodoQueryEachSingleConnection(t *testing.T) {
+	initTestPaths()
+	client, err := NewClient(WithDatabasePath(testDatabasePath), WithMaxOpenConns(1))
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	n := 0
+	err = client.Todos().Status().Any().IncludeChecklist().Each(ctx, func(todo Todo) error {
+		n++
+		_, err := client.Todos().WithUUID(todo.UUID).Count(ctx)
+		return err
+	})
+	require.NoError(t, err, "fn can query while Each runs on one connection")
+	assert.Positive(t, n)
+}
+
+func TestProjectQueryEach(t *testing.T) {
+	db := newTestDB(t)
+	ctx := t.Context()
+
+	count, err := db.Projects().Status().Any().Count(ctx)
+	require.NoError(t, err)
+	n := 0
+	require.NoError(t, db.Projects().Status().Any().Each(ctx, func(Project) error {
+		n++
+		return nil
+	}))
+	assert.Equal(t, count, n)
+}
+
 func TestTodoQueryFirst(t *testing.T) {
 	db := newTestDB(t)
 	ctx := t.Context()
@@ -338,33 +849,369 @@ func TestTodoQueryOrderByTodayIndex(t *testing.T) {
 	db := newTestDB(t)
 	ctx := t.Context()
 
-	_, err := db.Todos().
-		StartDate().Exists(true).
-		Start().Anytime().
-		Status().Incomplete().
-		OrderByTodayIndex().
-		All(ctx)
-	require.NoError(t, err)
+	_, err := db.Todos().
+		StartDate().Exists(true).
+		Start().Anytime().
+		Status().Incomplete().
+		OrderByTodayIndex().
+		All(ctx)
+	require.NoError(t, err)
+}
+
+func TestTodoQueryOrderByIndex(t *testing.T) {
+	client := newTestClient(t)
+	ctx := t.Context()
+	inbox := client.Todos().Start().Inbox().Status().Incomplete()
+
+	byDefault, err := inbox.All(ctx)
+	require.NoError(t, err)
+	require.Greater(t, len(byDefault), 1)
+	reset, err := inbox.OrderByTodayIndex().OrderByIndex().All(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, extractTodoUUIDs(byDefault), extractTodoUUIDs(reset))
+	assert.Contains(t, inbox.OrderByDeadline(true).OrderByIndex().SQL(), `TASK.deadline DESC, TASK."index"`,
+		"OrderByIndex keeps earlier sort terms")
+
+	indexes := make([]int, len(byDefault))
+	for i := range byDefault {
+		require.NoError(t, client.SQLDB().QueryRowContext(ctx,
+			`SELECT "index" FROM TMTask WHERE uuid = ?`, byDefault[i].UUID).Scan(&indexes[i]))
+	}
+	assert.True(t, slices.IsSorted(indexes), "Inbox follows the manual index, got %v", indexes)
+}
+
+func TestTodoQueryInheritProjectStart(t *testing.T) {
+	dbPath := copyWritableFixture(t)
+	execFixtureSQL(t, dbPath, "UPDATE TMTask SET start = 2 WHERE uuid = ?", testUUIDProjectInArea1)
+	client, err := NewClient(WithDatabasePath(dbPath))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	ctx := t.Context()
+
+	inProject := []string{testUUIDTodoInArea1Tags, testUUIDTodoInHeading}
+
+	own, err := client.Todos().Start().Someday().Status().Incomplete().All(ctx)
+	require.NoError(t, err)
+	for _, uuid := range inProject {
+		assert.NotContains(t, extractTodoUUIDs(own), uuid, "own bucket is still Anytime")
+	}
+
+	inherited, err := client.Todos().InheritProjectStart(true).Start().Someday().Status().Incomplete().All(ctx)
+	require.NoError(t, err)
+	for _, uuid := range inProject {
+		assert.Contains(t, extractTodoUUIDs(inherited), uuid, "directly or through a heading")
+	}
+	for i := range inherited {
+		assert.Equal(t, StartSomeday, inherited[i].Start)
+	}
+
+	anytime, err := client.Todos().InheritProjectStart(true).Start().Anytime().Status().Incomplete().All(ctx)
+	require.NoError(t, err)
+	for _, uuid := range inProject {
+		assert.NotContains(t, extractTodoUUIDs(anytime), uuid)
+	}
+}
+
+func TestTodoQueryIncludeContextTrashed(t *testing.T) {
+	dbPath := copyWritableFixture(t)
+	// Trash the heading only: its todos stay untrashed rows.
+	execFixtureSQL(t, dbPath, "UPDATE TMTask SET trashed = 1 WHERE uuid = '6QpDLSHZMRAUSAeZ9mNvgt'")
+	client, err := NewClient(WithDatabasePath(dbPath))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	ctx := t.Context()
+
+	hidden, err := client.Todos().Status().Incomplete().All(ctx)
+	require.NoError(t, err)
+	assert.NotContains(t, extractTodoUUIDs(hidden), testUUIDTodoInHeading, "a trashed heading hides its todos")
+
+	ghosts, err := client.Todos().IncludeContextTrashed(true).Status().Incomplete().All(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, extractTodoUUIDs(ghosts), testUUIDTodoInHeading)
+
+	// The same holds for a trashed project, including todos under its headings.
+	execFixtureSQL(t, dbPath, "UPDATE TMTask SET trashed = 0 WHERE uuid = '6QpDLSHZMRAUSAeZ9mNvgt'")
+	execFixtureSQL(t, dbPath, "UPDATE TMTask SET trashed = 1 WHERE uuid = ?", testUUIDProjectInArea1)
+	hidden, err = client.Todos().Status().Incomplete().All(ctx)
+	require.NoError(t, err)
+	assert.NotContains(t, extractTodoUUIDs(hidden), testUUIDTodoInHeading)
+	assert.NotContains(t, extractTodoUUIDs(hidden), testUUIDTodoInArea1Tags)
+
+	ghosts, err = client.Todos().IncludeContextTrashed(true).Status().Incomplete().All(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, extractTodoUUIDs(ghosts), testUUIDTodoInHeading)
+	assert.Contains(t, extractTodoUUIDs(ghosts), testUUIDTodoInArea1Tags)
+}
+
+func TestTodoQueryAround(t *testing.T) {
+	db := newTestDB(t)
+	ctx := t.Context()
+
+	query := db.Todos().Status().Incomplete()
+	all, err := query.All(ctx)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, len(all), 3)
+
+	prev, next, err := query.Around(ctx, all[1].UUID)
+	require.NoError(t, err)
+	require.NotNil(t, prev)
+	require.NotNil(t, next)
+	assert.Equal(t, all[0].UUID, prev.UUID)
+	assert.Equal(t, all[2].UUID, next.UUID)
+
+	prev, next, err = query.Around(ctx, all[0].UUID)
+	require.NoError(t, err)
+	assert.Nil(t, prev, "first item has no previous")
+	require.NotNil(t, next)
+	assert.Equal(t, all[1].UUID, next.UUID)
+
+	prev, next, err = query.Around(ctx, all[len(all)-1].UUID)
+	require.NoError(t, err)
+	require.NotNil(t, prev)
+	assert.Equal(t, all[len(all)-2].UUID, prev.UUID)
+	assert.Nil(t, next, "last item has no next")
+
+	_, _, err = query.Around(ctx, "nonexistent-uuid")
+	require.ErrorIs(t, err, ErrTodoNotFound)
+}
+
+func TestTodoQueryLimit(t *testing.T) {
+	db := newTestDB(t)
+	ctx := t.Context()
+
+	// Without limit - should return all incomplete todos
+	all, err := db.Todos().Status().Incomplete().All(ctx)
+	require.NoError(t, err)
+	require.Greater(t, len(all), 3, "need enough todos to test limit")
+
+	// With limit
+	limited, err := db.Todos().Status().Incomplete().Limit(3).All(ctx)
+	require.NoError(t, err)
+	assert.Len(t, limited, 3)
+
+	// Limit larger than result set returns all
+	big, err := db.Todos().Status().Incomplete().Limit(1000).All(ctx)
+	require.NoError(t, err)
+	assert.Len(t, big, len(all))
+}
+
+// Paging the logbook in chunks must visit every entry exactly once.
+func TestTodoQueryLimitOffsetPaging(t *testing.T) {
+	db := newTestDB(t)
+	ctx := t.Context()
+
+	logbook := db.Todos().orderByStopDate(true).StopDate().Exists(true)
+	total, err := logbook.Count(ctx)
+	require.NoError(t, err)
+	require.Greater(t, total, 10, "fixture needs more than one page")
+
+	want, err := logbook.All(ctx)
+	require.NoError(t, err)
+
+	const pageSize = 10
+	var paged []Todo
+	for offset := 0; ; offset += pageSize {
+		page := logbook.Limit(pageSize).Offset(offset)
+		n, err := page.Count(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, total, n, "Count ignores limit and offset")
+
+		todos, err := page.All(ctx)
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(todos), pageSize)
+		paged = append(paged, todos...)
+		if len(todos) < pageSize {
+			break
+		}
+	}
+	assert.Equal(t, extractTodoUUIDs(want), extractTodoUUIDs(paged), "no overlap or gaps")
+
+	first, err := logbook.Offset(3).First(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, want[3].UUID, first.UUID, "First honors Offset")
+}
+
+func TestAreaTagQuerySQL(t *testing.T) {
+	db := newTestDB(t)
+	ctx := t.Context()
+
+	// runSQL runs a previewed statement by hand and returns the first
+	// column of each row.
+	runSQL := func(t *testing.T, stmt string) []string {
+		t.Helper()
+		rows, err := db.inner.SQLDB().QueryContext(ctx, stmt)
+		require.NoError(t, err)
+		defer rows.Close()
+		cols, err := rows.Columns()
+		require.NoError(t, err)
+		var uuids []string
+		for rows.Next() {
+			dest := make([]any, len(cols))
+			var uuid string
+			dest[0] = &uuid
+			for i := 1; i < len(dest); i++ {
+				dest[i] = new(any)
+			}
+			require.NoError(t, rows.Scan(dest...))
+			uuids = append(uuids, uuid)
+		}
+		require.NoError(t, rows.Err())
+		return uuids
+	}
+
+	t.Run("areas", func(t *testing.T) {
+		query := db.Areas().Visible(true).InTag("Errand").Limit(2)
+		stmt := query.SQL()
+		assert.Contains(t, stmt, "IFNULL(AREA.visible, 1)")
+		assert.Contains(t, stmt, "TAG.title = 'Errand'")
+		assert.Contains(t, stmt, "LIMIT 2")
+		assert.Equal(t, []string{testUUIDArea1}, runSQL(t, stmt))
+	})
+
+	t.Run("tags", func(t *testing.T) {
+		query := db.Tags().WithTitle("Office")
+		stmt := query.SQL()
+		assert.Contains(t, stmt, "title = 'Office'")
+		assert.Equal(t, []string{testUUIDTagOffice}, runSQL(t, stmt))
+	})
+}
+
+func TestAreaTagLimitOffset(t *testing.T) {
+	db := newTestDB(t)
+	ctx := t.Context()
+
+	areas, err := db.Areas().All(ctx)
+	require.NoError(t, err)
+	require.Len(t, areas, 3)
+	page, err := db.Areas().Limit(1).Offset(1).All(ctx)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, areas[1].UUID, page[0].UUID)
+	count, err := db.Areas().Limit(1).Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	tags, err := db.Tags().All(ctx)
+	require.NoError(t, err)
+	rest, err := db.Tags().Offset(2).All(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, tags[2:], rest)
+	all, err := db.Tags().Limit(0).All(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, tags, all, "Limit(0) means no limit")
+}
+
+func TestTodoQueryOrderByDate(t *testing.T) {
+	db := newTestDB(t)
+	ctx := t.Context()
+
+	tests := []struct {
+		name  string
+		query TodoQueryBuilder
+		date  func(*Todo) *time.Time
+		desc  bool
+	}{
+		{"deadline asc", db.Todos().OrderByDeadline(false), func(t *Todo) *time.Time { return t.Deadline }, false},
+		{"deadline desc", db.Todos().OrderByDeadline(true), func(t *Todo) *time.Time { return t.Deadline }, true},
+		{"start date asc", db.Todos().OrderByStartDate(false), func(t *Todo) *time.Time { return t.StartDate }, false},
+		{"start date desc", db.Todos().OrderByStartDate(true), func(t *Todo) *time.Time { return t.StartDate }, true},
+		{"OrderBy deadline asc", db.Todos().OrderBy(TaskColumnDeadline, SortAsc), func(t *Todo) *time.Time { return t.Deadline }, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			todos, err := tt.query.Status().Incomplete().All(ctx)
+			require.NoError(t, err)
+			require.NotEmpty(t, todos)
+
+			// Dated todos come first in the requested direction, undated last.
+			seenUndated := false
+			var prev *time.Time
+			for i := range todos {
+				date := tt.date(&todos[i])
+				if date == nil {
+					seenUndated = true
+					continue
+				}
+				require.False(t, seenUndated, "dated todo %q sorted after an undated one", todos[i].UUID)
+				if prev != nil {
+					if tt.desc {
+						assert.False(t, date.After(*prev), "todo %q out of order", todos[i].UUID)
+					} else {
+						assert.False(t, date.Before(*prev), "todo %q out of order", todos[i].UUID)
+					}
+				}
+				prev = date
+			}
+			assert.NotNil(t, prev, "fixture needs dated todos")
+		})
+	}
 }
 
-func TestTodoQueryLimit(t *testing.T) {
+func TestTodoQueryOrderBy(t *testing.T) {
 	db := newTestDB(t)
 	ctx := t.Context()
 
-	// Without limit - should return all incomplete todos
-	all, err := db.Todos().Status().Incomplete().All(ctx)
-	require.NoError(t, err)
-	require.Greater(t, len(all), 3, "need enough todos to test limit")
+	t.Run("created desc", func(t *testing.T) {
+		todos, err := db.Todos().OrderBy(TaskColumnCreated, SortDesc).All(ctx)
+		require.NoError(t, err)
+		require.NotEmpty(t, todos)
+		for i := 1; i < len(todos); i++ {
+			assert.False(t, todos[i].CreatedAt.After(todos[i-1].CreatedAt), "todo %q out of order", todos[i].UUID)
+		}
+	})
 
-	// With limit
-	limited, err := db.Todos().Status().Incomplete().Limit(3).All(ctx)
-	require.NoError(t, err)
-	assert.Len(t, limited, 3)
+	t.Run("stacked terms", func(t *testing.T) {
+		todos, err := db.Todos().
+			OrderBy(TaskColumnDeadline, SortAsc).
+			OrderBy(TaskColumnTitle, SortDesc).
+			All(ctx)
+		require.NoError(t, err)
+		require.NotEmpty(t, todos)
+		for i := 1; i < len(todos); i++ {
+			prev, cur := todos[i-1], todos[i]
+			if prev.Deadline == nil || cur.Deadline == nil || !prev.Deadline.Equal(*cur.Deadline) {
+				continue
+			}
+			assert.GreaterOrEqual(t, strings.ToLower(prev.Title), strings.ToLower(cur.Title),
+				"equal deadlines must fall back to title desc")
+		}
+		assert.Contains(t, db.Todos().OrderBy(TaskColumnDeadline, SortAsc).OrderBy(TaskColumnTitle, SortDesc).SQL(),
+			"TASK.deadline ASC, TASK.title COLLATE NOCASE DESC")
+	})
 
-	// Limit larger than result set returns all
-	big, err := db.Todos().Status().Incomplete().Limit(1000).All(ctx)
+	t.Run("shorthands stack with OrderBy", func(t *testing.T) {
+		assert.Contains(t, db.Todos().OrderByDeadline(false).OrderBy(TaskColumnTitle, SortDesc).OrderByStartDate(true).SQL(),
+			"TASK.deadline ASC, TASK.title COLLATE NOCASE DESC, TASK.startDate IS NULL, TASK.startDate DESC")
+	})
+
+	t.Run("unknown column fails the query", func(t *testing.T) {
+		bad := db.Todos().OrderBy("title; DROP TABLE TMTask", SortAsc)
+		assert.NotContains(t, bad.SQL(), "DROP TABLE")
+		_, err := bad.All(ctx)
+		require.ErrorIs(t, err, ErrInvalidTaskColumn)
+		require.ErrorIs(t, err, ErrInvalidColumn)
+		_, err = bad.First(ctx)
+		require.ErrorIs(t, err, ErrInvalidTaskColumn)
+		_, err = bad.Count(ctx)
+		require.ErrorIs(t, err, ErrInvalidTaskColumn)
+		err = bad.Each(ctx, func(Todo) error { return nil })
+		require.ErrorIs(t, err, ErrInvalidTaskColumn)
+		_, err = bad.Page(ctx, 10)
+		require.ErrorIs(t, err, ErrInvalidTaskColumn)
+		_, err = db.Projects().OrderBy("nope", SortDesc).All(ctx)
+		require.ErrorIs(t, err, ErrInvalidTaskColumn)
+	})
+}
+
+func TestProjectQueryOrderByDeadline(t *testing.T) {
+	db := newTestDB(t)
+
+	projects, err := db.Projects().Status().Any().OrderByDeadline(false).All(t.Context())
 	require.NoError(t, err)
-	assert.Len(t, big, len(all))
+	unordered, err := db.Projects().Status().Any().All(t.Context())
+	require.NoError(t, err)
+	assert.Len(t, projects, len(unordered), "ordering must not change the result set")
 }
 
 // =============================================================================
@@ -413,6 +1260,23 @@ func TestProjectQueryInArea(t *testing.T) {
 	}
 }
 
+// TestProjectQueryAreaJoin verifies that every project row carries its area
+// from the query's own join, so grouping by area needs no second lookup.
+func TestProjectQueryAreaJoin(t *testing.T) {
+	db := newTestDB(t)
+	ctx := t.Context()
+
+	projects, err := db.Projects().Status().Incomplete().All(ctx)
+	require.NoError(t, err)
+
+	areas := make(map[string][2]string, len(projects))
+	for _, p := range projects {
+		areas[p.UUID] = [2]string{p.AreaUUID, p.AreaTitle}
+	}
+	assert.Equal(t, [2]string{testUUIDArea1, "Area 1"}, areas[testUUIDProjectInArea1])
+	assert.Equal(t, [2]string{"", ""}, areas["TCozQqXVbB2TJkXXXQj2H9"], "Project without Area")
+}
+
 // =============================================================================
 // HeadingQuery Tests
 // =============================================================================
@@ -683,6 +1547,43 @@ func TestDateFilterComparison(t *testing.T) {
 		}
 	})
 
+	// Between is inclusive on both ends, so a range that starts and ends on
+	// an existing date still matches it.
+	betweenTests := []struct {
+		name string
+		pick func(TodoQueryBuilder) DateFilter[TodoQueryBuilder]
+		date func(*Todo) *time.Time
+	}{
+		{"Deadline Between", TodoQueryBuilder.Deadline, func(t *Todo) *time.Time { return t.Deadline }},
+		{"StartDate Between", TodoQueryBuilder.StartDate, func(t *Todo) *time.Time { return t.StartDate }},
+	}
+	for _, tt := range betweenTests {
+		pick, date := tt.pick, tt.date
+		t.Run(tt.name, func(t *testing.T) {
+			dated, err := pick(db.Todos()).Exists(true).Status().Incomplete().All(ctx)
+			require.NoError(t, err)
+			require.NotEmpty(t, dated)
+			day := *date(&dated[0])
+
+			single, err := pick(db.Todos()).Between(day, day).Status().Incomplete().All(ctx)
+			require.NoError(t, err)
+			require.NotEmpty(t, single)
+			for i := range single {
+				assert.True(t, date(&single[i]).Equal(day), "todo %q outside the one-day range", single[i].UUID)
+			}
+
+			all, err := pick(db.Todos()).
+				Between(time.Date(1, 1, 2, 0, 0, 0, 0, time.Local), time.Date(9999, 12, 31, 0, 0, 0, 0, time.Local)).
+				Status().Incomplete().Count(ctx)
+			require.NoError(t, err)
+			assert.Len(t, dated, all)
+
+			none, err := pick(db.Todos()).Between(day, day.AddDate(0, 0, -1)).Count(ctx)
+			require.NoError(t, err)
+			assert.Zero(t, none, "an end before start matches nothing")
+		})
+	}
+
 	// Cross-validate StartDate: Before + OnOrAfter == Exists(true)
 	t.Run("StartDate Before and OnOrAfter partition", func(t *testing.T) {
 		startPivot := time.Date(2023, 1, 1, 0, 0, 0, 0, time.Local)
@@ -871,6 +1772,40 @@ func TestHasHeading(t *testing.T) {
 	}
 }
 
+func TestInHeadingPopulatesProject(t *testing.T) {
+	db := newTestDB(t)
+
+	todos, err := db.Todos().InHeading("6QpDLSHZMRAUSAeZ9mNvgt").Status().Any().All(t.Context())
+	require.NoError(t, err)
+	require.Len(t, todos, 3)
+	for _, todo := range todos {
+		assert.Equalf(t, testUUIDProjectInArea1, todo.ProjectUUID,
+			"heading todo %q should carry its heading's project", todo.UUID)
+		assert.Equal(t, "Project in Area 1", todo.ProjectTitle)
+	}
+}
+
+func TestOrphaned(t *testing.T) {
+	db := newTestDB(t)
+	ctx := t.Context()
+
+	orphans, err := db.Todos().
+		Orphaned().
+		Status().Incomplete().
+		All(ctx)
+	require.NoError(t, err)
+	uuids := extractTodoUUIDs(orphans)
+	assert.Contains(t, uuids, testUUIDTodoInbox)
+	assert.NotContains(t, uuids, testUUIDTodoInArea1, "area todo is not an orphan")
+	assert.NotContains(t, uuids, testUUIDTodoInProject, "project todo is not an orphan")
+	assert.NotContains(t, uuids, testUUIDTodoInHeading, "heading todo is not an orphan")
+	for _, todo := range orphans {
+		assert.Empty(t, todo.AreaUUID, "Orphaned() returned todo %q with area", todo.UUID)
+		assert.Empty(t, todo.ProjectUUID, "Orphaned() returned todo %q with project", todo.UUID)
+		assert.Empty(t, todo.HeadingUUID, "Orphaned() returned todo %q with heading", todo.UUID)
+	}
+}
+
 // =============================================================================
 // Area Query Tests
 // =============================================================================
@@ -889,6 +1824,23 @@ func TestAreaWithTitle(t *testing.T) {
 	require.ErrorIs(t, err, ErrAreaNotFound)
 }
 
+func TestAreaWithUUIDs(t *testing.T) {
+	db := newTestDB(t)
+	ctx := t.Context()
+
+	areas, err := db.Areas().WithUUIDs(testUUIDArea1, testUUIDArea2, "missing").All(ctx)
+	require.NoError(t, err)
+	uuids := make([]string, len(areas))
+	for i := range areas {
+		uuids[i] = areas[i].UUID
+	}
+	assert.ElementsMatch(t, []string{testUUIDArea1, testUUIDArea2}, uuids)
+
+	count, err := db.Areas().WithUUIDs().Count(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
 func TestAreaVisible(t *testing.T) {
 	db := newTestDB(t)
 	ctx := t.Context()
@@ -904,6 +1856,45 @@ func TestAreaVisible(t *testing.T) {
 	require.Equal(t, len(allAreas), len(visibleAreas)+len(hiddenAreas))
 }
 
+func TestAreaVisibleField(t *testing.T) {
+	dbPath := copyWritableFixture(t)
+	execFixtureSQL(t, dbPath, `UPDATE TMArea SET visible = 0 WHERE uuid = ?`, testUUIDArea2)
+	execFixtureSQL(t, dbPath, `UPDATE TMArea SET visible = 1 WHERE uuid = ?`, testUUIDArea3)
+	client, err := NewClient(WithDatabasePath(dbPath))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	ctx := t.Context()
+
+	visible, err := client.Areas().Visible(true).All(ctx)
+	require.NoError(t, err)
+	hidden, err := client.Areas().Visible(false).All(ctx)
+	require.NoError(t, err)
+
+	require.Len(t, hidden, 1)
+	assert.Equal(t, testUUIDArea2, hidden[0].UUID)
+	assert.False(t, hidden[0].Visible)
+
+	uuids := make([]string, len(visible))
+	for i, area := range visible {
+		uuids[i] = area.UUID
+		assert.True(t, area.Visible, "area %s", area.Title)
+	}
+	assert.ElementsMatch(t, []string{testUUIDArea1, testUUIDArea3}, uuids, "a NULL visible column counts as visible")
+}
+
+// Built-in lists (Logbook, Trash, ...) are not rows in the area table, so an
+// unfiltered area query returns user areas only.
+func TestAreaListHasNoBuiltInLists(t *testing.T) {
+	db := newTestDB(t)
+
+	areas, err := db.Areas().All(t.Context())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, testAreaUUIDs, extractAreaUUIDs(areas))
+	for _, area := range areas {
+		assert.NotContains(t, []string{"Inbox", "Today", "Upcoming", "Anytime", "Someday", "Logbook", "Trash"}, area.Title)
+	}
+}
+
 func TestAreaHasTag(t *testing.T) {
 	db := newTestDB(t)
 	ctx := t.Context()
@@ -940,6 +1931,52 @@ func TestTagWithParent(t *testing.T) {
 	assert.Empty(t, tags)
 }
 
+func TestTagHierarchy(t *testing.T) {
+	dbPath := copyWritableFixture(t)
+	execFixtureSQL(t, dbPath, `UPDATE TMTag SET parent = ? WHERE title IN ('Errand', 'Office')`, testUUIDTagHome)
+	execFixtureSQL(t, dbPath, `UPDATE TMTag SET parent = ? WHERE title = 'Pending'`, testUUIDTagOffice)
+	client, err := NewClient(WithDatabasePath(dbPath))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	ctx := t.Context()
+
+	titles := func(tags []Tag) []string {
+		out := make([]string, len(tags))
+		for i, tag := range tags {
+			out[i] = tag.Title
+		}
+		return out
+	}
+
+	nested, err := client.Tags().WithParent(testUUIDTagHome).All(ctx)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"Errand", "Office"}, titles(nested))
+	for _, tag := range nested {
+		assert.Equal(t, testUUIDTagHome, tag.ParentUUID)
+	}
+
+	home, err := client.Tags().WithUUID(testUUIDTagHome).First(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, home.ParentUUID, "a top-level tag has no parent")
+
+	children, err := client.Tags().WithUUID(testUUIDTagHome).Children(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, titles(nested), titles(children))
+
+	grandchildren, err := client.Tags().WithTitle("Office").Children(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Pending"}, titles(grandchildren))
+
+	leaf, err := client.Tags().WithTitle("Pending").Children(ctx)
+	require.NoError(t, err)
+	assert.NotNil(t, leaf)
+	assert.Empty(t, leaf)
+
+	none, err := client.Tags().WithUUID("nonexistent").Children(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, none, "no parents means no children, not every tag")
+}
+
 // =============================================================================
 // UUID Prefix Filter Tests
 // =============================================================================
@@ -1034,3 +2071,342 @@ func TestAllEmptyResultsMarshalAsJSONArray(t *testing.T) {
 		})
 	}
 }
+
+// TestEmptyNestedListsOmittedFromJSON verifies that untagged items and todos
+// without a loaded checklist encode without the key rather than as null.
+func TestEmptyNestedListsOmittedFromJSON(t *testing.T) {
+	db := newTestDB(t)
+	ctx := t.Context()
+
+	todo, err := db.Todos().WithUUID(testUUIDTodoInbox).First(ctx)
+	require.NoError(t, err)
+	require.Empty(t, todo.Tags)
+	require.Empty(t, todo.Checklist)
+
+	data, err := json.Marshal(todo)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), `"tags"`)
+	assert.NotContains(t, string(data), `"checklist"`)
+	assert.NotContains(t, string(data), "null")
+
+	areas, err := db.Areas().All(ctx)
+	require.NoError(t, err)
+	data, err = json.Marshal(areas)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "null")
+}
+
+// TestTodoJSONGolden pins the encoded form of fixture todos, which callers
+// serve from their own APIs. Scanned timestamps use time.Local, so the test
+// runs in UTC to keep the document machine-independent.
+func TestTodoJSONGolden(t *testing.T) {
+	local := time.Local
+	time.Local = time.UTC
+	t.Cleanup(func() { time.Local = local })
+
+	db := newTestDB(t)
+	ctx := t.Context()
+
+	todos, err := db.Todos().
+		WithUUIDs(testUUIDTodoInboxChecklist, testUUIDTodoInArea1Tags, testUUIDTodoInProject).
+		Status().Any().
+		IncludeChecklist().
+		All(ctx)
+	require.NoError(t, err)
+	require.Len(t, todos, 3)
+
+	data, err := json.MarshalIndent(todos, "", "  ")
+	require.NoError(t, err)
+	assertGolden(t, "todos.json", string(data)+"\n")
+}
+
+func TestTodoQuerySkipCorruptRows(t *testing.T) {
+	dbPath := copyWritableFixture(t)
+	execFixtureSQL(t, dbPath, `UPDATE TMTask SET "index" = 'garbled' WHERE uuid = ?`, testUUIDTodoInToday)
+	ctx := t.Context()
+
+	strict, err := NewClient(WithDatabasePath(dbPath))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = strict.Close() })
+	_, err = strict.Todos().Status().Incomplete().All(ctx)
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrCorruptRows)
+
+	lenient, err := NewClient(WithDatabasePath(dbPath), WithSkipCorruptRows(true))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = lenient.Close() })
+	todos, err := lenient.Todos().Status().Incomplete().All(ctx)
+	require.ErrorIs(t, err, ErrCorruptRows)
+	var corrupt *CorruptRowsError
+	require.ErrorAs(t, err, &corrupt)
+	assert.Equal(t, 1, corrupt.Skipped)
+	assert.Len(t, todos, testTodosIncomplete-1)
+	assert.NotContains(t, extractTodoUUIDs(todos), testUUIDTodoInToday)
+}
+
+func TestSkipCorruptRowsComposedReads(t *testing.T) {
+	dbPath := copyWritableFixture(t)
+	ctx := t.Context()
+
+	clean, err := NewClient(WithDatabasePath(dbPath))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = clean.Close() })
+	first, err := clean.Todos().InProject(testUUIDProjectInArea1).Status().Incomplete().First(ctx)
+	require.NoError(t, err)
+	corruptUUID := first.UUID
+	execFixtureSQL(t, dbPath, `UPDATE TMTask SET todayIndex = 'garbled' WHERE uuid = ?`, corruptUUID)
+
+	var logged []error
+	client, err := NewClient(WithDatabasePath(dbPath), WithSkipCorruptRows(true), WithLogger(
+		func(_ context.Context, _ string, _ time.Duration, err error) {
+			if errors.Is(err, ErrCorruptRows) {
+				logged = append(logged, err)
+			}
+		}))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	q := client.Todos().InProject(testUUIDProjectInArea1).Status().Incomplete()
+
+	t.Run("First passes over the corrupt row", func(t *testing.T) {
+		todo, err := q.First(ctx)
+		require.ErrorIs(t, err, ErrCorruptRows)
+		require.NotNil(t, todo)
+		assert.NotEqual(t, corruptUUID, todo.UUID)
+
+		todo, ok, err := q.FirstOK(ctx)
+		require.ErrorIs(t, err, ErrCorruptRows)
+		require.True(t, ok)
+		assert.NotEqual(t, corruptUUID, todo.UUID)
+	})
+
+	t.Run("First with only the corrupt row", func(t *testing.T) {
+		todo, err := client.Todos().WithUUID(corruptUUID).First(ctx)
+		require.ErrorIs(t, err, ErrCorruptRows)
+		assert.Nil(t, todo)
+	})
+
+	t.Run("HeadingGroups keeps the readable todos", func(t *testing.T) {
+		groups, err := client.HeadingGroups(ctx, testUUIDProjectInArea1)
+		var corrupt *CorruptRowsError
+		require.ErrorAs(t, err, &corrupt)
+		assert.Equal(t, 1, corrupt.Skipped)
+		var uuids []string
+		for i := range groups {
+			uuids = append(uuids, extractTodoUUIDs(groups[i].Todos)...)
+		}
+		assert.NotEmpty(t, uuids)
+		assert.NotContains(t, uuids, corruptUUID)
+
+		items, err := client.ProjectOutline(ctx, testUUIDProjectInArea1)
+		require.ErrorIs(t, err, ErrCorruptRows)
+		assert.NotEmpty(t, items)
+	})
+
+	t.Run("skipped rows reach the logger", func(t *testing.T) {
+		require.NotEmpty(t, logged)
+		var corrupt *CorruptRowsError
+		require.ErrorAs(t, logged[0], &corrupt)
+		assert.Equal(t, 1, corrupt.Skipped)
+	})
+}
+
+func TestTodoQueryPageSkipCorruptRows(t *testing.T) {
+	dbPath := copyWritableFixture(t)
+	ctx := t.Context()
+
+	client, err := NewClient(WithDatabasePath(dbPath), WithSkipCorruptRows(true))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	q := client.Todos().Status().Incomplete()
+	first, err := q.Page(ctx, 3)
+	require.NoError(t, err)
+	require.Len(t, first.Items, 3)
+	// Corrupt a row in the middle of the first page; todayIndex keeps the
+	// default ordering intact.
+	corruptUUID := first.Items[1].UUID
+	execFixtureSQL(t, dbPath, `UPDATE TMTask SET todayIndex = 'garbled' WHERE uuid = ?`, corruptUUID)
+
+	want, err := q.All(ctx)
+	require.ErrorIs(t, err, ErrCorruptRows)
+	for _, size := range []int{1, 3} {
+		var paged []string
+		page, err := q.Page(ctx, size)
+		for {
+			if err != nil {
+				require.ErrorIs(t, err, ErrCorruptRows)
+			}
+			require.LessOrEqual(t, len(page.Items), size)
+			paged = append(paged, extractTodoUUIDs(page.Items)...)
+			if page.NextCursor == "" {
+				break
+			}
+			require.Less(t, len(paged), len(want), "paging did not terminate")
+			page, err = q.AfterCursor(page.NextCursor).Page(ctx, size)
+		}
+		assert.ElementsMatch(t, extractTodoUUIDs(want), paged, "page size %d", size)
+		assert.NotContains(t, paged, corruptUUID)
+	}
+}
+
+func TestFirstOK(t *testing.T) {
+	db := newTestDB(t)
+	ctx := t.Context()
+
+	t.Run("found", func(t *testing.T) {
+		todo, ok, err := db.Todos().WithUUID(testUUIDTodoInbox).FirstOK(ctx)
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, testUUIDTodoInbox, todo.UUID)
+
+		project, ok, err := db.Projects().WithUUID(testUUIDProjectInArea1).FirstOK(ctx)
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, testUUIDProjectInArea1, project.UUID)
+
+		area, ok, err := db.Areas().WithUUID(testUUIDArea1).FirstOK(ctx)
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, testUUIDArea1, area.UUID)
+
+		tag, ok, err := db.Tags().WithUUID(testUUIDTagHome).FirstOK(ctx)
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, testUUIDTagHome, tag.UUID)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		todo, ok, err := db.Todos().WithUUID("missing").FirstOK(ctx)
+		require.NoError(t, err)
+		assert.False(t, ok)
+		assert.Nil(t, todo)
+
+		project, ok, err := db.Projects().WithUUID("missing").FirstOK(ctx)
+		require.NoError(t, err)
+		assert.False(t, ok)
+		assert.Nil(t, project)
+
+		heading, ok, err := db.Headings().WithUUID("missing").FirstOK(ctx)
+		require.NoError(t, err)
+		assert.False(t, ok)
+		assert.Nil(t, heading)
+
+		area, ok, err := db.Areas().WithUUID("missing").FirstOK(ctx)
+		require.NoError(t, err)
+		assert.False(t, ok)
+		assert.Nil(t, area)
+
+		tag, ok, err := db.Tags().WithUUID("missing").FirstOK(ctx)
+		require.NoError(t, err)
+		assert.False(t, ok)
+		assert.Nil(t, tag)
+	})
+
+	t.Run("real failures keep the error", func(t *testing.T) {
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+		todo, ok, err := db.Todos().FirstOK(canceled)
+		require.Error(t, err)
+		assert.False(t, ok)
+		assert.Nil(t, todo)
+	})
+}
+
+func TestTodoQueryPage(t *testing.T) {
+	db := newTestDB(t)
+	ctx := t.Context()
+
+	for _, q := range []TodoQueryBuilder{db.Todos(), db.Todos().OrderByTodayIndex()} {
+		all, err := q.All(ctx)
+		require.NoError(t, err)
+		require.Greater(t, len(all), 3)
+
+		var paged []string
+		page, err := q.Page(ctx, 3)
+		for pages := 1; ; pages++ {
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(page.Items), 3)
+			paged = append(paged, extractTodoUUIDs(page.Items)...)
+			if page.NextCursor == "" {
+				break
+			}
+			require.Less(t, pages, len(all), "paging did not terminate")
+			page, err = q.AfterCursor(page.NextCursor).Page(ctx, 3)
+		}
+		assert.Len(t, paged, len(all), "pages repeated or dropped todos")
+		assert.ElementsMatch(t, extractTodoUUIDs(all), paged)
+	}
+}
+
+func TestTodoQueryPageOffset(t *testing.T) {
+	db := newTestDB(t)
+	ctx := t.Context()
+
+	q := db.Todos().Offset(2)
+	rest, err := q.All(ctx)
+	require.NoError(t, err)
+	require.Greater(t, len(rest), 2)
+
+	// The offset skips rows before the first page only; later pages
+	// resume from the cursor without skipping again.
+	var paged []string
+	page, err := q.Page(ctx, 2)
+	for {
+		require.NoError(t, err)
+		paged = append(paged, extractTodoUUIDs(page.Items)...)
+		if page.NextCursor == "" {
+			break
+		}
+		require.Less(t, len(paged), len(rest), "paging did not terminate")
+		page, err = q.AfterCursor(page.NextCursor).Page(ctx, 2)
+	}
+	assert.ElementsMatch(t, extractTodoUUIDs(rest), paged)
+}
+
+func TestTodoQueryPageCursorErrors(t *testing.T) {
+	db := newTestDB(t)
+	ctx := t.Context()
+
+	page, err := db.Todos().Page(ctx, 2)
+	require.NoError(t, err)
+	require.NotEmpty(t, page.NextCursor)
+
+	_, err = db.Todos().AfterCursor("not a cursor").All(ctx)
+	require.ErrorIs(t, err, ErrInvalidCursor)
+	_, err = db.Todos().AfterCursor(page.NextCursor).OrderByTodayIndex().All(ctx)
+	require.ErrorIs(t, err, ErrCursorOrder)
+	_, err = db.Todos().OrderByDeadline(false).Page(ctx, 2)
+	require.ErrorIs(t, err, ErrCursorOrder)
+	_, err = db.Todos().OrderBy(TaskColumnTitle, SortAsc).Page(ctx, 2)
+	require.ErrorIs(t, err, ErrCursorOrder)
+	_, err = db.Todos().Page(ctx, 0)
+	require.ErrorIs(t, err, ErrInvalidPageSize)
+
+	// An empty cursor starts from the top.
+	first, err := db.Todos().AfterCursor("").Page(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, page, first)
+}
+
+func TestProjectQueryPage(t *testing.T) {
+	db := newTestDB(t)
+	ctx := t.Context()
+
+	want, err := db.Projects().Count(ctx)
+	require.NoError(t, err)
+
+	seen := make(map[string]bool)
+	page, err := db.Projects().Page(ctx, 1)
+	for {
+		require.NoError(t, err)
+		for _, p := range page.Items {
+			assert.False(t, seen[p.UUID], "project %s on two pages", p.UUID)
+			seen[p.UUID] = true
+		}
+		require.LessOrEqual(t, len(seen), want, "paging did not terminate")
+		if page.NextCursor == "" {
+			break
+		}
+		page, err = db.Projects().AfterCursor(page.NextCursor).Page(ctx, 1)
+	}
+	assert.Len(t, seen, want)
+}
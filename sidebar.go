@@ -0,0 +1,71 @@
+package things3
+
+import "context"
+
+// Sidebar is the area and project list shown in the app's sidebar.
+type Sidebar struct {
+	// Projects holds the open projects that belong to no area, which the
+	// app lists above the areas.
+	Projects []SidebarProject `json:"projects"`
+	Areas    []SidebarArea    `json:"areas"`
+}
+
+// SidebarArea is an area with its open projects.
+type SidebarArea struct {
+	Area
+	Projects []SidebarProject `json:"projects"`
+}
+
+// SidebarProject is an open project with the number of open todos in it.
+type SidebarProject struct {
+	Project
+	// OpenTodos counts the project's incomplete todos, including those
+	// filed under its headings.
+	OpenTodos int `json:"open_todos"`
+}
+
+// Sidebar returns the areas and open projects in sidebar order, each project
+// with its open todo count. Like the app, it leaves out projects in Someday,
+// which are listed there instead. The whole view costs three queries, plus
+// the tag lookups, however many areas and projects there are. Every slice in
+// the result is non-nil. Under WithSkipCorruptRows the readable projects are
+// listed and the sidebar is returned with a *CorruptRowsError.
+func (c *Client) Sidebar(ctx context.Context) (*Sidebar, error) {
+	areas, err := c.database.Areas().All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var skipped skippedRows
+	projects, err := c.database.Projects().Status().Incomplete().All(ctx)
+	if err := skipped.add(err); err != nil {
+		return nil, err
+	}
+	open := c.database.Todos()
+	open.inner.filter.Status = new(int(StatusIncomplete))
+	counts, err := open.countByProject(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sidebar := &Sidebar{
+		Projects: make([]SidebarProject, 0),
+		Areas:    make([]SidebarArea, len(areas)),
+	}
+	byArea := make(map[string]int, len(areas))
+	for i := range areas {
+		sidebar.Areas[i] = SidebarArea{Area: areas[i], Projects: make([]SidebarProject, 0)}
+		byArea[areas[i].UUID] = i
+	}
+	for i := range projects {
+		if projects[i].Start == StartSomeday {
+			continue
+		}
+		item := SidebarProject{Project: projects[i], OpenTodos: counts[projects[i].UUID]}
+		if j, ok := byArea[projects[i].AreaUUID]; ok {
+			sidebar.Areas[j].Projects = append(sidebar.Areas[j].Projects, item)
+		} else {
+			sidebar.Projects = append(sidebar.Projects, item)
+		}
+	}
+	return sidebar, skipped.result()
+}
@@ -0,0 +1,48 @@
+package things3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientSidebar(t *testing.T) {
+	client := newTestClient(t)
+	ctx := t.Context()
+
+	sidebar, err := client.Sidebar(ctx)
+	require.NoError(t, err)
+
+	areas, err := client.Areas().All(ctx)
+	require.NoError(t, err)
+	require.Len(t, sidebar.Areas, len(areas))
+
+	titles := func(projects []SidebarProject) []string {
+		out := make([]string, len(projects))
+		for i := range projects {
+			out[i] = projects[i].Title
+		}
+		return out
+	}
+	assert.Equal(t, []string{"Project in Today", "Project without Area"}, titles(sidebar.Projects),
+		"Someday projects are left out")
+
+	var all []SidebarProject
+	all = append(all, sidebar.Projects...)
+	for _, area := range sidebar.Areas {
+		require.NotNil(t, area.Projects)
+		for _, p := range area.Projects {
+			assert.Equal(t, area.UUID, p.AreaUUID)
+		}
+		all = append(all, area.Projects...)
+	}
+	require.Contains(t, titles(all), "Project in Area 1")
+
+	// Each count agrees with a per-project query, headings included.
+	for _, p := range all {
+		want, err := client.Todos().InProject(p.UUID).Status().Incomplete().Count(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, want, p.OpenTodos, p.Title)
+	}
+}
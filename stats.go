@@ -0,0 +1,60 @@
+package things3
+
+import "context"
+
+// Stats holds item counts for a dashboard. The list counts cover open todos
+// and match the lengths of the corresponding views.
+type Stats struct {
+	// InboxCount counts open todos in the Inbox.
+	InboxCount int `json:"inbox_count"`
+	// TodayCount counts the todos Today returns.
+	TodayCount int `json:"today_count"`
+	// UpcomingCount counts the todos Upcoming returns.
+	UpcomingCount int `json:"upcoming_count"`
+	// AnytimeCount counts open todos in the Anytime bucket, including those
+	// scheduled into Today.
+	AnytimeCount int `json:"anytime_count"`
+	// SomedayCount counts open todos in the Someday bucket, including those
+	// scheduled for a date.
+	SomedayCount int `json:"someday_count"`
+	// CompletedCount and CanceledCount count closed todos, as in the Logbook.
+	CompletedCount int `json:"completed_count"`
+	CanceledCount  int `json:"canceled_count"`
+	// ProjectCount counts open projects.
+	ProjectCount int `json:"project_count"`
+	AreaCount    int `json:"area_count"`
+	TagCount     int `json:"tag_count"`
+}
+
+// Stats returns the item counts in one pass of COUNT queries, without loading
+// any todos. Trashed items are left out, as in every query.
+func (c *Client) Stats(ctx context.Context) (Stats, error) {
+	todos := c.database.Todos()
+	open := todos.Status().Incomplete()
+
+	var stats Stats
+	counts := []struct {
+		dst   *int
+		count func(context.Context) (int, error)
+	}{
+		{&stats.InboxCount, open.Start().Inbox().Count},
+		{&stats.TodayCount, todos.todayView().Status().Incomplete().Count},
+		{&stats.UpcomingCount, open.StartDate().Future().Start().Someday().Count},
+		{&stats.UpcomingCount, todos.repeatingTemplates().StartDate().Future().Status().Incomplete().Count},
+		{&stats.AnytimeCount, open.Start().Anytime().Count},
+		{&stats.SomedayCount, open.Start().Someday().Count},
+		{&stats.CompletedCount, todos.Status().Completed().Count},
+		{&stats.CanceledCount, todos.Status().Canceled().Count},
+		{&stats.ProjectCount, c.database.Projects().Status().Incomplete().Count},
+		{&stats.AreaCount, c.database.Areas().Count},
+		{&stats.TagCount, c.database.Tags().Count},
+	}
+	for _, cnt := range counts {
+		n, err := cnt.count(ctx)
+		if err != nil {
+			return Stats{}, err
+		}
+		*cnt.dst += n
+	}
+	return stats, nil
+}
@@ -0,0 +1,48 @@
+package things3
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientStats(t *testing.T) {
+	client := newTestClient(t)
+	ctx := t.Context()
+
+	stats, err := client.Stats(ctx)
+	require.NoError(t, err)
+
+	lengths := map[string]struct {
+		got  int
+		list func() (int, error)
+	}{
+		"inbox":     {stats.InboxCount, lenOf(ctx, client.Todos().Start().Inbox().Status().Incomplete().All)},
+		"today":     {stats.TodayCount, lenOf(ctx, client.Today)},
+		"upcoming":  {stats.UpcomingCount, lenOf(ctx, client.Upcoming)},
+		"anytime":   {stats.AnytimeCount, lenOf(ctx, client.Todos().Start().Anytime().Status().Incomplete().All)},
+		"someday":   {stats.SomedayCount, lenOf(ctx, client.Todos().Start().Someday().Status().Incomplete().All)},
+		"completed": {stats.CompletedCount, lenOf(ctx, client.Todos().Status().Completed().All)},
+		"canceled":  {stats.CanceledCount, lenOf(ctx, client.Todos().Status().Canceled().All)},
+		"projects":  {stats.ProjectCount, lenOf(ctx, client.Projects().Status().Incomplete().All)},
+		"areas":     {stats.AreaCount, lenOf(ctx, client.Areas().All)},
+		"tags":      {stats.TagCount, lenOf(ctx, client.Tags().All)},
+	}
+	for name, tt := range lengths {
+		want, err := tt.list()
+		require.NoError(t, err, name)
+		assert.Equal(t, want, tt.got, name)
+	}
+	assert.Positive(t, stats.InboxCount)
+	assert.Positive(t, stats.TagCount)
+}
+
+// lenOf adapts a list method to return the length of its result.
+func lenOf[T any](ctx context.Context, list func(context.Context) ([]T, error)) func() (int, error) {
+	return func() (int, error) {
+		items, err := list(ctx)
+		return len(items), err
+	}
+}
@@ -0,0 +1,126 @@
+package things3
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// Things accepts at most renameBatchItems items through the JSON command in
+// renameBatchInterval, and may drop a longer URL.
+const (
+	renameBatchItems    = 250
+	renameBatchInterval = 10 * time.Second
+)
+
+// RenameTag moves every todo and project tagged oldTitle to newTitle through
+// authenticated JSON batches, leaving their other tags in place.
+//
+// The database is read-only, so the rename goes through the URL scheme: the
+// affected items are queried first, then updated with their rewritten tag
+// lists. Things ignores tags that do not exist, so newTitle must already be a
+// tag; RenameTag returns ErrTagNotFound otherwise. The oldTitle tag itself is
+// not deleted and remains, unused, in the tag list. Trashed items are not
+// touched. When no item carries oldTitle, nothing is executed.
+//
+// Things throttles the JSON command to 250 items per 10 seconds, so a rename
+// touching more items is sent in batches of 250, ten seconds apart. RenameTag
+// stops at the first batch that fails or when ctx ends, and batches already
+// sent stay applied.
+func (c *Client) RenameTag(ctx context.Context, oldTitle, newTitle string) error {
+	batches, err := c.renameTagBatches(ctx, oldTitle, newTitle)
+	if err != nil {
+		return err
+	}
+	for i, batch := range batches {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return context.Cause(ctx)
+			case <-time.After(renameBatchInterval):
+			}
+		}
+		if err := batch.Execute(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renameTagBatches builds the batches behind RenameTag, each of at most
+// renameBatchItems updates. It returns none when there is nothing to update.
+func (c *Client) renameTagBatches(ctx context.Context, oldTitle, newTitle string) ([]AuthBatchCreator, error) {
+	if oldTitle == newTitle {
+		return nil, nil
+	}
+	if _, err := c.Tags().WithTitle(newTitle).First(ctx); err != nil {
+		return nil, err
+	}
+
+	todos, err := c.Todos().InTag(oldTitle).Status().Any().All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	projects, err := c.Projects().InTag(oldTitle).Status().Any().All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make([]func(AuthBatchCreator) AuthBatchCreator, 0, len(todos)+len(projects))
+	for i := range todos {
+		uuid, tags := todos[i].UUID, replaceTag(todos[i].Tags, oldTitle, newTitle)
+		updates = append(updates, func(batch AuthBatchCreator) AuthBatchCreator {
+			return batch.UpdateTodo(uuid, func(b BatchTodoConfigurator) { b.Tags(tags...) })
+		})
+	}
+	for i := range projects {
+		uuid, tags := projects[i].UUID, replaceTag(projects[i].Tags, oldTitle, newTitle)
+		updates = append(updates, func(batch AuthBatchCreator) AuthBatchCreator {
+			return batch.UpdateProject(uuid, func(b BatchProjectConfigurator) { b.Tags(tags...) })
+		})
+	}
+
+	var batches []AuthBatchCreator
+	for chunk := range slices.Chunk(updates, renameBatchItems) {
+		batch := c.AuthBatch()
+		for _, update := range chunk {
+			batch = update(batch)
+		}
+		batches = append(batches, batch)
+	}
+	return batches, nil
+}
+
+// TagsUsedInArea returns the tags applied to any untrashed todo or project in
+// the area, including todos filed in the area's projects and their headings,
+// in tag list order. Closed tasks count, so the result suits suggesting tags
+// for a new task in the area. The area's own tags are not included unless a
+// task carries them too. The result is never nil.
+func (c *Client) TagsUsedInArea(ctx context.Context, areaUUID string) ([]Tag, error) {
+	return c.database.Tags().usedInArea(areaUUID).All(ctx)
+}
+
+// UnusedTags returns the tags applied to no todo, project or area, in tag
+// list order, as candidates for pruning. A tag still on a trashed task counts
+// as used until the trash is emptied. A parent tag whose children are in use
+// is reported too; query Tags().WithParent before deleting one in Things. The
+// result is never nil.
+func (c *Client) UnusedTags(ctx context.Context) ([]Tag, error) {
+	return c.database.Tags().unused().All(ctx)
+}
+
+// replaceTag returns tags with oldTitle swapped for newTitle in place,
+// dropping the swap when newTitle is already present.
+func replaceTag(tags []string, oldTitle, newTitle string) []string {
+	has := slices.Contains(tags, newTitle)
+	out := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		switch {
+		case tag != oldTitle:
+			out = append(out, tag)
+		case !has:
+			out = append(out, newTitle)
+		}
+	}
+	return out
+}
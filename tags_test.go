@@ -0,0 +1,156 @@
+package things3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplaceTag(t *testing.T) {
+	tests := []struct {
+		name string
+		tags []string
+		want []string
+	}{
+		{"swaps in place", []string{"Errand", "Office", "Pending"}, []string{"Errand", "Work", "Pending"}},
+		{"drops duplicate", []string{"Work", "Office"}, []string{"Work"}},
+		{"absent old", []string{"Errand"}, []string{"Errand"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, replaceTag(tt.tags, "Office", "Work"))
+		})
+	}
+}
+
+func TestClientRenameTagBatch(t *testing.T) {
+	dbPath := copyWritableFixture(t)
+	execFixtureSQL(t, dbPath, "INSERT INTO TMTaskTag (tasks, tags) VALUES (?, ?)",
+		testUUIDProjectInArea1, testUUIDTagErrand)
+
+	client, err := NewClient(WithDatabasePath(dbPath))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	ctx := t.Context()
+
+	batches, err := client.renameTagBatches(ctx, "Errand", "Home")
+	require.NoError(t, err)
+	require.Len(t, batches, 1)
+	thingsURL, err := batches[0].Build()
+	require.NoError(t, err)
+
+	_, params := parseThingsURL(t, thingsURL)
+	assert.Equal(t, testAuthToken, params.Get("auth-token"))
+
+	items := parseJSONItems(t, thingsURL)
+	require.Len(t, items, 2)
+	assert.Equal(t, JSONItem{
+		Type: JSONItemTypeTodo, Operation: JSONOperationUpdate, ID: testUUIDTodoInArea1Tags,
+		Attributes: map[string]any{"tags": []any{"Home"}},
+	}, items[0], "Errand merges into the todo's existing Home tag")
+	assert.Equal(t, JSONItem{
+		Type: JSONItemTypeProject, Operation: JSONOperationUpdate, ID: testUUIDProjectInArea1,
+		Attributes: map[string]any{"tags": []any{"Home"}},
+	}, items[1])
+}
+
+func TestClientRenameTagBatchSplits(t *testing.T) {
+	dbPath := copyWritableFixture(t)
+	// 250 copies of the one Errand todo make 251 items to update.
+	copied := execFixtureSQL(t, dbPath, `WITH RECURSIVE n(i) AS (SELECT 1 UNION ALL SELECT i + 1 FROM n WHERE i < 250)
+		INSERT INTO TMTask (uuid, type, status, trashed, title, start, "index", todayIndex, area,
+			creationDate, userModificationDate)
+		SELECT 'rename-' || i, type, status, trashed, title, start, "index" + i, todayIndex, area,
+			creationDate, userModificationDate FROM TMTask, n WHERE uuid = ?`,
+		testUUIDTodoInArea1Tags)
+	require.EqualValues(t, 250, copied)
+	execFixtureSQL(t, dbPath, `INSERT INTO TMTaskTag (tasks, tags) SELECT uuid, ? FROM TMTask WHERE uuid LIKE 'rename-%'`,
+		testUUIDTagErrand)
+
+	client, err := NewClient(WithDatabasePath(dbPath))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+
+	batches, err := client.renameTagBatches(t.Context(), "Errand", "Home")
+	require.NoError(t, err)
+	require.Len(t, batches, 2)
+	first, err := batches[0].Build()
+	require.NoError(t, err)
+	assert.Len(t, parseJSONItems(t, first), 250)
+	second, err := batches[1].Build()
+	require.NoError(t, err)
+	assert.Len(t, parseJSONItems(t, second), 1)
+}
+
+func TestClientRenameTagBatchNoop(t *testing.T) {
+	client := newTestClient(t)
+	ctx := t.Context()
+
+	batches, err := client.renameTagBatches(ctx, "Office", "Office")
+	require.NoError(t, err)
+	assert.Empty(t, batches, "renaming a tag to itself is a no-op")
+
+	batches, err = client.renameTagBatches(ctx, "Nonexistent Tag", "Home")
+	require.NoError(t, err)
+	assert.Empty(t, batches, "no tagged items means nothing to execute")
+
+	require.NoError(t, client.RenameTag(ctx, "Nonexistent Tag", "Home"))
+
+	_, err = client.renameTagBatches(ctx, "Office", "Nonexistent Tag")
+	require.ErrorIs(t, err, ErrTagNotFound)
+}
+
+func TestClientTagsUsedInArea(t *testing.T) {
+	dbPath := copyWritableFixture(t)
+	client, err := NewClient(WithDatabasePath(dbPath))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	ctx := t.Context()
+
+	tagTitles := func(areaUUID string) []string {
+		t.Helper()
+		tags, err := client.TagsUsedInArea(ctx, areaUUID)
+		require.NoError(t, err)
+		require.NotNil(t, tags)
+		titles := make([]string, len(tags))
+		for i := range tags {
+			titles[i] = tags[i].Title
+		}
+		return titles
+	}
+
+	assert.Equal(t, []string{"Errand", "Home"}, tagTitles(testUUIDArea1), "via the todo's project")
+	assert.Empty(t, tagTitles(testUUIDArea2))
+
+	// A todo under a heading reaches the area through the heading's project.
+	execFixtureSQL(t, dbPath, "INSERT INTO TMTaskTag (tasks, tags) SELECT ?, uuid FROM TMTag WHERE title = 'Office'",
+		testUUIDTodoInHeading)
+	assert.Contains(t, tagTitles(testUUIDArea1), "Office")
+
+	execFixtureSQL(t, dbPath, "UPDATE TMTask SET trashed = 1 WHERE uuid = ?", testUUIDTodoInHeading)
+	assert.NotContains(t, tagTitles(testUUIDArea1), "Office", "trashed tasks do not count")
+}
+
+func TestClientUnusedTags(t *testing.T) {
+	dbPath := copyWritableFixture(t)
+	client, err := NewClient(WithDatabasePath(dbPath))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	ctx := t.Context()
+
+	tags, err := client.UnusedTags(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, tags)
+	assert.Empty(t, tags, "every fixture tag is applied to something")
+
+	// Dropping Office from its only task leaves it unused.
+	execFixtureSQL(t, dbPath, "DELETE FROM TMTaskTag WHERE tags = 'Qt2AY87x2QDdowSn9HKTt1'")
+	// Errand stays used through its area even without tasks.
+	execFixtureSQL(t, dbPath, "DELETE FROM TMTaskTag WHERE tags IN (SELECT uuid FROM TMTag WHERE title = 'Errand')")
+
+	tags, err = client.UnusedTags(ctx)
+	require.NoError(t, err)
+	require.Len(t, tags, 1)
+	assert.Equal(t, "Office", tags[0].Title)
+}
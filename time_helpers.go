@@ -3,6 +3,9 @@ package things3
 import (
 	"fmt"
 	"time"
+
+	"github.com/moond4rk/things3/internal/database"
+	"github.com/moond4rk/things3/internal/scheme"
 )
 
 // Today returns today's date at midnight (00:00:00) in local timezone.
@@ -12,8 +15,20 @@ import (
 //
 //	client.AddTodo().Title("Morning task").When(things3.Today())
 func Today() time.Time {
-	now := time.Now()
-	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return startOfDay(time.Now())
+}
+
+// today is Today for the client, following its WithClock.
+func (c *Client) today() time.Time {
+	if c.clock == nil {
+		return Today()
+	}
+	return startOfDay(c.clock())
+}
+
+// startOfDay returns midnight at the start of t's day, in t's location.
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
 }
 
 // Tomorrow returns tomorrow's date at midnight (00:00:00) in local timezone.
@@ -66,6 +81,20 @@ func YearsAgo(n int) time.Time {
 	return time.Now().AddDate(-n, 0, 0)
 }
 
+// ValidateISODate reports whether s is a yyyy-mm-dd date Things can store,
+// such as a deadline taken from a command-line flag. Malformed input,
+// impossible days like 2023-02-29, and the empty string return an error
+// matching ErrInvalidDate.
+//
+// Example:
+//
+//	if err := things3.ValidateISODate(deadline); err != nil {
+//	    return err
+//	}
+func ValidateISODate(s string) error {
+	return database.ValidateISODate(s)
+}
+
 // When keyword strings accepted by ParseWhen and ApplyWhen.
 const (
 	whenKeywordToday    = "today"
@@ -85,8 +114,8 @@ type WhenScheduler[T any] interface {
 	WhenSomeday() T
 }
 
-// ParseWhen parses a when string and applies scheduling to a builder.
-// Supports:
+// ParseWhen parses a when string and applies scheduling to a builder. Input
+// is trimmed and matched case-insensitively. Supports:
 //   - "today": schedules for today
 //   - "tomorrow": schedules for tomorrow
 //   - "evening": schedules for this evening
@@ -94,15 +123,20 @@ type WhenScheduler[T any] interface {
 //   - "someday": schedules for someday (indefinite future)
 //   - "yyyy-mm-dd": schedules for specific date
 //
-// Unrecognized input returns the builder unchanged along with a descriptive
-// error. Use ApplyWhen to silently ignore invalid input instead.
+// Unrecognized input returns the builder unchanged along with an error
+// matching ErrInvalidWhen. Use ApplyWhen to silently ignore invalid input
+// instead.
 //
 // Example:
 //
 //	todo := client.AddTodo().Title("Buy milk")
 //	todo, err := things3.ParseWhen(todo, "2024-12-25")
 func ParseWhen[T WhenScheduler[T]](b T, when string) (T, error) {
-	switch when {
+	normalized, err := scheme.NormalizeWhen(scheme.When(when))
+	if err != nil {
+		return b, fmt.Errorf("%w: %q", err, when)
+	}
+	switch normalized {
 	case whenKeywordToday:
 		return b.When(Today()), nil
 	case whenKeywordTomorrow:
@@ -114,13 +148,9 @@ func ParseWhen[T WhenScheduler[T]](b T, when string) (T, error) {
 	case whenKeywordSomeday:
 		return b.WhenSomeday(), nil
 	default:
-		if t, err := time.Parse(time.DateOnly, when); err == nil {
-			return b.When(t), nil
-		}
-		return b, fmt.Errorf(
-			"things3: unrecognized when value %q (expected today, tomorrow, evening, anytime, someday, or yyyy-mm-dd)",
-			when,
-		)
+		// NormalizeWhen accepted it, so it is a valid date.
+		t, _ := time.Parse(time.DateOnly, string(normalized))
+		return b.When(t), nil
 	}
 }
 
@@ -78,6 +78,8 @@ func TestParseWhen(t *testing.T) {
 		{"anytime keyword", whenKeywordAnytime, whenKeywordAnytime, false},
 		{"someday keyword", whenKeywordSomeday, whenKeywordSomeday, false},
 		{"specific date", testWhenDate, testWhenDate, false},
+		{"case and space are normalized", " Evening ", whenKeywordEvening, false},
+		{"uppercase keyword", "TODAY", todayStr, false},
 		{"unrecognized word", "invalid", "", true},
 		{"malformed date", "2024-13-45", "", true},
 		{"empty string", "", "", true},
@@ -88,7 +90,7 @@ func TestParseWhen(t *testing.T) {
 			todo, err := ParseWhen(scheme.AddTodo().Title("Test"), tt.when)
 
 			if tt.wantErr {
-				require.ErrorContains(t, err, "unrecognized when value")
+				require.ErrorIs(t, err, ErrInvalidWhen)
 				if tt.when != "" {
 					require.ErrorContains(t, err, tt.when)
 				}
@@ -190,3 +192,34 @@ func TestApplyWhenWithDifferentBuilders(t *testing.T) {
 	_, updateProjectParams := parseThingsURL(t, updateProjectURL)
 	assert.Equal(t, whenKeywordSomeday, updateProjectParams.Get("when"))
 }
+
+// User input reaches the batch builders through ParseWhen, which rejects
+// what Things would silently ignore.
+func TestParseWhenBatch(t *testing.T) {
+	scheme := newScheme()
+
+	var parseErr error
+	thingsURL, err := scheme.Batch().
+		AddTodo(func(todo BatchTodoConfigurator) {
+			_, parseErr = ParseWhen(todo.Title("T"), "next week")
+		}).
+		AddProject(func(project BatchProjectConfigurator) {
+			_, err := ParseWhen(project.Title("P"), " Someday")
+			require.NoError(t, err)
+		}).
+		Build()
+	require.ErrorIs(t, parseErr, ErrInvalidWhen)
+	require.NoError(t, err)
+
+	items := parseJSONItems(t, thingsURL)
+	require.Len(t, items, 2)
+	assert.NotContains(t, items[0].Attributes, "when")
+	assert.Equal(t, whenKeywordSomeday, items[1].Attributes["when"])
+}
+
+func TestValidateISODate(t *testing.T) {
+	require.NoError(t, ValidateISODate("2024-12-25"))
+	err := ValidateISODate("2024-02-30")
+	require.ErrorIs(t, err, ErrInvalidDate)
+	assert.Contains(t, err.Error(), "day out of range")
+}
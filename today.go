@@ -2,46 +2,49 @@ package things3
 
 import (
 	"context"
-	"slices"
+	"time"
 )
 
 // Today returns the todos in the Things Today view: todos scheduled into Today,
 // Someday todos whose scheduled date has arrived, and overdue-deadline todos,
-// concatenated in the app's display order. Within the scheduled-today group,
-// This Evening todos are placed after the rest, mirroring the app's Evening
-// section. The result is never nil.
+// in the app's display order and fetched in a single query. Within the
+// scheduled-today group, This Evening todos are placed after the rest,
+// mirroring the app's Evening section. The result is never nil. Projects
+// scheduled for today are listed by TodayProjects.
 func (c *Client) Today(ctx context.Context) ([]Todo, error) {
-	base := c.database.Todos()
+	return c.database.Todos().
+		todayView().
+		Status().Incomplete().
+		All(ctx)
+}
+
+// TodayProjects returns the projects in the Things Today view, which the app
+// lists alongside the todos returned by Today. The same three groups apply:
+// projects scheduled into Today in today-index order, Someday projects whose
+// scheduled date has arrived, and projects with an overdue deadline. The
+// result is never nil. Under WithSkipCorruptRows the readable projects are
+// returned with a *CorruptRowsError.
+func (c *Client) TodayProjects(ctx context.Context) ([]Project, error) {
+	base := c.database.Projects()
+	var skipped skippedRows
 
 	regular, err := base.
+		OrderByTodayIndex().
 		StartDate().Exists(true).
 		Start().Anytime().
 		Status().Incomplete().
-		OrderByTodayIndex().
 		All(ctx)
-	if err != nil {
+	if err := skipped.add(err); err != nil {
 		return nil, err
 	}
-	// This Evening todos form the bottom section of Today; move them after the
-	// rest while preserving todayIndex order within each part.
-	slices.SortStableFunc(regular, func(a, b Todo) int {
-		switch {
-		case a.Evening == b.Evening:
-			return 0
-		case a.Evening:
-			return 1
-		default:
-			return -1
-		}
-	})
 
 	scheduled, err := base.
+		OrderByTodayIndex().
 		StartDate().Past().
 		Start().Someday().
 		Status().Incomplete().
-		OrderByTodayIndex().
 		All(ctx)
-	if err != nil {
+	if err := skipped.add(err); err != nil {
 		return nil, err
 	}
 
@@ -51,13 +54,49 @@ func (c *Client) Today(ctx context.Context) ([]Todo, error) {
 		Deadline().Past().
 		Status().Incomplete().
 		All(ctx)
-	if err != nil {
+	if err := skipped.add(err); err != nil {
 		return nil, err
 	}
 
-	todos := make([]Todo, 0, len(regular)+len(scheduled)+len(overdue))
-	todos = append(todos, regular...)
-	todos = append(todos, scheduled...)
-	todos = append(todos, overdue...)
-	return todos, nil
+	projects := make([]Project, 0, len(regular)+len(scheduled)+len(overdue))
+	projects = append(projects, regular...)
+	projects = append(projects, scheduled...)
+	projects = append(projects, overdue...)
+	return projects, skipped.result()
+}
+
+// SuppressedDeadlines returns the incomplete todos with a past deadline that
+// the user dismissed from Today, earliest deadline first. These are exactly
+// the overdue todos Today leaves out. The result is never nil.
+func (c *Client) SuppressedDeadlines(ctx context.Context) ([]Todo, error) {
+	return c.database.Todos().
+		deadlineSuppressed(true).
+		Deadline().Past().
+		Status().Incomplete().
+		OrderByDeadline(false).
+		All(ctx)
+}
+
+// IsInToday reports whether the todo belongs in the Things Today view on the
+// local day of now, applying the rules of Today without a query: scheduled
+// into Today, Someday with a scheduled date that has arrived, or an overdue
+// deadline the user has not dismissed. Only open, untrashed todos qualify.
+// Dismissed deadlines are known only for todos loaded from the database.
+func (t *Todo) IsInToday(now time.Time) bool {
+	if t.Status != StatusIncomplete || t.Trashed {
+		return false
+	}
+	local := now.In(time.Local)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.Local)
+
+	switch {
+	case t.StartDate != nil && t.Start == StartAnytime:
+		return true
+	case t.StartDate != nil && t.Start == StartSomeday:
+		return !t.StartDate.After(day)
+	case t.StartDate == nil && t.Deadline != nil:
+		return !t.deadlineSuppressed && !t.Deadline.After(day)
+	default:
+		return false
+	}
 }
@@ -1,11 +1,14 @@
 package things3
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -43,6 +46,229 @@ func TestClientToday(t *testing.T) {
 	}
 }
 
+// TestClientTodayOrder pins the full Today list against the fixture: the
+// scheduled-today group in todayIndex order, then yellow-dot Someday todos,
+// then overdue deadlines. The fixture's future-dated Someday todo is pushed a
+// year out so the expectation does not drift with the calendar.
+func TestClientTodayOrder(t *testing.T) {
+	dbPath := copyWritableFixture(t)
+	require.Equal(t, int64(1), execFixtureSQL(t, dbPath,
+		"UPDATE TMTask SET startDate = ? WHERE uuid = '7F4vqUNiTvGKaCUfv5pqYG'",
+		packThingsDate(Today().AddDate(1, 0, 0))))
+
+	client, err := NewClient(WithDatabasePath(dbPath))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	ctx := t.Context()
+
+	todos, err := client.Today(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		testUUIDTodoInToday,        // todayIndex -519
+		testUUIDTodoRepeating,      // repeat instance, todayIndex -75
+		"6Hf2qWBjWhq7B1xszwdo34",   // Someday with a past start date
+		testUUIDTodoOverdueInToday, // overdue deadline, no start date
+	}, extractTodoUUIDs(todos))
+
+	projects, err := client.TodayProjects(ctx)
+	require.NoError(t, err)
+	require.Len(t, projects, 1)
+	assert.Equal(t, "PgsWnDkzXRz6zvofTqtHqn", projects[0].UUID, "Project in Today")
+}
+
+// todayThreeQueries is the former Today implementation, one query per group
+// merged in memory. It is kept as the reference the single-query view is
+// checked and benchmarked against.
+func todayThreeQueries(ctx context.Context, c *Client) ([]Todo, error) {
+	base := c.database.Todos()
+
+	regular, err := base.
+		StartDate().Exists(true).
+		Start().Anytime().
+		Status().Incomplete().
+		OrderByTodayIndex().
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(regular, func(a, b Todo) int {
+		switch {
+		case a.Evening == b.Evening:
+			return 0
+		case a.Evening:
+			return 1
+		default:
+			return -1
+		}
+	})
+
+	scheduled, err := base.
+		StartDate().Past().
+		Start().Someday().
+		Status().Incomplete().
+		OrderByTodayIndex().
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	overdue, err := base.
+		deadlineSuppressed(false).
+		StartDate().Exists(false).
+		Deadline().Past().
+		Status().Incomplete().
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.Concat(regular, scheduled, overdue), nil
+}
+
+// TestClientTodayMatchesThreeQueries checks the single-query view against the
+// per-group reference, including an Evening todo that must sort after the
+// rest of the scheduled group despite a lower todayIndex.
+func TestClientTodayMatchesThreeQueries(t *testing.T) {
+	dbPath := copyWritableFixture(t)
+	require.Equal(t, int64(1), execFixtureSQL(t, dbPath,
+		"UPDATE TMTask SET startBucket = 1, todayIndex = -9999 WHERE uuid = ?", testUUIDTodoRepeating))
+
+	client, err := NewClient(WithDatabasePath(dbPath))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	ctx := t.Context()
+
+	want, err := todayThreeQueries(ctx, client)
+	require.NoError(t, err)
+	got, err := client.Today(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	uuids := extractTodoUUIDs(got)
+	assert.Equal(t, testUUIDTodoInToday, uuids[0])
+	assert.Equal(t, testUUIDTodoRepeating, uuids[1], "This Evening follows the rest of the scheduled group")
+}
+
+func BenchmarkToday(b *testing.B) {
+	initTestPaths()
+	client, err := NewClient(WithDatabasePath(testDatabasePath))
+	require.NoError(b, err)
+	b.Cleanup(func() { _ = client.Close() })
+	ctx := b.Context()
+
+	b.Run("SingleQuery", func(b *testing.B) {
+		for b.Loop() {
+			if _, err := client.Today(ctx); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("ThreeQueries", func(b *testing.B) {
+		for b.Loop() {
+			if _, err := todayThreeQueries(ctx, client); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestClientTodayProjectsGroups(t *testing.T) {
+	dbPath := copyWritableFixture(t)
+	today := Today()
+	// A Someday project whose date has arrived, and an open project with an
+	// overdue deadline; the regular group keeps todayIndex order.
+	execFixtureSQL(t, dbPath, "UPDATE TMTask SET startDate = ? WHERE uuid = 'SmdyProjTestFixture001'",
+		packThingsDate(today.AddDate(0, 0, -2)))
+	execFixtureSQL(t, dbPath, "UPDATE TMTask SET deadline = ? WHERE uuid = ?",
+		packThingsDate(today.AddDate(0, 0, -1)), testUUIDProjectInArea1)
+	execFixtureSQL(t, dbPath,
+		"UPDATE TMTask SET startDate = ?, start = 1, todayIndex = -2000 WHERE uuid = 'TCozQqXVbB2TJkXXXQj2H9'",
+		packThingsDate(today))
+
+	client, err := NewClient(WithDatabasePath(dbPath))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+
+	projects, err := client.TodayProjects(t.Context())
+	require.NoError(t, err)
+	uuids := make([]string, len(projects))
+	for i := range projects {
+		uuids[i] = projects[i].UUID
+	}
+	assert.Equal(t, []string{
+		"TCozQqXVbB2TJkXXXQj2H9", // regular, todayIndex -2000
+		"PgsWnDkzXRz6zvofTqtHqn", // regular, todayIndex -1013
+		"SmdyProjTestFixture001", // Someday, date arrived
+		testUUIDProjectInArea1,   // overdue deadline
+	}, uuids)
+}
+
+func TestClientSuppressedDeadlines(t *testing.T) {
+	client := newTestClient(t)
+	ctx := t.Context()
+
+	todos, err := client.SuppressedDeadlines(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{testUUIDTodoOverdueNotToday}, extractTodoUUIDs(todos))
+
+	today, err := client.Today(ctx)
+	require.NoError(t, err)
+	assert.NotContains(t, extractTodoUUIDs(today), testUUIDTodoOverdueNotToday,
+		"a dismissed deadline must not resurface in Today")
+}
+
+func TestTodoIsInTodayMatchesToday(t *testing.T) {
+	client := newTestClient(t)
+	ctx := t.Context()
+
+	today, err := client.Today(ctx)
+	require.NoError(t, err)
+	all, err := client.Todos().Status().Any().All(ctx)
+	require.NoError(t, err)
+
+	now := time.Now()
+	var inToday []string
+	for i := range all {
+		if all[i].IsInToday(now) {
+			inToday = append(inToday, all[i].UUID)
+		}
+	}
+	assert.ElementsMatch(t, extractTodoUUIDs(today), inToday)
+}
+
+func TestTodoIsInToday(t *testing.T) {
+	now := time.Date(2026, time.March, 10, 15, 0, 0, 0, time.Local)
+	day := func(offset int) *time.Time {
+		d := time.Date(2026, time.March, 10+offset, 0, 0, 0, 0, time.Local)
+		return &d
+	}
+
+	tests := []struct {
+		name string
+		todo Todo
+		want bool
+	}{
+		{"scheduled today", Todo{Start: StartAnytime, StartDate: day(0)}, true},
+		{"anytime without date", Todo{Start: StartAnytime}, false},
+		{"someday date arrived", Todo{Start: StartSomeday, StartDate: day(-1)}, true},
+		{"someday date today", Todo{Start: StartSomeday, StartDate: day(0)}, true},
+		{"someday date ahead", Todo{Start: StartSomeday, StartDate: day(1)}, false},
+		{"deadline passed", Todo{Start: StartAnytime, Deadline: day(-2)}, true},
+		{"deadline today", Todo{Start: StartInbox, Deadline: day(0)}, true},
+		{"deadline ahead", Todo{Start: StartAnytime, Deadline: day(3)}, false},
+		{"deadline dismissed", Todo{Start: StartAnytime, Deadline: day(-2), deadlineSuppressed: true}, false},
+		{"deadline with future start", Todo{Start: StartSomeday, StartDate: day(2), Deadline: day(-1)}, false},
+		{"completed", Todo{Status: StatusCompleted, Start: StartAnytime, StartDate: day(0)}, false},
+		{"trashed", Todo{Trashed: true, Start: StartAnytime, StartDate: day(0)}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.todo.IsInToday(now))
+		})
+	}
+}
+
 func TestClientTodayEmptyIsNonNil(t *testing.T) {
 	dbPath := copyWritableFixture(t)
 	execFixtureSQL(t, dbPath, "UPDATE TMTask SET status = 3 WHERE status = 0")
@@ -55,6 +281,16 @@ func TestClientTodayEmptyIsNonNil(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, todos)
 	assert.Empty(t, todos)
+
+	projects, err := client.TodayProjects(t.Context())
+	require.NoError(t, err)
+	require.NotNil(t, projects)
+	assert.Empty(t, projects)
+
+	suppressed, err := client.SuppressedDeadlines(t.Context())
+	require.NoError(t, err)
+	require.NotNil(t, suppressed)
+	assert.Empty(t, suppressed)
 }
 
 func TestClientTodayEveningSortsAfterRegular(t *testing.T) {
@@ -185,3 +421,54 @@ func execFixtureSQL(t *testing.T, dbPath, query string, args ...any) int64 {
 	require.NoError(t, err)
 	return n
 }
+
+func TestClientWithClock(t *testing.T) {
+	initTestPaths()
+	ctx := t.Context()
+	// The fixture schedules testUUIDTodoInUpcoming for 2026-09-17.
+	clientAt := func(t *testing.T, now time.Time) *Client {
+		t.Helper()
+		client, err := NewClient(WithDatabasePath(testDatabasePath), WithClock(func() time.Time { return now }))
+		require.NoError(t, err)
+		t.Cleanup(func() { client.Close() })
+		return client
+	}
+
+	t.Run("day before the start date", func(t *testing.T) {
+		client := clientAt(t, time.Date(2026, time.September, 16, 23, 59, 0, 0, time.Local))
+		today, err := client.Today(ctx)
+		require.NoError(t, err)
+		assert.NotContains(t, extractTodoUUIDs(today), testUUIDTodoInUpcoming)
+		upcoming, err := client.Upcoming(ctx)
+		require.NoError(t, err)
+		assert.Contains(t, extractTodoUUIDs(upcoming), testUUIDTodoInUpcoming)
+	})
+
+	t.Run("on the start date", func(t *testing.T) {
+		client := clientAt(t, time.Date(2026, time.September, 17, 0, 1, 0, 0, time.Local))
+		first, err := client.Today(ctx)
+		require.NoError(t, err)
+		assert.Contains(t, extractTodoUUIDs(first), testUUIDTodoInUpcoming)
+		upcoming, err := client.Upcoming(ctx)
+		require.NoError(t, err)
+		assert.NotContains(t, extractTodoUUIDs(upcoming), testUUIDTodoInUpcoming)
+
+		second, err := client.Today(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, extractTodoUUIDs(first), extractTodoUUIDs(second), "a frozen clock gives a stable Today")
+	})
+
+	t.Run("upcoming deadlines follow the clock", func(t *testing.T) {
+		client := clientAt(t, time.Date(2021, time.March, 28, 12, 0, 0, 0, time.Local))
+		due, err := client.UpcomingDeadlines(ctx, 0)
+		require.NoError(t, err)
+		assert.Contains(t, extractTodoUUIDs(due), testUUIDTodoRepeating, "due on the frozen day")
+	})
+
+	t.Run("sql binds the date", func(t *testing.T) {
+		client := clientAt(t, time.Date(2026, time.September, 17, 9, 0, 0, 0, time.Local))
+		sql := client.Todos().StopDate().Past().SQL()
+		assert.Contains(t, sql, "'2026-09-17'")
+		assert.NotContains(t, sql, "date('now'")
+	})
+}
@@ -0,0 +1,89 @@
+package things3
+
+// TreeNode is a project, heading or todo with the items nested under it.
+// Exactly one of Project, Heading and Todo is set.
+type TreeNode struct {
+	Project  *Project    `json:"project,omitempty"`
+	Heading  *Heading    `json:"heading,omitempty"`
+	Todo     *Todo       `json:"todo,omitempty"`
+	Children []*TreeNode `json:"children,omitempty"`
+}
+
+// BuildTree nests already fetched items under their parents without further
+// queries: headings under their project, and todos under their heading, or
+// under their project when they have no heading in the input. An item whose
+// parent is not in the input becomes a root, so a todo whose heading is
+// missing still nests under its project when that is present.
+//
+// A project lists its own todos before its headings, as the app does. Roots
+// are the parentless projects, then headings, then todos. Input order is kept
+// within each group, so pass items in display order. The nodes point into the
+// given slices. The result is never nil.
+func BuildTree(projects []Project, headings []Heading, todos []Todo) []*TreeNode {
+	projectNodes := make(map[string]*TreeNode, len(projects))
+	headingNodes := make(map[string]*TreeNode, len(headings))
+	roots := make([]*TreeNode, 0)
+
+	var projectRoots, headingRoots, todoRoots []*TreeNode
+	for i := range projects {
+		node := &TreeNode{Project: &projects[i]}
+		projectNodes[projects[i].UUID] = node
+		projectRoots = append(projectRoots, node)
+	}
+
+	// Todos attach before headings so a project's own todos come first.
+	for i := range headings {
+		headingNodes[headings[i].UUID] = &TreeNode{Heading: &headings[i]}
+	}
+	for i := range todos {
+		node := &TreeNode{Todo: &todos[i]}
+		if parent, ok := headingNodes[todos[i].HeadingUUID]; ok {
+			parent.Children = append(parent.Children, node)
+		} else if parent, ok := projectNodes[todos[i].ProjectUUID]; ok {
+			parent.Children = append(parent.Children, node)
+		} else {
+			todoRoots = append(todoRoots, node)
+		}
+	}
+	for i := range headings {
+		node := headingNodes[headings[i].UUID]
+		if parent, ok := projectNodes[headings[i].ProjectUUID]; ok {
+			parent.Children = append(parent.Children, node)
+		} else {
+			headingRoots = append(headingRoots, node)
+		}
+	}
+
+	roots = append(roots, projectRoots...)
+	roots = append(roots, headingRoots...)
+	return append(roots, todoRoots...)
+}
+
+// Progress reports how much of the node is done. A project or heading counts
+// the todos beneath it, recursing into headings; a todo counts its checklist
+// items. Completed and canceled both count as done, as in the app's progress
+// pie. A node with nothing to count returns (0, 0).
+func (n *TreeNode) Progress() (completed, total int) {
+	if n.Todo != nil {
+		for _, item := range n.Todo.Checklist {
+			total++
+			if item.Status != StatusIncomplete {
+				completed++
+			}
+		}
+		return completed, total
+	}
+	for _, child := range n.Children {
+		if child.Todo == nil {
+			c, t := child.Progress()
+			completed += c
+			total += t
+			continue
+		}
+		total++
+		if child.Todo.Status != StatusIncomplete {
+			completed++
+		}
+	}
+	return completed, total
+}
@@ -0,0 +1,104 @@
+package things3
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// treeShape renders nodes as "kind:uuid" strings with children in brackets,
+// so a whole tree compares in one assertion.
+func treeShape(nodes []*TreeNode) []string {
+	shape := make([]string, 0, len(nodes))
+	for _, n := range nodes {
+		var s string
+		switch {
+		case n.Project != nil:
+			s = "p:" + n.Project.UUID
+		case n.Heading != nil:
+			s = "h:" + n.Heading.UUID
+		default:
+			s = "t:" + n.Todo.UUID
+		}
+		if len(n.Children) > 0 {
+			s += "[" + strings.Join(treeShape(n.Children), " ") + "]"
+		}
+		shape = append(shape, s)
+	}
+	return shape
+}
+
+func TestBuildTree(t *testing.T) {
+	projects := []Project{{UUID: "P1"}, {UUID: "P2"}}
+	headings := []Heading{
+		{UUID: "H1", ProjectUUID: "P1"},
+		{UUID: "H2", ProjectUUID: "gone"},
+	}
+	todos := []Todo{
+		{UUID: "T1", ProjectUUID: "P1", HeadingUUID: "H1"},
+		{UUID: "T2", ProjectUUID: "P1"},
+		{UUID: "T3", ProjectUUID: "P2", HeadingUUID: "missing"},
+		{UUID: "T4", HeadingUUID: "H2"},
+		{UUID: "T5"},
+	}
+
+	tree := BuildTree(projects, headings, todos)
+	assert.Equal(t, []string{
+		"p:P1[t:T2 h:H1[t:T1]]",
+		"p:P2[t:T3]",
+		"h:H2[t:T4]",
+		"t:T5",
+	}, treeShape(tree))
+
+	require.NotNil(t, tree[0].Project)
+	assert.Same(t, &projects[0], tree[0].Project, "nodes point into the input")
+}
+
+func TestBuildTreeEmpty(t *testing.T) {
+	tree := BuildTree(nil, nil, nil)
+	assert.NotNil(t, tree)
+	assert.Empty(t, tree)
+}
+
+func TestTreeNodeProgress(t *testing.T) {
+	client := newTestClient(t)
+	ctx := t.Context()
+
+	project, err := client.Projects().WithUUID(testUUIDProjectInArea1).First(ctx)
+	require.NoError(t, err)
+	headings, err := client.Headings().InProject(testUUIDProjectInArea1).All(ctx)
+	require.NoError(t, err)
+	todos, err := client.ProjectAllItems(ctx, testUUIDProjectInArea1)
+	require.NoError(t, err)
+
+	tree := BuildTree([]Project{*project}, headings, todos)
+	require.Len(t, tree, 1)
+
+	// Three open todos sit directly in the project; its heading holds one
+	// open, one completed and one canceled todo.
+	completed, total := tree[0].Progress()
+	assert.Equal(t, 2, completed)
+	assert.Equal(t, 6, total)
+
+	heading := tree[0].Children[len(tree[0].Children)-1]
+	require.NotNil(t, heading.Heading)
+	completed, total = heading.Progress()
+	assert.Equal(t, 2, completed)
+	assert.Equal(t, 3, total)
+
+	completed, total = tree[0].Children[0].Progress()
+	assert.Zero(t, completed, "a todo without a checklist has nothing to count")
+	assert.Zero(t, total)
+
+	todo, err := client.Todos().WithUUID(testUUIDTodoInboxChecklist).First(ctx)
+	require.NoError(t, err)
+	completed, total = (&TreeNode{Todo: todo}).Progress()
+	assert.Equal(t, 1, completed, "checklist items count for a todo")
+	assert.Equal(t, 3, total)
+
+	completed, total = (&TreeNode{Project: &Project{}}).Progress()
+	assert.Zero(t, completed)
+	assert.Zero(t, total)
+}
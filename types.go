@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/moond4rk/things3/internal/database"
 	"github.com/moond4rk/things3/internal/scheme"
 )
 
@@ -201,6 +202,104 @@ func parseStartBucket(s string) (StartBucket, error) {
 	}
 }
 
+// Frequency is the calendar unit a repeating task recurs by.
+type Frequency int
+
+const (
+	// FrequencyUnknown indicates a unit the rule decoder does not recognize.
+	FrequencyUnknown Frequency = iota
+	// FrequencyDaily repeats every Interval days.
+	FrequencyDaily
+	// FrequencyWeekly repeats every Interval weeks.
+	FrequencyWeekly
+	// FrequencyMonthly repeats every Interval months.
+	FrequencyMonthly
+	// FrequencyYearly repeats every Interval years.
+	FrequencyYearly
+)
+
+// Frequency string constants.
+const (
+	frequencyStringDaily   = "daily"
+	frequencyStringWeekly  = "weekly"
+	frequencyStringMonthly = "monthly"
+	frequencyStringYearly  = "yearly"
+)
+
+// String returns the string representation of the Frequency.
+func (f Frequency) String() string {
+	switch f {
+	case FrequencyDaily:
+		return frequencyStringDaily
+	case FrequencyWeekly:
+		return frequencyStringWeekly
+	case FrequencyMonthly:
+		return frequencyStringMonthly
+	case FrequencyYearly:
+		return frequencyStringYearly
+	default:
+		return unknownString
+	}
+}
+
+// MarshalJSON implements json.Marshaler for Frequency.
+func (f Frequency) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.String())
+}
+
+// MarshalYAML implements yaml.Marshaler for Frequency.
+func (f Frequency) MarshalYAML() (any, error) {
+	return f.String(), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler for Frequency.
+func (f *Frequency) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	v, err := parseFrequency(str)
+	if err != nil {
+		return err
+	}
+	*f = v
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler for Frequency.
+func (f *Frequency) UnmarshalYAML(unmarshal func(any) error) error {
+	var str string
+	if err := unmarshal(&str); err != nil {
+		return err
+	}
+	v, err := parseFrequency(str)
+	if err != nil {
+		return err
+	}
+	*f = v
+	return nil
+}
+
+// parseFrequency converts a string to Frequency. Unlike the other enums it
+// accepts "unknown", which String produces for an unrecognized unit, so
+// marshaled rules round-trip; any other string is an error.
+func parseFrequency(s string) (Frequency, error) {
+	switch s {
+	case unknownString:
+		return FrequencyUnknown, nil
+	case frequencyStringDaily:
+		return FrequencyDaily, nil
+	case frequencyStringWeekly:
+		return FrequencyWeekly, nil
+	case frequencyStringMonthly:
+		return FrequencyMonthly, nil
+	case frequencyStringYearly:
+		return FrequencyYearly, nil
+	default:
+		return 0, fmt.Errorf("things3: unknown frequency %q", s)
+	}
+}
+
 // Command represents Things URL scheme commands (aliased from internal/scheme).
 type Command = scheme.Command
 
@@ -216,6 +315,17 @@ const (
 	CommandJSON          = scheme.CommandJSON
 )
 
+// OpenMethod selects the command that hands a URL to Things (aliased from internal/scheme).
+type OpenMethod = scheme.OpenMethod
+
+// OpenMethod constants for WithOpenMethod.
+const (
+	OpenMethodAuto       = scheme.OpenMethodAuto
+	OpenMethodForeground = scheme.OpenMethodForeground
+	OpenMethodBackground = scheme.OpenMethodBackground
+	OpenMethodScript     = scheme.OpenMethodScript
+)
+
 // ListID represents built-in Things list identifiers (aliased from internal/scheme).
 type ListID = scheme.ListID
 
@@ -234,6 +344,17 @@ const (
 	ListLoggedProjects = scheme.ListLoggedProjects
 )
 
+// ParsedCommand is a Things URL decoded by ParseURL (aliased from internal/scheme).
+type ParsedCommand = scheme.ParsedCommand
+
+// ParseURL decodes a things:/// URL, such as one returned by a builder's
+// Build, into its command, parameters and, for the json command, its items.
+// It returns an error matching ErrUnknownCommand for a command the URL scheme
+// does not define and ErrInvalidURL for anything else it cannot decode.
+func ParseURL(raw string) (*ParsedCommand, error) {
+	return scheme.ParseURL(raw)
+}
+
 // JSON batch operation types (aliased from internal/scheme).
 type (
 	JSONOperation = scheme.JSONOperation
@@ -247,4 +368,110 @@ const (
 	JSONOperationUpdate = scheme.JSONOperationUpdate
 	JSONItemTypeTodo    = scheme.JSONItemTypeTodo
 	JSONItemTypeProject = scheme.JSONItemTypeProject
+	JSONItemTypeHeading = scheme.JSONItemTypeHeading
 )
+
+// SearchColumn names a field matched by the Search filter.
+type SearchColumn string
+
+const (
+	// SearchColumnTitle matches the task title.
+	SearchColumnTitle SearchColumn = database.SearchColumnTitle
+	// SearchColumnNotes matches the task notes.
+	SearchColumnNotes SearchColumn = database.SearchColumnNotes
+	// SearchColumnArea matches the title of the task's area.
+	SearchColumnArea SearchColumn = database.SearchColumnArea
+	// SearchColumnProject matches the title of the task's project, including
+	// the project of the heading a todo sits under.
+	SearchColumnProject SearchColumn = database.SearchColumnProject
+	// SearchColumnHeading matches the title of the todo's heading.
+	SearchColumnHeading SearchColumn = database.SearchColumnHeading
+	// SearchColumnTag matches the titles of the task's tags.
+	SearchColumnTag SearchColumn = database.SearchColumnTag
+)
+
+// searchColumns is the allow-list SearchIn checks fields against.
+var searchColumns = []SearchColumn{
+	SearchColumnTitle, SearchColumnNotes, SearchColumnArea,
+	SearchColumnProject, SearchColumnHeading, SearchColumnTag,
+}
+
+// SearchMode selects how SearchIn matches its query. Wildcards such as % and
+// _ in the query match literally in every mode but SearchRawLike.
+type SearchMode = database.SearchMode
+
+const (
+	// SearchContains matches the query anywhere in a field, like Search.
+	SearchContains = database.SearchContains
+	// SearchPrefix matches fields that start with the query.
+	SearchPrefix = database.SearchPrefix
+	// SearchExact matches fields equal to the query, ignoring ASCII case.
+	SearchExact = database.SearchExact
+	// SearchRawLike uses the query as a SQLite LIKE pattern, so % and _ are
+	// wildcards; escape them with a backslash to match them literally.
+	SearchRawLike = database.SearchRawLike
+)
+
+// TaskColumn names a column todos and projects can be ordered by with OrderBy.
+type TaskColumn string
+
+const (
+	// TaskColumnStartDate orders by start date; a repeating template uses
+	// the start date of its next occurrence.
+	TaskColumnStartDate TaskColumn = database.OrderStartDate
+	// TaskColumnDeadline orders by deadline.
+	TaskColumnDeadline TaskColumn = database.OrderDeadline
+	// TaskColumnStopDate orders by when the task was completed or canceled.
+	TaskColumnStopDate TaskColumn = database.OrderStopDate
+	// TaskColumnCreated orders by creation time.
+	TaskColumnCreated TaskColumn = database.OrderCreationDate
+	// TaskColumnModified orders by last modification time.
+	TaskColumnModified TaskColumn = database.OrderModificationDate
+	// TaskColumnTitle orders by title, ignoring case.
+	TaskColumnTitle TaskColumn = database.OrderTitle
+	// TaskColumnIndex orders by the manual order arranged in the app.
+	TaskColumnIndex TaskColumn = database.IndexDefault
+)
+
+// taskColumns is the allow-list OrderBy checks columns against, so no
+// caller-supplied name reaches the SQL.
+var taskColumns = []TaskColumn{
+	TaskColumnStartDate, TaskColumnDeadline, TaskColumnStopDate,
+	TaskColumnCreated, TaskColumnModified, TaskColumnTitle, TaskColumnIndex,
+}
+
+// GroupColumn names a field CountBy groups by.
+type GroupColumn string
+
+const (
+	// GroupByArea counts by area UUID.
+	GroupByArea GroupColumn = database.GroupByArea
+	// GroupByProject counts by project UUID, including the project of the
+	// heading a todo sits under.
+	GroupByProject GroupColumn = database.GroupByProject
+	// GroupByStatus counts by status, keyed "incomplete", "completed" and
+	// "canceled".
+	GroupByStatus GroupColumn = database.GroupByStatus
+	// GroupByType counts by type, keyed "to-do", "project" and "heading".
+	GroupByType GroupColumn = database.GroupByType
+	// GroupByTag counts by tag title.
+	GroupByTag GroupColumn = database.GroupByTag
+)
+
+// SortDir is the direction of an OrderBy term.
+type SortDir int
+
+const (
+	// SortAsc sorts from smallest to largest.
+	SortAsc SortDir = iota
+	// SortDesc sorts from largest to smallest.
+	SortDesc
+)
+
+// sortDir returns SortDesc when desc is set and SortAsc otherwise.
+func sortDir(desc bool) SortDir {
+	if desc {
+		return SortDesc
+	}
+	return SortAsc
+}
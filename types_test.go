@@ -41,6 +41,49 @@ func TestStatus_UnmarshalJSON_Unknown(t *testing.T) {
 	assert.Contains(t, err.Error(), "unknown status")
 }
 
+func TestStartBucket_JSONRoundTrip(t *testing.T) {
+	tests := []struct {
+		start   StartBucket
+		jsonStr string
+	}{
+		{StartInbox, `"inbox"`},
+		{StartAnytime, `"anytime"`},
+		{StartSomeday, `"someday"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.jsonStr, func(t *testing.T) {
+			data, err := json.Marshal(tt.start)
+			require.NoError(t, err)
+			assert.Equal(t, tt.jsonStr, string(data))
+
+			var got StartBucket
+			require.NoError(t, json.Unmarshal(data, &got))
+			assert.Equal(t, tt.start, got)
+		})
+	}
+}
+
+func TestStartBucket_UnmarshalJSON_Unknown(t *testing.T) {
+	var got StartBucket
+	require.Error(t, json.Unmarshal([]byte(`"Anytime"`), &got), "bucket names are lowercase")
+}
+
+func TestFrequency_UnmarshalJSON(t *testing.T) {
+	for _, f := range []Frequency{FrequencyUnknown, FrequencyDaily, FrequencyWeekly, FrequencyMonthly, FrequencyYearly} {
+		data, err := json.Marshal(f)
+		require.NoError(t, err)
+		var got Frequency
+		require.NoError(t, json.Unmarshal(data, &got))
+		assert.Equal(t, f, got)
+	}
+
+	var got Frequency
+	err := json.Unmarshal([]byte(`"fortnightly"`), &got)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown frequency")
+}
+
 func TestStatus_StructRoundTrip(t *testing.T) {
 	type wrapper struct {
 		Status Status `json:"status"`
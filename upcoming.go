@@ -13,18 +13,20 @@ import (
 // The database stores only the NEXT occurrence of each repeating task, so a
 // repeating task appears exactly once here, at that next occurrence; expanding a
 // recurrence rule into its full future series is out of scope because the rule
-// is persisted as an opaque binary blob.
+// is only decoded best-effort (see RecurrenceRule).
 //
-// The result is never nil.
+// The result is never nil. Under WithSkipCorruptRows the readable todos are
+// returned with a *CorruptRowsError.
 func (c *Client) Upcoming(ctx context.Context) ([]Todo, error) {
 	base := c.database.Todos()
+	var skipped skippedRows
 
 	scheduled, err := base.
 		StartDate().Future().
 		Start().Someday().
 		Status().Incomplete().
 		All(ctx)
-	if err != nil {
+	if err := skipped.add(err); err != nil {
 		return nil, err
 	}
 
@@ -33,7 +35,7 @@ func (c *Client) Upcoming(ctx context.Context) ([]Todo, error) {
 		StartDate().Future().
 		Status().Incomplete().
 		All(ctx)
-	if err != nil {
+	if err := skipped.add(err); err != nil {
 		return nil, err
 	}
 
@@ -41,13 +43,13 @@ func (c *Client) Upcoming(ctx context.Context) ([]Todo, error) {
 	todos = append(todos, scheduled...)
 	todos = append(todos, repeating...)
 	slices.SortStableFunc(todos, func(a, b Todo) int {
-		return compareStartDateAsc(a.StartDate, b.StartDate)
+		return compareDateAsc(a.StartDate, b.StartDate)
 	})
-	return todos, nil
+	return todos, skipped.result()
 }
 
-// compareStartDateAsc orders two start dates ascending, ranking a nil date last.
-func compareStartDateAsc(a, b *time.Time) int {
+// compareDateAsc orders two dates ascending, ranking a nil date last.
+func compareDateAsc(a, b *time.Time) int {
 	switch {
 	case a == nil && b == nil:
 		return 0
@@ -59,3 +61,33 @@ func compareStartDateAsc(a, b *time.Time) int {
 		return a.Compare(*b)
 	}
 }
+
+// UpcomingDeadlines returns the incomplete todos whose deadline falls within
+// the horizon: from today (inclusive) through today plus within (inclusive),
+// sorted by deadline ascending. within is truncated to whole days, so 0 yields
+// todos due today and 7*24*time.Hour yields "due this week". Overdue todos are
+// not included; see Today for those. The result is never nil.
+func (c *Client) UpcomingDeadlines(ctx context.Context, within time.Duration) ([]Todo, error) {
+	days := max(int(within/(24*time.Hour)), 0)
+	from := c.today()
+	until := from.AddDate(0, 0, days+1)
+
+	return c.database.Todos().
+		deadlineBetween(from, until).
+		Status().Incomplete().
+		OrderByDeadline(false).
+		All(ctx)
+}
+
+// UnscheduledDeadlines returns the incomplete todos that have a deadline but
+// no start date: work that is due yet was never scheduled, earliest deadline
+// first. Overdue ones are included, so some also appear in Today. The result
+// is never nil.
+func (c *Client) UnscheduledDeadlines(ctx context.Context) ([]Todo, error) {
+	return c.database.Todos().
+		Deadline().Exists(true).
+		StartDate().Exists(false).
+		Status().Incomplete().
+		OrderByDeadline(false).
+		All(ctx)
+}
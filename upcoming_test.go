@@ -35,7 +35,7 @@ func TestClientUpcoming(t *testing.T) {
 
 	// The merged result is sorted ascending by start date.
 	assert.Truef(t, slices.IsSortedFunc(todos, func(a, b Todo) int {
-		return compareStartDateAsc(a.StartDate, b.StartDate)
+		return compareDateAsc(a.StartDate, b.StartDate)
 	}), "upcoming todos must be sorted ascending by start date")
 
 	// The repeating template surfaces its next occurrence as its start date.
@@ -70,3 +70,63 @@ func findTodo(todos []Todo, uuid string) *Todo {
 	}
 	return &todos[i]
 }
+
+// packThingsDate encodes a calendar date in the Things packed-date format
+// (year<<16 | month<<12 | day<<7) used by the startDate and deadline columns.
+func packThingsDate(t time.Time) int64 {
+	return int64(t.Year())<<16 | int64(t.Month())<<12 | int64(t.Day())<<7
+}
+
+func TestClientUpcomingDeadlines(t *testing.T) {
+	dbPath := copyWritableFixture(t)
+	today := Today()
+	deadlines := map[string]time.Time{
+		testUUIDTodoInbox:       today.AddDate(0, 0, 7),
+		testUUIDTodoAnytime:     today,
+		testUUIDTodoInArea1:     today.AddDate(0, 0, 3),
+		testUUIDTodoInArea3:     today.AddDate(0, 0, 8),
+		testUUIDTodoInArea1Tags: today.AddDate(0, 0, -1),
+	}
+	for uuid, deadline := range deadlines {
+		execFixtureSQL(t, dbPath, "UPDATE TMTask SET deadline = ? WHERE uuid = ?", packThingsDate(deadline), uuid)
+	}
+
+	client, err := NewClient(WithDatabasePath(dbPath))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+
+	tests := []struct {
+		name   string
+		within time.Duration
+		want   []string
+	}{
+		{"today only", 0, []string{testUUIDTodoAnytime}},
+		{"this week is inclusive of both ends", 7 * 24 * time.Hour, []string{testUUIDTodoAnytime, testUUIDTodoInArea1, testUUIDTodoInbox}},
+		{"partial days truncate", 3*24*time.Hour + 23*time.Hour, []string{testUUIDTodoAnytime, testUUIDTodoInArea1}},
+		{"negative is today", -time.Hour, []string{testUUIDTodoAnytime}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			todos, err := client.UpcomingDeadlines(t.Context(), tt.within)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, extractTodoUUIDs(todos))
+		})
+	}
+}
+
+func TestClientUnscheduledDeadlines(t *testing.T) {
+	client := newTestClient(t)
+
+	todos, err := client.UnscheduledDeadlines(t.Context())
+	require.NoError(t, err)
+	uuids := extractTodoUUIDs(todos)
+	require.Len(t, uuids, 3)
+	assert.ElementsMatch(t, []string{testUUIDTodoOverdueInToday, testUUIDTodoOverdueNotToday}, uuids[:2],
+		"the two overdue todos share the earliest deadline")
+	assert.Equal(t, testUUIDTodoInHeading, uuids[2])
+	for i := range todos {
+		assert.NotNil(t, todos[i].Deadline)
+		assert.Nil(t, todos[i].StartDate)
+	}
+}
@@ -0,0 +1,118 @@
+package things3
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"time"
+)
+
+// defaultWatchInterval is how often Watch polls when WithWatchInterval is not set.
+const defaultWatchInterval = 2 * time.Second
+
+// ChangeEvent reports that the Things database changed since the previous
+// event, or since Watch was called.
+type ChangeEvent struct {
+	// LastModified is the latest modification time of any todo, project or
+	// heading when the change was seen. It can stay the same across events,
+	// for example when items were deleted from the Trash.
+	LastModified time.Time `json:"last_modified"`
+}
+
+// watchState is what Watch compares between polls: the database and
+// write-ahead log file stats, and the latest task modification time.
+type watchState struct {
+	dbModTime    time.Time
+	walModTime   time.Time
+	walSize      int64
+	lastModified time.Time
+}
+
+func (s watchState) equal(o watchState) bool {
+	return s.dbModTime.Equal(o.dbModTime) &&
+		s.walModTime.Equal(o.walModTime) &&
+		s.walSize == o.walSize &&
+		s.lastModified.Equal(o.lastModified)
+}
+
+// Watch polls the database for changes and sends a ChangeEvent on the
+// returned channel for each poll that finds one. A change is a new
+// modification time on the database or its write-ahead log, where Things
+// writes first, or a newer task modification date. Events are not buffered:
+// changes made while the receiver is busy collapse into the next event. The
+// channel closes when ctx is done. Polls that fail, for example while Things
+// holds a lock, are skipped.
+//
+// The interval defaults to two seconds; see WithWatchInterval.
+//
+// Example:
+//
+//	events, err := client.Watch(ctx)
+//	if err != nil {
+//	    return err
+//	}
+//	for range events {
+//	    refresh()
+//	}
+func (c *Client) Watch(ctx context.Context) (<-chan ChangeEvent, error) {
+	last, err := c.pollWatchState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	interval := c.watchInterval
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+
+	events := make(chan ChangeEvent)
+	go func() {
+		defer close(events)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			state, err := c.pollWatchState(ctx)
+			if err != nil || state.equal(last) {
+				continue
+			}
+			last = state
+			select {
+			case events <- ChangeEvent{LastModified: state.lastModified}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events, nil
+}
+
+// pollWatchState reads the current watchState. A missing write-ahead log is
+// not an error; it is absent whenever SQLite has checkpointed and removed it.
+func (c *Client) pollWatchState(ctx context.Context) (watchState, error) {
+	path := c.database.Filepath()
+	info, err := os.Stat(path)
+	if err != nil {
+		return watchState{}, err
+	}
+	state := watchState{dbModTime: info.ModTime()}
+
+	wal, err := os.Stat(path + "-wal")
+	switch {
+	case err == nil:
+		state.walModTime = wal.ModTime()
+		state.walSize = wal.Size()
+	case !errors.Is(err, fs.ErrNotExist):
+		return watchState{}, err
+	}
+
+	state.lastModified, err = c.database.inner.LastModified(ctx)
+	if err != nil {
+		return watchState{}, err
+	}
+	return state, nil
+}
@@ -0,0 +1,64 @@
+package things3
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientWatch(t *testing.T) {
+	dbPath := copyWritableFixture(t)
+	client, err := NewClient(WithDatabasePath(dbPath), WithWatchInterval(10*time.Millisecond))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	events, err := client.Watch(ctx)
+	require.NoError(t, err)
+
+	select {
+	case ev := <-events:
+		t.Fatalf("unexpected event without a change: %+v", ev)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	modified := time.Date(2030, time.January, 2, 3, 4, 5, 0, time.UTC)
+	execFixtureSQL(t, dbPath, `UPDATE TMTask SET title = 'Changed', userModificationDate = ? WHERE uuid = ?`,
+		float64(modified.Unix()), testUUIDTodoInToday)
+
+	// A poll can catch the write-ahead log growing before the commit is
+	// visible, which yields an event for the file change first.
+	timeout := time.After(5 * time.Second)
+	for seen := false; !seen; {
+		select {
+		case ev, ok := <-events:
+			require.True(t, ok)
+			seen = modified.Equal(ev.LastModified)
+		case <-timeout:
+			t.Fatal("no event carrying the new modification time")
+		}
+	}
+
+	cancel()
+	select {
+	case _, ok := <-events:
+		assert.False(t, ok, "channel must close once the context is canceled")
+	case <-time.After(5 * time.Second):
+		t.Fatal("channel not closed after cancel")
+	}
+}
+
+func TestClientWatchClosedDatabase(t *testing.T) {
+	dbPath := copyWritableFixture(t)
+	client, err := NewClient(WithDatabasePath(dbPath))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+
+	client.database.inner.Close()
+	_, err = client.Watch(t.Context())
+	assert.Error(t, err, "Watch reports a failing first poll")
+}